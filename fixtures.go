@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// defaultFixtureSeed is the seed the fixtures command derives the fixtures from by default, see
+// prover.GenerateFixtures.
+const defaultFixtureSeed = "keyless/fixtures/v1"
+
+// fixturesConfig is the configuration of the fixtures command.
+type fixturesConfig struct {
+	seed   string
+	outDir string
+}
+
+// parseFixturesFlags parses the flags of the fixtures command.
+func parseFixturesFlags(args []string, stderr io.Writer) (fixturesConfig, error) {
+	cfg := fixturesConfig{seed: defaultFixtureSeed, outDir: "fixtures"}
+
+	fs := flag.NewFlagSet("fixtures", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&cfg.seed, "seed", cfg.seed, "seed the fixtures are derived from")
+	fs.StringVar(&cfg.outDir, "out", cfg.outDir, "directory the fixtures are written to")
+	if err := fs.Parse(args); err != nil {
+		return fixturesConfig{}, err
+	}
+	if fs.NArg() > 0 {
+		err := fmt.Errorf("unexpected arguments %v", fs.Args())
+		fmt.Fprintf(stderr, "fixtures: %v\n", err)
+		fs.Usage()
+		return fixturesConfig{}, err
+	}
+
+	return cfg, nil
+}
@@ -1,7 +1,126 @@
 package main
 
-import "github.com/hblocks/keyless/pkg/zk/prover/kProof"
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/logger"
+	"github.com/hblocks/keyless/pkg/selftest"
+	prover "github.com/hblocks/keyless/pkg/zk/prover"
+	"github.com/hblocks/keyless/pkg/zk/prover/kProof"
+)
+
+const usage = `usage: keyless [command] [flags]
+
+Without a command, the ownership circuit templates are generated.
+
+commands:
+  prove     prove the demo circuit, see keyless prove -h
+  fixtures  write reproducible proof fixtures for tests, see keyless fixtures -h
+  selftest  run the commitment and proof self-checks
+`
 
 func main() {
-	kProof.BlsVerify()
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command given by args and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		kProof.BlsVerify()
+		return 0
+	}
+
+	switch args[0] {
+	case "prove":
+		cfg, err := parseProveFlags(args[1:], stderr)
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		if err != nil {
+			return 2
+		}
+
+		// the demo proves knowledge of x = 3 such that x³ + x + 5 = 35
+		if err := prover.ProveAndExport(cfg, "cubic/v1", &prover.CubicCircuit{}, prover.NewCubicAssignment(big.NewInt(3))); err != nil {
+			fmt.Fprintf(stderr, "prove: %v\n", err)
+			return 1
+		}
+		return 0
+	case "fixtures":
+		cfg, err := parseFixturesFlags(args[1:], stderr)
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		if err != nil {
+			return 2
+		}
+
+		if err := prover.GenerateFixtures(cfg.outDir, cfg.seed); err != nil {
+			fmt.Fprintf(stderr, "fixtures: %v\n", err)
+			return 1
+		}
+		return 0
+	case "selftest":
+		logger.Disable() // keep the summary readable
+		if !selftest.WriteSummary(stdout, selftest.Run(selftest.DefaultChecks())) {
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// parseProveFlags parses the flags of the prove command into a prover configuration. Invalid
+// values, or a backend that does not support the curve, are reported along with the usage.
+func parseProveFlags(args []string, stderr io.Writer) (prover.ProverConfig, error) {
+	cfg := prover.DefaultConfig()
+
+	fs := flag.NewFlagSet("prove", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	curve := fs.String("curve", cfg.Curve.String(), "curve to prove over, e.g. bn254 or bls12-381")
+	backendName := fs.String("backend", cfg.Backend.String(), "proving backend, groth16 or plonk")
+	fs.StringVar(&cfg.OutDir, "out", cfg.OutDir, "directory the artifacts are written to")
+	fs.BoolVar(&cfg.InsecureSRS, "insecure-srs", false, "let plonk use an srs with a known toxic value, proofs can be forged: demos only")
+	if err := fs.Parse(args); err != nil {
+		return prover.ProverConfig{}, err
+	}
+
+	fail := func(err error) (prover.ProverConfig, error) {
+		fmt.Fprintf(stderr, "prove: %v\n", err)
+		fs.Usage()
+		return prover.ProverConfig{}, err
+	}
+
+	if fs.NArg() > 0 {
+		return fail(fmt.Errorf("unexpected arguments %v", fs.Args()))
+	}
+
+	id, err := ecc.IDFromString(strings.ReplaceAll(*curve, "-", "_"))
+	if err != nil {
+		return fail(fmt.Errorf("%w: %q", err, *curve))
+	}
+	cfg.Curve = id
+
+	if cfg.Backend = backend.IDFromString(strings.ToLower(*backendName)); cfg.Backend == backend.UNKNOWN {
+		return fail(fmt.Errorf("unknown backend %q", *backendName))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fail(err)
+	}
+	if cfg.InsecureSRS {
+		fmt.Fprintln(stderr, "WARNING: --insecure-srs: the srs toxic value is known, anyone can forge proofs for these keys")
+	}
+
+	return cfg, nil
 }
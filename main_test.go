@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	prover "github.com/hblocks/keyless/pkg/zk/prover"
+	"github.com/hblocks/keyless/pkg/zk/verifier"
+)
+
+func TestParseProveFlags(t *testing.T) {
+	var stderr bytes.Buffer
+
+	cfg, err := parseProveFlags([]string{"--curve", "bls12-381", "--backend", "plonk", "--insecure-srs", "--out", "./artifacts-plonk"}, &stderr)
+	if err != nil {
+		t.Fatalf("unable to parse flags: %v", err)
+	}
+	if cfg.Curve != ecc.BLS12_381 || cfg.Backend != backend.PLONK || cfg.OutDir != "./artifacts-plonk" || !cfg.InsecureSRS {
+		t.Fatalf("unexpected config %s/%s in %s", cfg.Curve, cfg.Backend, cfg.OutDir)
+	}
+	if !strings.Contains(stderr.String(), "WARNING") {
+		t.Fatalf("no warning printed for the insecure srs, got %q", stderr.String())
+	}
+
+	cfg, err = parseProveFlags(nil, &stderr)
+	if err != nil {
+		t.Fatalf("unable to parse empty flags: %v", err)
+	}
+	if cfg.Curve != ecc.BN254 || cfg.Backend != backend.GROTH16 || cfg.OutDir != prover.DefaultOutDir {
+		t.Fatalf("unexpected default config %s/%s in %s", cfg.Curve, cfg.Backend, cfg.OutDir)
+	}
+
+	for name, args := range map[string][]string{
+		"unknown curve":       {"--curve", "ed25519"},
+		"unknown backend":     {"--backend", "stark"},
+		"curve without proof": {"--curve", "secp256k1"},
+		"extra argument":      {"now"},
+		"plonk without srs":   {"--backend", "plonk"},
+	} {
+		stderr.Reset()
+		if _, err := parseProveFlags(args, &stderr); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+		if !strings.Contains(stderr.String(), "-backend") {
+			t.Fatalf("%s: usage not printed, got %q", name, stderr.String())
+		}
+	}
+
+	// the rule lives in ProverConfig.Validate, whose error points at the flag
+	stderr.Reset()
+	if _, err := parseProveFlags([]string{"--backend", "plonk"}, &stderr); !errors.Is(err, prover.ErrInvalidConfig) {
+		t.Fatalf("expected %v, got %v", prover.ErrInvalidConfig, err)
+	}
+	if !strings.Contains(stderr.String(), "--insecure-srs") {
+		t.Fatalf("error does not point at --insecure-srs, got %q", stderr.String())
+	}
+
+	if _, err := parseProveFlags([]string{"-h"}, &stderr); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected %v, got %v", flag.ErrHelp, err)
+	}
+}
+
+func TestRunInvalidCommand(t *testing.T) {
+	var stderr bytes.Buffer
+
+	if code := run([]string{"prove", "--curve", "ed25519"}, &stderr, &stderr); code == 0 {
+		t.Fatal("invalid flags exited with 0")
+	}
+	if code := run([]string{"unknown"}, &stderr, &stderr); code == 0 {
+		t.Fatal("unknown command exited with 0")
+	}
+}
+
+func TestRunSelftest(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"selftest"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("selftest exited with %d:\n%s%s", code, stdout.String(), stderr.String())
+	}
+	if strings.Contains(stdout.String(), "FAIL") {
+		t.Fatalf("selftest reported a failure:\n%s", stdout.String())
+	}
+}
+
+// TestRunFixtures regenerates the fixtures checked in for the verifier tests and checks that they
+// are byte for byte the same, i.e. that they are reproducible and up to date.
+func TestRunFixtures(t *testing.T) {
+	var stderr bytes.Buffer
+
+	dir := t.TempDir()
+	if code := run([]string{"fixtures", "-out", dir}, &stderr, &stderr); code != 0 {
+		t.Fatalf("fixtures exited with %d:\n%s", code, stderr.String())
+	}
+
+	names := []string{prover.ProofFile, prover.VerifyingKeyFile, prover.PublicWitnessBinFile, prover.PublicWitnessJSONFile, verifier.ProofCalldataFile}
+	for _, name := range names {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", name, err)
+		}
+		want, err := os.ReadFile(filepath.Join("pkg", "zk", "verifier", "testdata", name))
+		if err != nil {
+			t.Fatalf("unable to read checked in %s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s differs from the checked in fixture, run go generate ./pkg/zk/verifier", name)
+		}
+	}
+
+	other := t.TempDir()
+	if code := run([]string{"fixtures", "-seed", "another seed", "-out", other}, &stderr, &stderr); code != 0 {
+		t.Fatalf("fixtures exited with %d:\n%s", code, stderr.String())
+	}
+	for _, name := range []string{prover.ProofFile, prover.VerifyingKeyFile, prover.PublicWitnessBinFile} {
+		a, _ := os.ReadFile(filepath.Join(dir, name))
+		b, err := os.ReadFile(filepath.Join(other, name))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", name, err)
+		}
+		if bytes.Equal(a, b) {
+			t.Fatalf("%s does not depend on the seed", name)
+		}
+	}
+
+	if code := run([]string{"fixtures", "now"}, &stderr, &stderr); code != 2 {
+		t.Fatalf("extra argument exited with %d, want 2", code)
+	}
+}
@@ -0,0 +1,32 @@
+// Package commitment abstracts over the commitment schemes implemented in its subpackages, so that
+// callers can switch between Pedersen and KZG without changing code.
+package commitment
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ErrWrongScheme denotes a commitment or proof produced by another scheme than the verifying one.
+var ErrWrongScheme = errors.New("commitment or proof of another scheme")
+
+// Commitment is a scheme specific commitment to a vector of values.
+type Commitment interface {
+	Marshal() []byte
+}
+
+// Proof is a scheme specific proof that a commitment opens to given values.
+type Proof interface {
+	Marshal() []byte
+}
+
+// CommitmentScheme commits to vectors of field elements and proves openings of the commitments.
+type CommitmentScheme interface {
+	// Commit commits to values.
+	Commit(values []fr.Element) (Commitment, error)
+	// Open proves that the commitment to values opens to them.
+	Open(values []fr.Element) (Proof, error)
+	// Verify checks that c is a commitment to values, using the proof returned by Open.
+	Verify(c Commitment, values []fr.Element, proof Proof) error
+}
@@ -0,0 +1,95 @@
+package commitment_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/hblocks/keyless/pkg/commitment"
+	"github.com/hblocks/keyless/pkg/commitment/kzg"
+	"github.com/hblocks/keyless/pkg/commitment/pedersen"
+)
+
+const nbValues = 4
+
+func newPedersenScheme(t *testing.T) commitment.CommitmentScheme {
+	t.Helper()
+
+	bases := make([]bn254.G1Affine, nbValues)
+	for i := range bases {
+		bases[i].ScalarMultiplicationBase(big.NewInt(int64(i + 2)))
+	}
+	pk, vk, err := pedersen_bn254.Setup([][]bn254.G1Affine{bases})
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	return pedersen.NewScheme(pk[0], vk)
+}
+
+func newKZGScheme(t *testing.T) commitment.CommitmentScheme {
+	t.Helper()
+
+	srs, err := kzg_bn254.NewSRS(nbValues, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("unable to create srs: %v", err)
+	}
+	return kzg.NewScheme(srs)
+}
+
+func newValues(values ...uint64) []fr.Element {
+	res := make([]fr.Element, len(values))
+	for i, v := range values {
+		res[i].SetUint64(v)
+	}
+	return res
+}
+
+func TestCommitmentSchemes(t *testing.T) {
+	schemes := map[string]func(*testing.T) commitment.CommitmentScheme{
+		"pedersen": newPedersenScheme,
+		"kzg":      newKZGScheme,
+	}
+
+	for name, newScheme := range schemes {
+		t.Run(name, func(t *testing.T) {
+			scheme := newScheme(t)
+			values := newValues(1, 2, 3, 4)
+
+			c, err := scheme.Commit(values)
+			if err != nil {
+				t.Fatalf("unable to commit: %v", err)
+			}
+			proof, err := scheme.Open(values)
+			if err != nil {
+				t.Fatalf("unable to open: %v", err)
+			}
+			if err := scheme.Verify(c, values, proof); err != nil {
+				t.Fatalf("valid opening rejected: %v", err)
+			}
+
+			if err := scheme.Verify(c, newValues(1, 2, 3, 5), proof); err == nil {
+				t.Fatal("opening to other values accepted")
+			}
+
+			other, err := scheme.Commit(newValues(4, 3, 2, 1))
+			if err != nil {
+				t.Fatalf("unable to commit: %v", err)
+			}
+			if err := scheme.Verify(other, values, proof); err == nil {
+				t.Fatal("proof accepted for another commitment")
+			}
+
+			if err := scheme.Verify(c, values, foreignProof{}); !errors.Is(err, commitment.ErrWrongScheme) {
+				t.Fatalf("expected %v, got %v", commitment.ErrWrongScheme, err)
+			}
+		})
+	}
+}
+
+type foreignProof struct{}
+
+func (foreignProof) Marshal() []byte { return nil }
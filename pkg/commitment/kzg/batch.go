@@ -0,0 +1,73 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// ErrBatchLength denotes a batch whose commitments, proofs and evaluations have different lengths.
+var ErrBatchLength = errors.New("batch length mismatch")
+
+// BatchVerifyAtPoint verifies independent opening proofs of several commitments at the same point
+// with a single pairing check. kzg_bn254.BatchVerifySinglePoint needs one quotient computed over
+// all polynomials by the prover; here every proof was made on its own, so the verifier folds them
+// with powers of a random γ instead:
+//
+//	Σ γⁱ⋅Cᵢ opens to Σ γⁱ⋅evals[i] at point with quotient Σ γⁱ⋅Hᵢ
+//
+// Each proof must also claim the value given in evals.
+func BatchVerifyAtPoint(commits []kzg_bn254.Digest, proofs []kzg_bn254.OpeningProof, point fr.Element, evals []fr.Element, vk kzg_bn254.VerifyingKey) error {
+	if len(commits) != len(proofs) || len(commits) != len(evals) {
+		return fmt.Errorf("%w: %d commitments, %d proofs, %d evaluations",
+			ErrBatchLength, len(commits), len(proofs), len(evals))
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("%w: empty batch", ErrBatchLength)
+	}
+
+	for i := range proofs {
+		if !proofs[i].ClaimedValue.Equal(&evals[i]) {
+			return fmt.Errorf("%w: proof %d claims %s, expected %s",
+				ErrClaimedValueMismatch, i, proofs[i].ClaimedValue.String(), evals[i].String())
+		}
+	}
+
+	var gamma fr.Element
+	if _, err := gamma.SetRandom(); err != nil {
+		return fmt.Errorf("unable to sample folding challenge: %w", err)
+	}
+	powers := make([]fr.Element, len(commits))
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &gamma)
+	}
+
+	quotients := make([]kzg_bn254.Digest, len(proofs))
+	for i := range proofs {
+		quotients[i] = proofs[i].H
+	}
+
+	var folded kzg_bn254.OpeningProof
+	if _, err := folded.H.MultiExp(quotients, powers, ecc.MultiExpConfig{}); err != nil {
+		return fmt.Errorf("unable to fold proofs: %w", err)
+	}
+	for i := range evals {
+		var term fr.Element
+		term.Mul(&evals[i], &powers[i])
+		folded.ClaimedValue.Add(&folded.ClaimedValue, &term)
+	}
+
+	digest, err := FoldCommitments(commits, powers)
+	if err != nil {
+		return err
+	}
+	if err := kzg_bn254.Verify(&digest, &folded, point, vk); err != nil {
+		return fmt.Errorf("unable to verify batch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package kzg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+func TestBatchVerifyAtPoint(t *testing.T) {
+	srs := newTestSRS(t, 8)
+	polys := []polynomial.Polynomial{
+		NewPolynomial(1, 2, 3),
+		NewPolynomial(4, 5, 6, 7),
+		NewPolynomial(8, 9),
+	}
+
+	var point fr.Element
+	point.SetUint64(11)
+
+	commits, err := CommitMany(polys, srs)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	proofs := make([]kzg_bn254.OpeningProof, len(polys))
+	evals := make([]fr.Element, len(polys))
+	for i := range polys {
+		if proofs[i], err = Open(polys[i], point, srs.Pk); err != nil {
+			t.Fatalf("unable to open polynomial %d: %v", i, err)
+		}
+		evals[i] = Eval(polys[i], point)
+	}
+
+	if err := BatchVerifyAtPoint(commits, proofs, point, evals, srs.Vk); err != nil {
+		t.Fatalf("batch rejected: %v", err)
+	}
+
+	// a tampered quotient is caught by the folded pairing check
+	tampered := make([]kzg_bn254.OpeningProof, len(proofs))
+	copy(tampered, proofs)
+	tampered[1].H.Add(&tampered[1].H, &srs.Pk.G1[0])
+	if err := BatchVerifyAtPoint(commits, tampered, point, evals, srs.Vk); err == nil {
+		t.Fatal("batch with a tampered proof verified")
+	}
+
+	// so is a proof claiming another value than the expected one
+	wrong := make([]fr.Element, len(evals))
+	copy(wrong, evals)
+	wrong[2].SetUint64(9)
+	if err := BatchVerifyAtPoint(commits, proofs, point, wrong, srs.Vk); !errors.Is(err, ErrClaimedValueMismatch) {
+		t.Fatalf("expected %v, got %v", ErrClaimedValueMismatch, err)
+	}
+
+	if err := BatchVerifyAtPoint(commits[:2], proofs, point, evals, srs.Vk); !errors.Is(err, ErrBatchLength) {
+		t.Fatalf("expected %v, got %v", ErrBatchLength, err)
+	}
+}
@@ -0,0 +1,85 @@
+package kzg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// benchSizes are the numbers of coefficients of the polynomials the benchmarks run with.
+var benchSizes = []int{16, 256, 4096}
+
+// newBenchPolynomial returns a random polynomial of size coefficients along with an SRS fitting it.
+func newBenchPolynomial(b *testing.B, size int) (polynomial.Polynomial, *kzg_bn254.SRS) {
+	b.Helper()
+
+	p := make(polynomial.Polynomial, size)
+	for i := range p {
+		if _, err := p[i].SetRandom(); err != nil {
+			b.Fatalf("unable to sample coefficient: %v", err)
+		}
+	}
+	return p, newTestSRS(b, uint64(size))
+}
+
+func BenchmarkKZGCommit(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			p, srs := newBenchPolynomial(b, size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := Commit(p, srs.Pk); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkKZGOpen(b *testing.B) {
+	var point fr.Element
+	point.SetUint64(5)
+
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			p, srs := newBenchPolynomial(b, size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := Open(p, point, srs.Pk); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkKZGVerify(b *testing.B) {
+	var point fr.Element
+	point.SetUint64(5)
+
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			p, srs := newBenchPolynomial(b, size)
+			digest, err := Commit(p, srs.Pk)
+			if err != nil {
+				b.Fatal(err)
+			}
+			proof, err := Open(p, point, srs.Pk)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := kzg_bn254.Verify(&digest, &proof, point, srs.Vk); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
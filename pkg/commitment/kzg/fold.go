@@ -0,0 +1,58 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// ErrLengthMismatch denotes commitments or polynomials folded with a different number of coefficients.
+var ErrLengthMismatch = errors.New("length mismatch")
+
+// CommitMany commits to every polynomial with the SRS.
+func CommitMany(polys []polynomial.Polynomial, srs *kzg_bn254.SRS) ([]kzg_bn254.Digest, error) {
+	digests := make([]kzg_bn254.Digest, len(polys))
+	for i := range polys {
+		digest, err := Commit(polys[i], srs.Pk)
+		if err != nil {
+			return nil, fmt.Errorf("polynomial %d: %w", i, err)
+		}
+		digests[i] = digest
+	}
+	return digests, nil
+}
+
+// FoldCommitments returns Σ coeffs[i]⋅digests[i]. KZG commitments are linear, so this is the
+// commitment to FoldPolynomials(polys, coeffs). It returns ErrLengthMismatch if the lengths differ.
+func FoldCommitments(digests []kzg_bn254.Digest, coeffs []fr.Element) (kzg_bn254.Digest, error) {
+	if len(digests) != len(coeffs) {
+		return kzg_bn254.Digest{}, fmt.Errorf("%w: folding %d commitments with %d coefficients", ErrLengthMismatch, len(digests), len(coeffs))
+	}
+
+	var folded kzg_bn254.Digest
+	if _, err := folded.MultiExp(digests, coeffs, ecc.MultiExpConfig{}); err != nil {
+		return kzg_bn254.Digest{}, fmt.Errorf("unable to fold commitments: %w", err)
+	}
+	return folded, nil
+}
+
+// FoldPolynomials returns Σ coeffs[i]⋅polys[i]. It returns ErrLengthMismatch if the lengths differ.
+func FoldPolynomials(polys []polynomial.Polynomial, coeffs []fr.Element) (polynomial.Polynomial, error) {
+	if len(polys) != len(coeffs) {
+		return nil, fmt.Errorf("%w: folding %d polynomials with %d coefficients", ErrLengthMismatch, len(polys), len(coeffs))
+	}
+
+	var folded polynomial.Polynomial
+	for i := range polys {
+		scaled := make(polynomial.Polynomial, len(polys[i]))
+		for j := range polys[i] {
+			scaled[j].Mul(&polys[i][j], &coeffs[i])
+		}
+		folded = Add(folded, scaled)
+	}
+	return folded, nil
+}
@@ -0,0 +1,60 @@
+package kzg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+)
+
+func TestFoldCommitments(t *testing.T) {
+	srs := newTestSRS(t, 8)
+	polys := []polynomial.Polynomial{
+		NewPolynomial(1, 2, 3),
+		NewPolynomial(4, 5, 6, 7, 8),
+		NewPolynomial(9),
+	}
+
+	digests, err := CommitMany(polys, srs)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if len(digests) != len(polys) {
+		t.Fatalf("got %d digests, want %d", len(digests), len(polys))
+	}
+
+	coeffs := make([]fr.Element, len(polys))
+	for i := range coeffs {
+		if _, err := coeffs[i].SetRandom(); err != nil {
+			t.Fatalf("unable to sample coefficient: %v", err)
+		}
+	}
+
+	foldedPoly, err := FoldPolynomials(polys, coeffs)
+	if err != nil {
+		t.Fatalf("unable to fold polynomials: %v", err)
+	}
+	want, err := Commit(foldedPoly, srs.Pk)
+	if err != nil {
+		t.Fatalf("unable to commit to folded polynomial: %v", err)
+	}
+	got, err := FoldCommitments(digests, coeffs)
+	if err != nil {
+		t.Fatalf("unable to fold commitments: %v", err)
+	}
+	if !got.Equal(&want) {
+		t.Fatal("folded commitment differs from the commitment to the folded polynomial")
+	}
+
+	if _, err := FoldCommitments(digests, coeffs[1:]); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+	if _, err := FoldPolynomials(polys[1:], coeffs); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+
+	if _, err := CommitMany(append(polys, NewPolynomial(0, 1, 2, 3, 4, 5, 6, 7, 8)), srs); err == nil {
+		t.Fatal("expected an error for a polynomial too large for the srs")
+	}
+}
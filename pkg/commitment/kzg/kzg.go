@@ -0,0 +1,66 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+var (
+	// ErrClaimedValueMismatch denotes that an opening proof claims a value the polynomial does not evaluate to.
+	ErrClaimedValueMismatch = errors.New("claimed value does not match polynomial evaluation")
+	// ErrDegreeTooLarge denotes that a polynomial has more coefficients than the SRS has powers.
+	ErrDegreeTooLarge = errors.New("polynomial degree too large for srs")
+)
+
+// Commit commits to p using the SRS proving key. Unlike the underlying gnark-crypto call, a
+// polynomial that does not fit in the SRS is reported with both its degree and the SRS capacity.
+func Commit(p polynomial.Polynomial, pk kzg_bn254.ProvingKey) (kzg_bn254.Digest, error) {
+	if err := checkDegree(p, pk); err != nil {
+		return kzg_bn254.Digest{}, err
+	}
+
+	digest, err := kzg_bn254.Commit(p, pk)
+	if err != nil {
+		return kzg_bn254.Digest{}, fmt.Errorf("unable to commit to polynomial: %w", err)
+	}
+
+	return digest, nil
+}
+
+// checkDegree makes sure p can be committed to with pk, i.e. len(p) <= len(pk.G1).
+func checkDegree(p polynomial.Polynomial, pk kzg_bn254.ProvingKey) error {
+	if len(p) > len(pk.G1) {
+		return fmt.Errorf("%w: polynomial has degree %d, srs supports at most degree %d",
+			ErrDegreeTooLarge, len(p)-1, len(pk.G1)-1)
+	}
+	return nil
+}
+
+// open is the underlying opening routine; kept as a variable so tests can substitute a faulty one.
+var open = kzg_bn254.Open
+
+// Open computes an opening proof of p at point and, before returning it, re-evaluates p locally
+// to make sure the proof claims the right value. A mismatch usually means the SRS or the polynomial
+// degree is misconfigured, and is reported here instead of as a failed verification later on.
+func Open(p polynomial.Polynomial, point fr.Element, pk kzg_bn254.ProvingKey) (kzg_bn254.OpeningProof, error) {
+	if err := checkDegree(p, pk); err != nil {
+		return kzg_bn254.OpeningProof{}, err
+	}
+
+	proof, err := open(p, point, pk)
+	if err != nil {
+		return kzg_bn254.OpeningProof{}, fmt.Errorf("unable to open polynomial: %w", err)
+	}
+
+	expected := Eval(p, point)
+	if !expected.Equal(&proof.ClaimedValue) {
+		return kzg_bn254.OpeningProof{}, fmt.Errorf("%w: p(%s) = %s, proof claims %s",
+			ErrClaimedValueMismatch, point.String(), expected.String(), proof.ClaimedValue.String())
+	}
+
+	return proof, nil
+}
@@ -0,0 +1,88 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+func newTestSRS(t testing.TB, size uint64) *kzg_bn254.SRS {
+	t.Helper()
+
+	srs, err := kzg_bn254.NewSRS(size, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("unable to create srs: %v", err)
+	}
+	return srs
+}
+
+func TestOpen(t *testing.T) {
+	srs := newTestSRS(t, 8)
+	p := NewPolynomial(1, 2, 3, 4)
+
+	var point fr.Element
+	point.SetUint64(5)
+
+	proof, err := Open(p, point, srs.Pk)
+	if err != nil {
+		t.Fatalf("unable to open: %v", err)
+	}
+
+	digest, err := Commit(p, srs.Pk)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if err := kzg_bn254.Verify(&digest, &proof, point, srs.Vk); err != nil {
+		t.Fatalf("opening proof rejected: %v", err)
+	}
+}
+
+func TestOpenDetectsCorruptedPolynomial(t *testing.T) {
+	defer func(f func(p []fr.Element, point fr.Element, pk kzg_bn254.ProvingKey) (kzg_bn254.OpeningProof, error)) {
+		open = f
+	}(open)
+
+	// open a corrupted copy of the polynomial, as a misconfigured pipeline would
+	open = func(p []fr.Element, point fr.Element, pk kzg_bn254.ProvingKey) (kzg_bn254.OpeningProof, error) {
+		corrupted := make([]fr.Element, len(p))
+		copy(corrupted, p)
+		corrupted[0].SetUint64(1337)
+		return kzg_bn254.Open(corrupted, point, pk)
+	}
+
+	srs := newTestSRS(t, 8)
+
+	var point fr.Element
+	point.SetUint64(5)
+
+	_, err := Open(NewPolynomial(1, 2, 3, 4), point, srs.Pk)
+	if !errors.Is(err, ErrClaimedValueMismatch) {
+		t.Fatalf("expected %v, got %v", ErrClaimedValueMismatch, err)
+	}
+}
+
+func TestCommitDegreeTooLarge(t *testing.T) {
+	// an SRS of size 9 holds powers up to α⁸, i.e. supports polynomials up to degree 8
+	srs := newTestSRS(t, 9)
+
+	p := NewPolynomial(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	if p.Degree() != 10 {
+		t.Fatalf("test polynomial has degree %d, want 10", p.Degree())
+	}
+
+	_, err := Commit(p, srs.Pk)
+	if !errors.Is(err, ErrDegreeTooLarge) {
+		t.Fatalf("expected %v, got %v", ErrDegreeTooLarge, err)
+	}
+	if want := "polynomial has degree 10, srs supports at most degree 8"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q does not contain %q", err, want)
+	}
+
+	if _, err := Commit(NewPolynomial(0, 1, 2, 3, 4, 5, 6, 7, 8), srs.Pk); err != nil {
+		t.Fatalf("unable to commit to degree-8 polynomial: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// ErrDomainSize denotes a Lagrange SRS size that is not a power of two, or evaluations that do not
+// cover the whole domain.
+var ErrDomainSize = errors.New("invalid evaluation domain size")
+
+// LagrangeSRS holds an SRS in Lagrange form: G1[i] = [Lᵢ(τ)]G₁, where Lᵢ is the i-th Lagrange
+// polynomial over the multiplicative subgroup of the Domain, in natural order ωⁱ.
+type LagrangeSRS struct {
+	SRS    *kzg_bn254.SRS
+	G1     []bn254.G1Affine
+	Domain *fft.Domain
+}
+
+// NewLagrangeSRS generates an SRS of the given size for the secret k, as GenerateSRS does, and
+// converts it to Lagrange form. size must be a power of two.
+func NewLagrangeSRS(size uint64, k *big.Int) (*LagrangeSRS, error) {
+	if bits.OnesCount64(size) != 1 {
+		return nil, fmt.Errorf("%w: %d is not a power of two", ErrDomainSize, size)
+	}
+
+	srs, err := GenerateSRS(size, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToLagrangeSRS(srs, size)
+}
+
+// ToLagrangeSRS converts the first size powers of an existing SRS, e.g. one produced by a
+// ceremony, to Lagrange form. size must be a power of two no larger than the SRS.
+func ToLagrangeSRS(srs *kzg_bn254.SRS, size uint64) (*LagrangeSRS, error) {
+	if bits.OnesCount64(size) != 1 {
+		return nil, fmt.Errorf("%w: %d is not a power of two", ErrDomainSize, size)
+	}
+	if size > uint64(len(srs.Pk.G1)) {
+		return nil, fmt.Errorf("%w: domain of size %d, srs has %d powers", ErrDomainSize, size, len(srs.Pk.G1))
+	}
+
+	g1, err := kzg_bn254.ToLagrangeG1(srs.Pk.G1[:size])
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert srs to lagrange form: %w", err)
+	}
+
+	return &LagrangeSRS{SRS: srs, G1: g1, Domain: fft.NewDomain(size)}, nil
+}
+
+// CommitLagrange commits to the polynomial taking the values evals on the domain of lsrs, without
+// interpolating it first. The digest equals Commit of the polynomial in coefficient form, so it
+// can be opened and verified as usual.
+func CommitLagrange(evals []fr.Element, lsrs *LagrangeSRS) (kzg_bn254.Digest, error) {
+	if len(evals) != len(lsrs.G1) {
+		return kzg_bn254.Digest{}, fmt.Errorf("%w: %d evaluations on a domain of size %d",
+			ErrDomainSize, len(evals), len(lsrs.G1))
+	}
+
+	var digest kzg_bn254.Digest
+	if _, err := digest.MultiExp(lsrs.G1, evals, ecc.MultiExpConfig{}); err != nil {
+		return kzg_bn254.Digest{}, fmt.Errorf("unable to commit to evaluations: %w", err)
+	}
+
+	return digest, nil
+}
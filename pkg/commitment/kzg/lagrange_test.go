@@ -0,0 +1,46 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestCommitLagrange(t *testing.T) {
+	lsrs, err := NewLagrangeSRS(8, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("unable to create lagrange srs: %v", err)
+	}
+
+	p := NewPolynomial(3, 1, 4, 1, 5, 9, 2, 6)
+
+	// evaluate p on the domain, in natural order ωⁱ
+	evals := make([]fr.Element, lsrs.Domain.Cardinality)
+	var x fr.Element
+	x.SetOne()
+	for i := range evals {
+		evals[i] = Eval(p, x)
+		x.Mul(&x, &lsrs.Domain.Generator)
+	}
+
+	got, err := CommitLagrange(evals, lsrs)
+	if err != nil {
+		t.Fatalf("unable to commit to evaluations: %v", err)
+	}
+	want, err := Commit(p, lsrs.SRS.Pk)
+	if err != nil {
+		t.Fatalf("unable to commit to coefficients: %v", err)
+	}
+	if !got.Equal(&want) {
+		t.Fatal("lagrange commitment differs from the coefficient-form commitment")
+	}
+
+	if _, err := CommitLagrange(evals[:4], lsrs); !errors.Is(err, ErrDomainSize) {
+		t.Fatalf("expected %v, got %v", ErrDomainSize, err)
+	}
+	if _, err := NewLagrangeSRS(6, big.NewInt(42)); !errors.Is(err, ErrDomainSize) {
+		t.Fatalf("expected %v, got %v", ErrDomainSize, err)
+	}
+}
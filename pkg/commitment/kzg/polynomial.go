@@ -0,0 +1,56 @@
+package kzg
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+)
+
+// NewPolynomial builds a polynomial from its coefficients in ascending order of degree,
+// i.e. NewPolynomial(1, 2, 3) is 1 + 2x + 3x².
+func NewPolynomial(coeffs ...uint64) polynomial.Polynomial {
+	p := make(polynomial.Polynomial, len(coeffs))
+	for i, c := range coeffs {
+		p[i].SetUint64(c)
+	}
+	return p
+}
+
+// Add returns f + g. Neither input is modified.
+func Add(f, g polynomial.Polynomial) polynomial.Polynomial {
+	bigger, smaller := f, g
+	if len(bigger) < len(smaller) {
+		bigger, smaller = smaller, bigger
+	}
+
+	res := make(polynomial.Polynomial, len(bigger))
+	copy(res, bigger)
+	for i := range smaller {
+		res[i].Add(&res[i], &smaller[i])
+	}
+	return res
+}
+
+// Mul returns f * g using schoolbook multiplication. Neither input is modified.
+func Mul(f, g polynomial.Polynomial) polynomial.Polynomial {
+	if len(f) == 0 || len(g) == 0 {
+		return polynomial.Polynomial{}
+	}
+
+	res := make(polynomial.Polynomial, len(f)+len(g)-1)
+	var tmp fr.Element
+	for i := range f {
+		for j := range g {
+			tmp.Mul(&f[i], &g[j])
+			res[i+j].Add(&res[i+j], &tmp)
+		}
+	}
+	return res
+}
+
+// Eval returns f(x). The empty polynomial evaluates to zero.
+func Eval(f polynomial.Polynomial, x fr.Element) fr.Element {
+	if len(f) == 0 {
+		return fr.Element{}
+	}
+	return f.Eval(&x)
+}
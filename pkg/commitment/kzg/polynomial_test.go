@@ -0,0 +1,58 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+)
+
+func TestPolynomialArithmetic(t *testing.T) {
+	cases := []struct {
+		name string
+		f, g polynomial.Polynomial
+	}{
+		{"constants", NewPolynomial(3), NewPolynomial(7)},
+		{"linear by quadratic", NewPolynomial(1, 2), NewPolynomial(5, 0, 4)},
+		{"different degrees", NewPolynomial(9, 8, 7, 6, 5), NewPolynomial(0, 1)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, v := range []uint64{0, 1, 2, 1 << 40} {
+				var x fr.Element
+				x.SetUint64(v)
+
+				fx, gx := Eval(tc.f, x), Eval(tc.g, x)
+
+				var want fr.Element
+				want.Mul(&fx, &gx)
+				if got := Eval(Mul(tc.f, tc.g), x); !got.Equal(&want) {
+					t.Fatalf("(f*g)(%d) = %s, want %s", v, got.String(), want.String())
+				}
+
+				want.Add(&fx, &gx)
+				if got := Eval(Add(tc.f, tc.g), x); !got.Equal(&want) {
+					t.Fatalf("(f+g)(%d) = %s, want %s", v, got.String(), want.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPolynomialDegree(t *testing.T) {
+	f := NewPolynomial(1, 2, 3)
+	g := NewPolynomial(4, 5)
+
+	fg := Mul(f, g)
+	if got := fg.Degree(); got != 3 {
+		t.Fatalf("degree of f*g = %d, want 3", got)
+	}
+	sum := Add(f, g)
+	if got := sum.Degree(); got != 2 {
+		t.Fatalf("degree of f+g = %d, want 2", got)
+	}
+	if got := Eval(polynomial.Polynomial{}, fr.One()); !got.IsZero() {
+		t.Fatalf("empty polynomial evaluated to %s, want 0", got.String())
+	}
+}
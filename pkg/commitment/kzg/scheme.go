@@ -0,0 +1,88 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/hblocks/keyless/pkg/commitment"
+	"golang.org/x/crypto/sha3"
+)
+
+var _ commitment.CommitmentScheme = (*scheme)(nil)
+
+// OpeningProof is the proof returned by the KZG scheme: an opening of the polynomial at a point
+// derived from the commitment.
+type OpeningProof struct {
+	kzg_bn254.OpeningProof
+}
+
+// Marshal returns the encoding of the quotient commitment followed by the claimed value.
+func (p *OpeningProof) Marshal() []byte {
+	claimed := p.ClaimedValue.Bytes()
+	return append(p.H.Marshal(), claimed[:]...)
+}
+
+type scheme struct {
+	srs *kzg_bn254.SRS
+}
+
+// NewScheme returns the KZG commitment scheme for the SRS. The values are the coefficients of a
+// polynomial; commitments are *kzg_bn254.Digest and proofs *OpeningProof.
+func NewScheme(srs *kzg_bn254.SRS) commitment.CommitmentScheme {
+	return &scheme{srs: srs}
+}
+
+func (s *scheme) Commit(values []fr.Element) (commitment.Commitment, error) {
+	digest, err := Commit(values, s.srs.Pk)
+	if err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
+
+func (s *scheme) Open(values []fr.Element) (commitment.Proof, error) {
+	digest, err := Commit(values, s.srs.Pk)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := Open(values, challengePoint(&digest), s.srs.Pk)
+	if err != nil {
+		return nil, err
+	}
+	return &OpeningProof{OpeningProof: proof}, nil
+}
+
+func (s *scheme) Verify(c commitment.Commitment, values []fr.Element, proof commitment.Proof) error {
+	digest, ok := c.(*kzg_bn254.Digest)
+	if !ok {
+		return fmt.Errorf("%w: commitment is %T", commitment.ErrWrongScheme, c)
+	}
+	opening, ok := proof.(*OpeningProof)
+	if !ok {
+		return fmt.Errorf("%w: proof is %T", commitment.ErrWrongScheme, proof)
+	}
+
+	point := challengePoint(digest)
+	if expected := Eval(values, point); !expected.Equal(&opening.ClaimedValue) {
+		return errors.New("commitment does not open to the values")
+	}
+
+	if err := kzg_bn254.Verify(digest, &opening.OpeningProof, point, s.srs.Vk); err != nil {
+		return fmt.Errorf("unable to verify opening: %w", err)
+	}
+
+	return nil
+}
+
+// challengePoint derives the opening point from the commitment (Fiat-Shamir), so the prover cannot choose it.
+func challengePoint(digest *kzg_bn254.Digest) fr.Element {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(digest.Marshal())
+
+	var point fr.Element
+	point.SetBytes(h.Sum(nil))
+	return point
+}
@@ -0,0 +1,231 @@
+package kzg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+var (
+	// ErrInvalidSRS denotes an SRS whose powers are not consistent with its verifying key.
+	ErrInvalidSRS = errors.New("invalid srs")
+	// ErrInvalidContribution denotes a ceremony contribution that does not extend the previous one.
+	ErrInvalidContribution = errors.New("invalid srs contribution")
+	// ErrInvalidSecret denotes an SRS secret that is degenerate or not a canonical field element.
+	ErrInvalidSecret = errors.New("invalid srs secret")
+)
+
+// GenerateSRS returns an SRS of the given size for the secret k. With k = 0 every power is the point
+// at infinity and with k = 1 every power is the generator, so both are rejected, as well as values
+// that are not reduced modulo the scalar field. A nil k samples a random secret, resampling until
+// it is not degenerate.
+func GenerateSRS(size uint64, k *big.Int) (*kzg_bn254.SRS, error) {
+	if k == nil {
+		var err error
+		if k, err = randomSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkSecret(k); err != nil {
+		return nil, err
+	}
+
+	srs, err := kzg_bn254.NewSRS(size, k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create srs: %w", err)
+	}
+
+	return srs, nil
+}
+
+// checkSecret makes sure 1 < k < r.
+func checkSecret(k *big.Int) error {
+	switch {
+	case k.Sign() < 0:
+		return fmt.Errorf("%w: k is negative", ErrInvalidSecret)
+	case k.Cmp(big.NewInt(1)) <= 0:
+		return fmt.Errorf("%w: k = %s yields a degenerate srs", ErrInvalidSecret, k)
+	case k.Cmp(fr.Modulus()) >= 0:
+		return fmt.Errorf("%w: k is not smaller than the scalar field modulus", ErrInvalidSecret)
+	}
+	return nil
+}
+
+// randomSecret samples k uniformly in [2, r).
+func randomSecret() (*big.Int, error) {
+	for {
+		var e fr.Element
+		if _, err := e.SetRandom(); err != nil {
+			return nil, fmt.Errorf("unable to sample srs secret: %w", err)
+		}
+
+		k := e.BigInt(new(big.Int))
+		if checkSecret(k) == nil {
+			return k, nil
+		}
+	}
+}
+
+// Contribute updates srs with a participant's secret s, turning the powers [τⁱ]G₁ into [(sτ)ⁱ]G₁
+// and [τ]G₂ into [sτ]G₂. As long as one participant of the ceremony discards their secret, nobody
+// knows the final τ. The input SRS is not modified.
+func Contribute(srs *kzg_bn254.SRS, secret *big.Int) (*kzg_bn254.SRS, error) {
+	var s fr.Element
+	s.SetBigInt(secret)
+	if s.IsZero() || s.IsOne() {
+		return nil, fmt.Errorf("%w: secret must not be 0 or 1", ErrInvalidContribution)
+	}
+
+	var res kzg_bn254.SRS
+	res.Pk.G1 = make([]bn254.G1Affine, len(srs.Pk.G1))
+	res.Vk = srs.Vk
+
+	var power fr.Element
+	power.SetOne()
+	var bPower big.Int
+	for i := range srs.Pk.G1 {
+		res.Pk.G1[i].ScalarMultiplication(&srs.Pk.G1[i], power.BigInt(&bPower))
+		power.Mul(&power, &s)
+	}
+
+	res.Vk.G2[1].ScalarMultiplication(&srs.Vk.G2[1], s.BigInt(&bPower))
+	res.Vk.Lines[1] = bn254.PrecomputeLines(res.Vk.G2[1])
+
+	return &res, nil
+}
+
+// CombineSRSContributions checks the successive states of a multi-party ceremony and returns the
+// resulting SRS. contribs[0] is the initial SRS and every following entry must be the output of
+// Contribute applied to the previous one: all of them are checked to be a consistent sequence of
+// powers over the same generators, and every contribution must change the SRS.
+//
+// The SRS alone does not prove that a contribution was built on top of the previous one rather than
+// from scratch; ceremonies should additionally check each participant's proof of knowledge.
+func CombineSRSContributions(contribs []*kzg_bn254.SRS) (*kzg_bn254.SRS, error) {
+	if len(contribs) == 0 {
+		return nil, fmt.Errorf("%w: no contributions", ErrInvalidContribution)
+	}
+
+	for i, srs := range contribs {
+		if err := ValidateSRS(srs); err != nil {
+			return nil, fmt.Errorf("contribution %d: %w", i, err)
+		}
+		if i == 0 {
+			continue
+		}
+
+		previous := contribs[i-1]
+		switch {
+		case len(srs.Pk.G1) != len(previous.Pk.G1):
+			return nil, fmt.Errorf("%w: contribution %d has %d powers, previous has %d",
+				ErrInvalidContribution, i, len(srs.Pk.G1), len(previous.Pk.G1))
+		case !srs.Pk.G1[0].Equal(&previous.Pk.G1[0]) || !srs.Vk.G2[0].Equal(&previous.Vk.G2[0]):
+			return nil, fmt.Errorf("%w: contribution %d changes the generators", ErrInvalidContribution, i)
+		case srs.Vk.G2[1].Equal(&previous.Vk.G2[1]):
+			return nil, fmt.Errorf("%w: contribution %d does not update the srs", ErrInvalidContribution, i)
+		}
+	}
+
+	return contribs[len(contribs)-1], nil
+}
+
+// ValidateSRS checks an externally supplied SRS before it is used: all points must be in the
+// prime order subgroups, the precomputed pairing lines must match the G₂ points, and the G₁ powers
+// must be the successive powers of the τ in [τ]G₂. A corrupted SRS would otherwise silently produce
+// proofs that never verify.
+func ValidateSRS(srs *kzg_bn254.SRS) error {
+	if srs == nil {
+		return fmt.Errorf("%w: srs is nil", ErrInvalidSRS)
+	}
+
+	for i := range srs.Pk.G1 {
+		if srs.Pk.G1[i].IsInfinity() || !srs.Pk.G1[i].IsInSubGroup() {
+			return fmt.Errorf("%w: power %d is not a valid G1 point", ErrInvalidSRS, i)
+		}
+	}
+	for i := range srs.Vk.G2 {
+		if srs.Vk.G2[i].IsInfinity() || !srs.Vk.G2[i].IsInSubGroup() {
+			return fmt.Errorf("%w: verifying key G2 point %d is not valid", ErrInvalidSRS, i)
+		}
+		if bn254.PrecomputeLines(srs.Vk.G2[i]) != srs.Vk.Lines[i] {
+			return fmt.Errorf("%w: precomputed lines of G2 point %d do not match", ErrInvalidSRS, i)
+		}
+	}
+
+	return checkPowers(srs)
+}
+
+// checkPowers makes sure the G₁ powers are [τⁱ]G₁ for the τ of [τ]G₂ in the verifying key. Instead
+// of one pairing check per power, consecutive powers are folded with a random r:
+//
+//	e(Σ rⁱ[τⁱ⁺¹]G₁, G₂) == e(Σ rⁱ[τⁱ]G₁, [τ]G₂)
+func checkPowers(srs *kzg_bn254.SRS) error {
+	n := len(srs.Pk.G1)
+	if n < 2 {
+		return fmt.Errorf("%w: srs has %d powers, need at least 2", ErrInvalidSRS, n)
+	}
+	if !srs.Vk.G1.Equal(&srs.Pk.G1[0]) {
+		return fmt.Errorf("%w: verifying key G1 does not match the first power", ErrInvalidSRS)
+	}
+
+	var r fr.Element
+	if _, err := r.SetRandom(); err != nil {
+		return fmt.Errorf("unable to sample challenge: %w", err)
+	}
+	scalars := make([]fr.Element, n-1)
+	scalars[0].SetOne()
+	for i := 1; i < len(scalars); i++ {
+		scalars[i].Mul(&scalars[i-1], &r)
+	}
+
+	var lower, upper bn254.G1Affine
+	if _, err := lower.MultiExp(srs.Pk.G1[:n-1], scalars, ecc.MultiExpConfig{}); err != nil {
+		return fmt.Errorf("unable to fold powers: %w", err)
+	}
+	if _, err := upper.MultiExp(srs.Pk.G1[1:], scalars, ecc.MultiExpConfig{}); err != nil {
+		return fmt.Errorf("unable to fold powers: %w", err)
+	}
+	lower.Neg(&lower)
+
+	ok, err := bn254.PairingCheck([]bn254.G1Affine{upper, lower}, []bn254.G2Affine{srs.Vk.G2[0], srs.Vk.G2[1]})
+	if err != nil {
+		return fmt.Errorf("unable to compute pairing: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: powers are not a geometric progression", ErrInvalidSRS)
+	}
+
+	return nil
+}
+
+// NewSRSContext is GenerateSRS returning ctx.Err() as soon as ctx is done. The generation itself
+// cannot be interrupted: it goes on in the background and its result is dropped.
+func NewSRSContext(ctx context.Context, size uint64, k *big.Int) (*kzg_bn254.SRS, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		srs *kzg_bn254.SRS
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		srs, err := GenerateSRS(size, k)
+		done <- result{srs, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.srs, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,138 @@
+package kzg
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+func TestCombineSRSContributions(t *testing.T) {
+	initial := newTestSRS(t, 8)
+
+	first, err := Contribute(initial, big.NewInt(1234))
+	if err != nil {
+		t.Fatalf("unable to contribute: %v", err)
+	}
+	second, err := Contribute(first, big.NewInt(5678))
+	if err != nil {
+		t.Fatalf("unable to contribute: %v", err)
+	}
+
+	srs, err := CombineSRSContributions([]*kzg_bn254.SRS{initial, first, second})
+	if err != nil {
+		t.Fatalf("unable to combine contributions: %v", err)
+	}
+
+	p := NewPolynomial(1, 2, 3, 4)
+	var point fr.Element
+	point.SetUint64(5)
+
+	digest, err := Commit(p, srs.Pk)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	proof, err := Open(p, point, srs.Pk)
+	if err != nil {
+		t.Fatalf("unable to open: %v", err)
+	}
+	if err := kzg_bn254.Verify(&digest, &proof, point, srs.Vk); err != nil {
+		t.Fatalf("opening proof rejected: %v", err)
+	}
+
+	// the combined SRS must not be the one of any single participant
+	if initialDigest, _ := Commit(p, initial.Pk); initialDigest.Equal(&digest) {
+		t.Fatal("combined srs equals the initial srs")
+	}
+
+	if _, err := CombineSRSContributions([]*kzg_bn254.SRS{initial, initial}); !errors.Is(err, ErrInvalidContribution) {
+		t.Fatalf("expected %v, got %v", ErrInvalidContribution, err)
+	}
+
+	// a participant that only updates the verifying key breaks the consistency of the powers
+	forged := *second
+	forged.Vk.G2[1] = first.Vk.G2[1]
+	forged.Vk.G2[1].ScalarMultiplication(&forged.Vk.G2[1], big.NewInt(2))
+	forged.Vk.Lines[1] = bn254.PrecomputeLines(forged.Vk.G2[1])
+	if _, err := CombineSRSContributions([]*kzg_bn254.SRS{initial, first, &forged}); !errors.Is(err, ErrInvalidSRS) {
+		t.Fatalf("expected %v, got %v", ErrInvalidSRS, err)
+	}
+}
+
+func TestValidateSRS(t *testing.T) {
+	if err := ValidateSRS(newTestSRS(t, 16)); err != nil {
+		t.Fatalf("valid srs rejected: %v", err)
+	}
+
+	for _, index := range []int{0, 1, 7, 15} {
+		srs := newTestSRS(t, 16)
+
+		// replace one power by the next one, which is still a valid curve point
+		next := (index + 1) % len(srs.Pk.G1)
+		srs.Pk.G1[index] = srs.Pk.G1[next]
+
+		if err := ValidateSRS(srs); !errors.Is(err, ErrInvalidSRS) {
+			t.Fatalf("corrupted power %d: expected %v, got %v", index, ErrInvalidSRS, err)
+		}
+	}
+
+	srs := newTestSRS(t, 16)
+	srs.Vk.G2[1] = srs.Vk.G2[0]
+	if err := ValidateSRS(srs); !errors.Is(err, ErrInvalidSRS) {
+		t.Fatalf("stale pairing lines: expected %v, got %v", ErrInvalidSRS, err)
+	}
+	srs.Vk.Lines[1] = bn254.PrecomputeLines(srs.Vk.G2[1])
+	if err := ValidateSRS(srs); !errors.Is(err, ErrInvalidSRS) {
+		t.Fatalf("corrupted [τ]G2: expected %v, got %v", ErrInvalidSRS, err)
+	}
+}
+
+func TestGenerateSRS(t *testing.T) {
+	for _, k := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-5), fr.Modulus()} {
+		if _, err := GenerateSRS(8, k); !errors.Is(err, ErrInvalidSecret) {
+			t.Fatalf("k = %s: expected %v, got %v", k, ErrInvalidSecret, err)
+		}
+	}
+
+	srs, err := GenerateSRS(8, nil)
+	if err != nil {
+		t.Fatalf("unable to generate srs with a random secret: %v", err)
+	}
+	if err := ValidateSRS(srs); err != nil {
+		t.Fatalf("generated srs is invalid: %v", err)
+	}
+
+	srs, err = GenerateSRS(8, new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+	if err != nil {
+		t.Fatalf("unable to generate srs with k = r-1: %v", err)
+	}
+	if err := ValidateSRS(srs); err != nil {
+		t.Fatalf("generated srs is invalid: %v", err)
+	}
+}
+
+func TestNewSRSContext(t *testing.T) {
+	srs, err := NewSRSContext(t.Context(), 8, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("unable to create srs: %v", err)
+	}
+	if err := ValidateSRS(srs); err != nil {
+		t.Fatalf("invalid srs: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := NewSRSContext(ctx, 1<<16, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned %s after the deadline", elapsed)
+	}
+}
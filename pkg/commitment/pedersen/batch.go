@@ -0,0 +1,87 @@
+package pedersen
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// batchVerifyMultiVk accepts and rejects exactly what pedersen_bn254.BatchVerifyMultiVk does, but
+// the latter pairs every commitment on its own. Commitments verified under the same key, which is
+// the common case as a setup returns a single verifying key for all its proving keys, share the
+// G₂ point [-σ]G₂, so they are folded with one multi-exponentiation first:
+//
+//	Π e(Σⱼ rʲ⋅Cⱼ, [-σₖ]G₂) ⋅ e(Σᵢ rⁱ⋅πᵢ, G₂) == 1
+//
+// with one pair per distinct key rather than one per commitment.
+func batchVerifyMultiVk(vk []pedersen_bn254.VerifyingKey, commitments []bn254.G1Affine, pok []bn254.G1Affine, combinationCoeff fr.Element) error {
+	if len(commitments) != len(vk) {
+		return fmt.Errorf("%w: %d verifying keys, %d commitments", ErrLengthMismatch, len(vk), len(commitments))
+	}
+	if len(pok) != len(vk) && len(pok) != 1 {
+		return fmt.Errorf("%w: %d proofs for %d commitments", ErrLengthMismatch, len(pok), len(vk))
+	}
+	if len(vk) == 0 {
+		return fmt.Errorf("%w: nothing to verify", ErrLengthMismatch)
+	}
+
+	// group the commitments by key, each scaled by its power of the combination coefficient
+	var (
+		groups  = make(map[bn254.G2Affine]int)
+		sigmas  []bn254.G2Affine
+		points  [][]bn254.G1Affine
+		scalars [][]fr.Element
+		power   = fr.One()
+	)
+	for i := range commitments {
+		if !commitments[i].IsInSubGroup() {
+			return fmt.Errorf("%w: commitment %d is not in the subgroup", ErrInvalidProof, i)
+		}
+		if vk[i].G != vk[0].G {
+			return fmt.Errorf("%w: verifying key %d has another G2 point", ErrInvalidProof, i)
+		}
+
+		k, ok := groups[vk[i].GSigmaNeg]
+		if !ok {
+			k = len(sigmas)
+			groups[vk[i].GSigmaNeg] = k
+			sigmas = append(sigmas, vk[i].GSigmaNeg)
+			points, scalars = append(points, nil), append(scalars, nil)
+		}
+		points[k] = append(points[k], commitments[i])
+		scalars[k] = append(scalars[k], power)
+
+		power.Mul(&power, &combinationCoeff)
+	}
+	for i := range pok {
+		if !pok[i].IsInSubGroup() {
+			return fmt.Errorf("%w: proof %d is not in the subgroup", ErrInvalidProof, i)
+		}
+	}
+
+	pairingG1 := make([]bn254.G1Affine, len(sigmas)+1)
+	pairingG2 := append(sigmas, vk[0].G)
+	for k := range sigmas {
+		if _, err := pairingG1[k].MultiExp(points[k], scalars[k], ecc.MultiExpConfig{}); err != nil {
+			return fmt.Errorf("unable to fold commitments: %w", err)
+		}
+	}
+	if len(pok) == 1 {
+		pairingG1[len(sigmas)] = pok[0]
+	} else if _, err := pairingG1[len(sigmas)].Fold(pok, combinationCoeff, ecc.MultiExpConfig{}); err != nil {
+		return fmt.Errorf("unable to fold proofs: %w", err)
+	}
+
+	ok, err := bn254.PairingCheck(pairingG1, pairingG2)
+	if err != nil {
+		return fmt.Errorf("unable to compute pairing: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: pairing check failed", ErrInvalidProof)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package pedersen
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// batchFixture holds commitments along with their proofs of knowledge and verifying keys.
+type batchFixture struct {
+	vk          []pedersen_bn254.VerifyingKey
+	commitments []bn254.G1Affine
+	pok         []bn254.G1Affine
+	coeff       fr.Element
+}
+
+// newBatchFixture commits with nbSetups setups sharing the G₂ point, perSetup times each.
+func newBatchFixture(tb testing.TB, nbSetups, perSetup int) batchFixture {
+	tb.Helper()
+
+	_, _, _, g2 := bn254.Generators()
+
+	var f batchFixture
+	for s := 0; s < nbSetups; s++ {
+		bases := make([][]bn254.G1Affine, perSetup)
+		for i := range bases {
+			bases[i] = randomBases(tb, 2)
+		}
+
+		pk, vk, err := pedersen_bn254.Setup(bases, pedersen_bn254.WithG2Point(g2))
+		if err != nil {
+			tb.Fatalf("unable to run setup: %v", err)
+		}
+		for i := range pk {
+			values := newValues(uint64(s+1), uint64(i+1))
+			commitment, err := pk[i].Commit(values)
+			if err != nil {
+				tb.Fatalf("unable to commit: %v", err)
+			}
+			pok, err := pk[i].ProveKnowledge(values)
+			if err != nil {
+				tb.Fatalf("unable to prove knowledge: %v", err)
+			}
+			f.vk = append(f.vk, vk)
+			f.commitments = append(f.commitments, commitment)
+			f.pok = append(f.pok, pok)
+		}
+	}
+
+	if _, err := f.coeff.SetRandom(); err != nil {
+		tb.Fatalf("unable to sample coefficient: %v", err)
+	}
+	return f
+}
+
+func TestBatchVerifyMultiVk(t *testing.T) {
+	f := newBatchFixture(t, 5, 10)
+
+	swapped := make([]bn254.G1Affine, len(f.commitments))
+	copy(swapped, f.commitments)
+	swapped[3], swapped[17] = swapped[17], swapped[3]
+
+	tampered := make([]bn254.G1Affine, len(f.pok))
+	copy(tampered, f.pok)
+	tampered[42].Add(&tampered[42], &tampered[0])
+
+	var folded bn254.G1Affine
+	if _, err := folded.Fold(f.pok, f.coeff, ecc.MultiExpConfig{}); err != nil {
+		t.Fatalf("unable to fold proofs: %v", err)
+	}
+
+	cases := []struct {
+		name        string
+		commitments []bn254.G1Affine
+		pok         []bn254.G1Affine
+		valid       bool
+	}{
+		{"valid", f.commitments, f.pok, true},
+		{"folded proof", f.commitments, []bn254.G1Affine{folded}, true},
+		{"swapped commitments", swapped, f.pok, false},
+		{"tampered proof", f.commitments, tampered, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reference := pedersen_bn254.BatchVerifyMultiVk(f.vk, tc.commitments, tc.pok, f.coeff)
+			got := batchVerifyMultiVk(f.vk, tc.commitments, tc.pok, f.coeff)
+
+			if (reference == nil) != tc.valid {
+				t.Fatalf("reference implementation returned %v", reference)
+			}
+			if (got == nil) != (reference == nil) {
+				t.Fatalf("got %v, reference implementation returned %v", got, reference)
+			}
+		})
+	}
+}
+
+func BenchmarkBatchVerifyMultiVk(b *testing.B) {
+	f := newBatchFixture(b, 5, 10)
+
+	b.Run("per commitment", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := pedersen_bn254.BatchVerifyMultiVk(f.vk, f.commitments, f.pok, f.coeff); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("per key", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := batchVerifyMultiVk(f.vk, f.commitments, f.pok, f.coeff); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
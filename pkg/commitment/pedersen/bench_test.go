@@ -0,0 +1,64 @@
+package pedersen
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// benchSizes are the numbers of committed values the benchmarks run with.
+var benchSizes = []int{1, 16, 256}
+
+// randomValues returns n random field elements.
+func randomValues(tb testing.TB, n int) []fr.Element {
+	tb.Helper()
+
+	values := make([]fr.Element, n)
+	for i := range values {
+		if _, err := values[i].SetRandom(); err != nil {
+			tb.Fatalf("unable to sample value: %v", err)
+		}
+	}
+	return values
+}
+
+func BenchmarkPedersenCommit(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("values=%d", size), func(b *testing.B) {
+			pk, _ := newTestKeys(b, size)
+			values := randomValues(b, size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := pk[0].Commit(values); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPedersenVerify(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("values=%d", size), func(b *testing.B) {
+			pk, vk := newTestKeys(b, size)
+			values := randomValues(b, size)
+			commitment, err := pk[0].Commit(values)
+			if err != nil {
+				b.Fatal(err)
+			}
+			pok, err := pk[0].ProveKnowledge(values)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := vk.Verify(commitment, pok); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package pedersen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// basesDST is the hash-to-curve domain separation tag of SetupWithDomain.
+const basesDST = "KEYLESS-PEDERSEN-BASES-BN254_XMD:SHA-256_SVDW_RO_"
+
+// ErrDomainMismatch denotes a commitment verified under the key of another domain.
+var ErrDomainMismatch = errors.New("commitment domain mismatch")
+
+// DomainProvingKey is a Pedersen proving key whose bases are bound to a domain.
+type DomainProvingKey struct {
+	pedersen_bn254.ProvingKey
+	Domain []byte
+}
+
+// DomainVerifyingKey is a Pedersen verifying key recording the domain of its setup.
+type DomainVerifyingKey struct {
+	pedersen_bn254.VerifyingKey
+	Domain []byte
+}
+
+// DomainCommitment is a commitment together with its proof of knowledge and the domain it was made in.
+type DomainCommitment struct {
+	Domain     []byte
+	Commitment bn254.G1Affine
+	Pok        bn254.G1Affine
+}
+
+// SetupWithDomain runs a Pedersen setup for nbValues values whose bases and G₂ point are hashed from
+// the domain, so that commitments of one protocol cannot be replayed in another.
+func SetupWithDomain(nbValues int, domain []byte) (DomainProvingKey, DomainVerifyingKey, error) {
+	if nbValues <= 0 {
+		return DomainProvingKey{}, DomainVerifyingKey{}, fmt.Errorf("number of values must be positive, got %d", nbValues)
+	}
+
+	bases := make([]bn254.G1Affine, nbValues)
+	for i := range bases {
+		base, err := bn254.HashToG1(domainMessage(domain, uint32(i)), []byte(basesDST))
+		if err != nil {
+			return DomainProvingKey{}, DomainVerifyingKey{}, fmt.Errorf("unable to derive base %d: %w", i, err)
+		}
+		bases[i] = base
+	}
+
+	g2, err := bn254.HashToG2(domainMessage(domain, uint32(nbValues)), []byte(basesDST))
+	if err != nil {
+		return DomainProvingKey{}, DomainVerifyingKey{}, fmt.Errorf("unable to derive g2 point: %w", err)
+	}
+
+	pk, vk, err := pedersen_bn254.Setup([][]bn254.G1Affine{bases}, pedersen_bn254.WithG2Point(g2))
+	if err != nil {
+		return DomainProvingKey{}, DomainVerifyingKey{}, fmt.Errorf("unable to run setup: %w", err)
+	}
+
+	domain = bytes.Clone(domain)
+	return DomainProvingKey{ProvingKey: pk[0], Domain: domain}, DomainVerifyingKey{VerifyingKey: vk, Domain: domain}, nil
+}
+
+// Commit commits to values and proves knowledge of them, tagging the result with the key's domain.
+func (pk *DomainProvingKey) Commit(values []fr.Element) (DomainCommitment, error) {
+	commitment, err := pk.ProvingKey.Commit(values)
+	if err != nil {
+		return DomainCommitment{}, fmt.Errorf("unable to commit: %w", err)
+	}
+
+	pok, err := pk.ProveKnowledge(values)
+	if err != nil {
+		return DomainCommitment{}, fmt.Errorf("unable to prove knowledge: %w", err)
+	}
+
+	return DomainCommitment{Domain: pk.Domain, Commitment: commitment, Pok: pok}, nil
+}
+
+// Verify checks that c was made under the domain of the key and verifies its proof of knowledge.
+func (vk *DomainVerifyingKey) Verify(c DomainCommitment) error {
+	if !bytes.Equal(c.Domain, vk.Domain) {
+		return fmt.Errorf("%w: commitment made under %q, key expects %q", ErrDomainMismatch, c.Domain, vk.Domain)
+	}
+
+	if err := vk.VerifyingKey.Verify(c.Commitment, c.Pok); err != nil {
+		return fmt.Errorf("unable to verify commitment: %w", err)
+	}
+
+	return nil
+}
+
+// domainMessage returns the length prefixed domain followed by the index.
+func domainMessage(domain []byte, index uint32) []byte {
+	msg := binary.BigEndian.AppendUint32(nil, uint32(len(domain)))
+	msg = append(msg, domain...)
+	return binary.BigEndian.AppendUint32(msg, index)
+}
@@ -0,0 +1,48 @@
+package pedersen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetupWithDomain(t *testing.T) {
+	pkA, vkA, err := SetupWithDomain(3, []byte("protocol A"))
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	_, vkB, err := SetupWithDomain(3, []byte("protocol B"))
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+
+	commitment, err := pkA.Commit(newValues(1, 2, 3))
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if err := vkA.Verify(commitment); err != nil {
+		t.Fatalf("commitment rejected under its own domain: %v", err)
+	}
+
+	if err := vkB.Verify(commitment); !errors.Is(err, ErrDomainMismatch) {
+		t.Fatalf("expected %v, got %v", ErrDomainMismatch, err)
+	}
+
+	// relabelling the commitment does not help, the proof of knowledge is bound to the setup
+	commitment.Domain = vkB.Domain
+	if err := vkB.Verify(commitment); err == nil {
+		t.Fatal("relabelled commitment verified under domain B")
+	}
+
+	// the bases are a deterministic function of the domain
+	pkA2, _, err := SetupWithDomain(3, []byte("protocol A"))
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	again, err := pkA2.Commit(newValues(1, 2, 3))
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if !again.Commitment.Equal(&commitment.Commitment) {
+		t.Fatal("commitments under the same domain differ")
+	}
+}
@@ -0,0 +1,96 @@
+package pedersen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+var (
+	// ErrValuesDiffer denotes an equality proof requested for commitments to different values.
+	ErrValuesDiffer = errors.New("committed values differ")
+	// ErrInvalidProof denotes a sigma protocol proof that does not verify.
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// EqualityProof proves that two Pedersen commitments, possibly under different generators and
+// randomness, hide the same value.
+type EqualityProof struct {
+	TA, TB bn254.G1Affine // commitments to the nonces
+	SV     fr.Element     // response for the shared value
+	SA, SB fr.Element     // responses for the randomness of each commitment
+}
+
+// ProveEquality proves that valueA⋅GA + randA⋅HA and valueB⋅GB + randB⋅HB commit to the same
+// value, without revealing it. This is a Chaum-Pedersen style sigma protocol made non-interactive
+// with the transcript, which must be in the same state as the one later given to VerifyEquality.
+func ProveEquality(valueA, randA, valueB, randB fr.Element, gensA, gensB Generators, transcript *Transcript) (EqualityProof, error) {
+	if !valueA.Equal(&valueB) {
+		return EqualityProof{}, ErrValuesDiffer
+	}
+
+	commitA, commitB := gensA.Commit(valueA, randA), gensB.Commit(valueB, randB)
+
+	var kv, ka, kb fr.Element
+	for _, k := range []*fr.Element{&kv, &ka, &kb} {
+		nonce, err := randomScalar()
+		if err != nil {
+			return EqualityProof{}, err
+		}
+		*k = nonce
+	}
+
+	proof := EqualityProof{
+		TA: gensA.Commit(kv, ka),
+		TB: gensB.Commit(kv, kb),
+	}
+
+	c := equalityChallenge(commitA, commitB, gensA, gensB, proof, transcript)
+
+	proof.SV = response(kv, c, valueA)
+	proof.SA = response(ka, c, randA)
+	proof.SB = response(kb, c, randB)
+
+	return proof, nil
+}
+
+// VerifyEquality verifies that commitA and commitB hide the same value.
+func VerifyEquality(commitA, commitB bn254.G1Affine, gensA, gensB Generators, proof EqualityProof, transcript *Transcript) error {
+	c := equalityChallenge(commitA, commitB, gensA, gensB, proof, transcript)
+
+	if !checkResponse(gensA, proof.SV, proof.SA, proof.TA, c, commitA) {
+		return fmt.Errorf("%w: first commitment", ErrInvalidProof)
+	}
+	if !checkResponse(gensB, proof.SV, proof.SB, proof.TB, c, commitB) {
+		return fmt.Errorf("%w: second commitment", ErrInvalidProof)
+	}
+
+	return nil
+}
+
+func equalityChallenge(commitA, commitB bn254.G1Affine, gensA, gensB Generators, proof EqualityProof, transcript *Transcript) fr.Element {
+	transcript.Append("generators", gensA.Marshal())
+	transcript.Append("generators", gensB.Marshal())
+	transcript.Append("commitment", commitA.Marshal())
+	transcript.Append("commitment", commitB.Marshal())
+	transcript.Append("nonce", proof.TA.Marshal())
+	transcript.Append("nonce", proof.TB.Marshal())
+	return transcript.Challenge("equality")
+}
+
+// response returns k + c⋅secret.
+func response(k, c, secret fr.Element) fr.Element {
+	var s fr.Element
+	s.Mul(&c, &secret)
+	s.Add(&s, &k)
+	return s
+}
+
+// checkResponse checks sv⋅G + sr⋅H == T + c⋅C.
+func checkResponse(gens Generators, sv, sr fr.Element, t bn254.G1Affine, c fr.Element, commit bn254.G1Affine) bool {
+	lhs := gens.Commit(sv, sr)
+	rhs := combine(t, fr.One(), commit, c)
+	return lhs.Equal(&rhs)
+}
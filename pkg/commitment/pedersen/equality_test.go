@@ -0,0 +1,81 @@
+package pedersen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// newTestGenerators derives generators for the domain.
+func newTestGenerators(t *testing.T, domain string) Generators {
+	t.Helper()
+
+	gens, err := NewGenerators(domain)
+	if err != nil {
+		t.Fatalf("unable to derive generators: %v", err)
+	}
+	return gens
+}
+
+func TestEqualityProof(t *testing.T) {
+	gensA, gensB := newTestGenerators(t, "a"), newTestGenerators(t, "b")
+	value, randA, randB := newValues(42)[0], newValues(7)[0], newValues(1337)[0]
+
+	proof, err := ProveEquality(value, randA, value, randB, gensA, gensB, NewTranscript("test"))
+	if err != nil {
+		t.Fatalf("unable to prove equality: %v", err)
+	}
+
+	commitA, commitB := gensA.Commit(value, randA), gensB.Commit(value, randB)
+	if err := VerifyEquality(commitA, commitB, gensA, gensB, proof, NewTranscript("test")); err != nil {
+		t.Fatalf("equality proof rejected: %v", err)
+	}
+
+	// the proof does not carry over to a commitment to another value
+	other := gensB.Commit(newValues(43)[0], randB)
+	if err := VerifyEquality(commitA, other, gensA, gensB, proof, NewTranscript("test")); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+	}
+
+	if _, err := ProveEquality(value, randA, newValues(43)[0], randB, gensA, gensB, NewTranscript("test")); !errors.Is(err, ErrValuesDiffer) {
+		t.Fatalf("expected %v, got %v", ErrValuesDiffer, err)
+	}
+
+	// altering a response invalidates the proof
+	var zero fr.Element
+	forged := EqualityProof{TA: proof.TA, TB: proof.TB, SV: zero, SA: proof.SA, SB: proof.SB}
+	if err := VerifyEquality(commitA, commitB, gensA, gensB, forged, NewTranscript("test")); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+	}
+}
+
+func TestRerandomize(t *testing.T) {
+	gens := newTestGenerators(t, "test")
+	value, r, delta := newValues(42)[0], newValues(7)[0], newValues(99)[0]
+
+	commit := gens.Commit(value, r)
+	fresh := Rerandomize(commit, delta, gens.H)
+	if fresh.Equal(&commit) {
+		t.Fatal("rerandomized commitment equals the original")
+	}
+
+	// fresh opens to the same value with randomness r + delta
+	var adjusted fr.Element
+	adjusted.Add(&r, &delta)
+	if want := gens.Commit(value, adjusted); !fresh.Equal(&want) {
+		t.Fatal("rerandomized commitment does not open with the adjusted randomness")
+	}
+	if stale := gens.Commit(value, r); fresh.Equal(&stale) {
+		t.Fatal("rerandomized commitment opens with the old randomness")
+	}
+
+	// and provably hides the same value as the original
+	proof, err := ProveEquality(value, r, value, adjusted, gens, gens, NewTranscript("test"))
+	if err != nil {
+		t.Fatalf("unable to prove equality: %v", err)
+	}
+	if err := VerifyEquality(commit, fresh, gens, gens, proof, NewTranscript("test")); err != nil {
+		t.Fatalf("equality proof rejected: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package pedersen
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// generatorsDST is the hash-to-curve domain separation tag of NewGenerators.
+const generatorsDST = "KEYLESS-PEDERSEN-GENERATORS-BN254G1_XMD:SHA-256_SVDW_RO_"
+
+// Generators are the two bases of a single value Pedersen commitment v⋅G + r⋅H. Nobody may know
+// the discrete logarithm of H in base G, otherwise commitments can be opened to any value.
+type Generators struct {
+	G bn254.G1Affine
+	H bn254.G1Affine
+}
+
+// NewGenerators derives G and H by hashing the domain to the curve, so that their relative
+// discrete logarithm is unknown. Different domains yield independent generators.
+func NewGenerators(domain string) (Generators, error) {
+	g, err := bn254.HashToG1([]byte(domain+"/G"), []byte(generatorsDST))
+	if err != nil {
+		return Generators{}, fmt.Errorf("unable to derive G: %w", err)
+	}
+
+	h, err := bn254.HashToG1([]byte(domain+"/H"), []byte(generatorsDST))
+	if err != nil {
+		return Generators{}, fmt.Errorf("unable to derive H: %w", err)
+	}
+
+	return Generators{G: g, H: h}, nil
+}
+
+// Commit returns value⋅G + randomness⋅H.
+func (g Generators) Commit(value, randomness fr.Element) bn254.G1Affine {
+	return ComputePedersenCommitment(value, randomness, g.G, g.H)
+}
+
+// ComputePedersenCommitment returns m⋅g + r⋅h, the commitment a circuit constraining the same
+// scalar multiplications computes. It builds the public commitment of circuit witnesses and serves
+// as the reference value when testing such circuits.
+func ComputePedersenCommitment(m, r fr.Element, g, h bn254.G1Affine) bn254.G1Affine {
+	return combine(g, m, h, r)
+}
+
+// Rerandomize returns commit + deltaR⋅hBase. If commit is value⋅G + r⋅H with hBase = H, the result
+// commits to the same value with randomness r + deltaR, and cannot be linked to commit without
+// knowing deltaR.
+func Rerandomize(commit bn254.G1Affine, deltaR fr.Element, hBase bn254.G1Affine) bn254.G1Affine {
+	return combine(commit, fr.One(), hBase, deltaR)
+}
+
+// Marshal returns the encoding of both generators, used to bind them into transcripts.
+func (g Generators) Marshal() []byte {
+	return append(g.G.Marshal(), g.H.Marshal()...)
+}
+
+// combine returns a⋅P + b⋅Q.
+func combine(p bn254.G1Affine, a fr.Element, q bn254.G1Affine, b fr.Element) bn254.G1Affine {
+	var ap, bq bn254.G1Jac
+	ap.FromAffine(&p)
+	ap.ScalarMultiplication(&ap, a.BigInt(new(big.Int)))
+	bq.FromAffine(&q)
+	bq.ScalarMultiplication(&bq, b.BigInt(new(big.Int)))
+	ap.AddAssign(&bq)
+
+	var res bn254.G1Affine
+	res.FromJacobian(&ap)
+	return res
+}
+
+// randomScalar samples a uniformly random field element.
+func randomScalar() (fr.Element, error) {
+	var s fr.Element
+	if _, err := s.SetRandom(); err != nil {
+		return fr.Element{}, fmt.Errorf("unable to sample scalar: %w", err)
+	}
+	return s, nil
+}
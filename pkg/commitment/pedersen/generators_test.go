@@ -0,0 +1,70 @@
+package pedersen
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/test"
+)
+
+// commitmentCircuit constrains C = M⋅G + R⋅H on BN254 G1, emulated over the BN254 scalar field.
+type commitmentCircuit struct {
+	C    sw_bn254.G1Affine `gnark:",public"`
+	G, H sw_bn254.G1Affine
+	M, R sw_bn254.Scalar
+}
+
+func (c *commitmentCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bn254.BaseField, sw_bn254.ScalarField](api, sw_emulated.GetBN254Params())
+	if err != nil {
+		return err
+	}
+
+	commitment := curve.Add(curve.ScalarMul(&c.G, &c.M), curve.ScalarMul(&c.H, &c.R))
+	curve.AssertIsEqual(commitment, &c.C)
+	return nil
+}
+
+// newCommitmentAssignment assigns commitmentCircuit with the commitment computed off-circuit.
+func newCommitmentAssignment(m, r fr.Element, g, h bn254.G1Affine) *commitmentCircuit {
+	return &commitmentCircuit{
+		C: sw_bn254.NewG1Affine(ComputePedersenCommitment(m, r, g, h)),
+		G: sw_bn254.NewG1Affine(g),
+		H: sw_bn254.NewG1Affine(h),
+		M: sw_bn254.NewScalar(m),
+		R: sw_bn254.NewScalar(r),
+	}
+}
+
+func TestComputePedersenCommitment(t *testing.T) {
+	gens, err := NewGenerators("keyless/test")
+	if err != nil {
+		t.Fatalf("unable to derive generators: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		m, r := randomValues(t, 1)[0], randomValues(t, 1)[0]
+
+		commitment := ComputePedersenCommitment(m, r, gens.G, gens.H)
+		if want := gens.Commit(m, r); !commitment.Equal(&want) {
+			t.Fatal("commitment differs from Generators.Commit")
+		}
+
+		assignment := newCommitmentAssignment(m, r, gens.G, gens.H)
+		if err := test.IsSolved(&commitmentCircuit{}, assignment, ecc.BN254.ScalarField()); err != nil {
+			t.Fatalf("off-circuit commitment does not match the circuit: %v", err)
+		}
+
+		// the same scalars with the generators swapped commit to another point
+		swapped := newCommitmentAssignment(m, r, gens.G, gens.H)
+		swapped.C = sw_bn254.NewG1Affine(ComputePedersenCommitment(m, r, gens.H, gens.G))
+		if err := test.IsSolved(&commitmentCircuit{}, swapped, ecc.BN254.ScalarField()); err == nil {
+			t.Fatal("circuit accepted a wrong commitment")
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package pedersen
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// HashToField maps arbitrary bytes to a scalar field element. Challenges derived off-chain must use
+// the same function as the on-chain verifier, otherwise Fiat-Shamir challenges will not match.
+type HashToField func([]byte) fr.Element
+
+var (
+	_ HashToField = SHA256ToField
+	_ HashToField = MiMCToField
+)
+
+// SHA256ToField hashes data with SHA-256 and reduces the digest modulo the scalar field order.
+// This is what the precompile based Solidity verifiers can compute cheaply.
+func SHA256ToField(data []byte) fr.Element {
+	digest := sha256.Sum256(data)
+
+	var e fr.Element
+	e.SetBytes(digest[:])
+	return e
+}
+
+// MiMCToField hashes data with the bn254 MiMC hash, which is cheap to recompute inside a circuit.
+// The input length is hashed first, then the data in 31-byte chunks so that every block is a
+// canonical field element.
+func MiMCToField(data []byte) fr.Element {
+	const chunkSize = fr.Bytes - 1
+
+	h := mimc.NewMiMC()
+
+	var block [fr.Bytes]byte
+	binary.BigEndian.PutUint64(block[fr.Bytes-8:], uint64(len(data)))
+	h.Write(block[:])
+
+	for start := 0; start < len(data); start += chunkSize {
+		end := min(start+chunkSize, len(data))
+
+		block = [fr.Bytes]byte{}
+		copy(block[fr.Bytes-(end-start):], data[start:end])
+		h.Write(block[:])
+	}
+
+	var e fr.Element
+	e.SetBytes(h.Sum(nil))
+	return e
+}
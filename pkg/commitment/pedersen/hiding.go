@@ -0,0 +1,90 @@
+package pedersen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// ErrInvalidOpening denotes an opening that does not match the commitment.
+var ErrInvalidOpening = errors.New("invalid opening")
+
+// randomnessDomain separates the randomness derived by CommitDeterministic from other hashes of the seed.
+const randomnessDomain = "keyless/pedersen/randomness"
+
+// CommitWithRandomness returns the hiding commitment Σ values[i]⋅Bᵢ + r⋅Bₙ. A gnark-crypto
+// commitment alone is a deterministic function of the values, so the last basis element of pk,
+// which must have one more basis element than there are values, serves as the blinding base.
+// The commitment can be reopened by whoever knows r.
+func CommitWithRandomness(values []fr.Element, r fr.Element, pk pedersen_bn254.ProvingKey) (bn254.G1Affine, error) {
+	if len(pk.Basis) != len(values)+1 {
+		return bn254.G1Affine{}, fmt.Errorf("%w: %d values need %d basis elements, key has %d",
+			ErrLengthMismatch, len(values), len(values)+1, len(pk.Basis))
+	}
+
+	commitment, err := pk.Commit(withRandomness(values, r))
+	if err != nil {
+		return bn254.G1Affine{}, fmt.Errorf("unable to commit: %w", err)
+	}
+	return commitment, nil
+}
+
+// CommitDeterministic is CommitWithRandomness with r derived from seed, see DeriveRandomness, so
+// that the commitment is reproducible from the values and the seed.
+func CommitDeterministic(values []fr.Element, seed []byte, pk pedersen_bn254.ProvingKey) (bn254.G1Affine, error) {
+	return CommitWithRandomness(values, DeriveRandomness(seed), pk)
+}
+
+// DeriveRandomness returns the commitment randomness CommitDeterministic derives from seed.
+func DeriveRandomness(seed []byte) fr.Element {
+	t := NewTranscript(randomnessDomain)
+	t.Append("seed", seed)
+	return t.Challenge("randomness")
+}
+
+// Opening reveals the values and randomness of a commitment made with CommitWithRandomness.
+type Opening struct {
+	Values     []fr.Element
+	Randomness fr.Element
+}
+
+// Open returns the opening of the commitment to values with randomness r under pk. Unlike
+// ProveKnowledge, which proves knowledge of the values without revealing them, an opening
+// discloses them.
+func Open(values []fr.Element, r fr.Element, pk pedersen_bn254.ProvingKey) (Opening, error) {
+	if len(pk.Basis) != len(values)+1 {
+		return Opening{}, fmt.Errorf("%w: %d values need %d basis elements, key has %d",
+			ErrLengthMismatch, len(values), len(values)+1, len(pk.Basis))
+	}
+	return Opening{Values: slices.Clone(values), Randomness: r}, nil
+}
+
+// VerifyOpening checks that opening reveals values and that commit is the commitment to them with
+// the revealed randomness under pk. The bases of pk are public, so anyone can check an opening.
+func VerifyOpening(commit bn254.G1Affine, values []fr.Element, opening Opening, pk pedersen_bn254.ProvingKey) error {
+	if !slices.Equal(values, opening.Values) {
+		return fmt.Errorf("%w: opening reveals other values", ErrInvalidOpening)
+	}
+
+	expected, err := CommitWithRandomness(opening.Values, opening.Randomness, pk)
+	if err != nil {
+		return err
+	}
+	if !expected.Equal(&commit) {
+		return fmt.Errorf("%w: commitment does not open to the values", ErrInvalidOpening)
+	}
+
+	return nil
+}
+
+// withRandomness returns values followed by r.
+func withRandomness(values []fr.Element, r fr.Element) []fr.Element {
+	res := make([]fr.Element, len(values)+1)
+	copy(res, values)
+	res[len(values)] = r
+	return res
+}
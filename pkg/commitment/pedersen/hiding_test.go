@@ -0,0 +1,82 @@
+package pedersen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommitDeterministic(t *testing.T) {
+	pk, _ := newTestKeys(t, 3)
+	values := newValues(1, 2)
+
+	a, err := CommitDeterministic(values, []byte("seed"), pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	b, err := CommitDeterministic(values, []byte("seed"), pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if !a.Equal(&b) {
+		t.Fatal("commitments with the same seed differ")
+	}
+
+	c, err := CommitDeterministic(values, []byte("other seed"), pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if a.Equal(&c) {
+		t.Fatal("commitments with different seeds match")
+	}
+
+	// the derived randomness reopens the commitment
+	reopened, err := CommitWithRandomness(values, DeriveRandomness([]byte("seed")), pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if !reopened.Equal(&a) {
+		t.Fatal("commitment with the derived randomness differs")
+	}
+
+	if _, err := CommitWithRandomness(newValues(1, 2, 3), DeriveRandomness(nil), pk[0]); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+}
+
+func TestVerifyOpening(t *testing.T) {
+	pk, _ := newTestKeys(t, 4)
+	values := newValues(7, 8, 9)
+	r := DeriveRandomness([]byte("seed"))
+
+	commit, err := CommitWithRandomness(values, r, pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	opening, err := Open(values, r, pk[0])
+	if err != nil {
+		t.Fatalf("unable to open: %v", err)
+	}
+	if err := VerifyOpening(commit, values, opening, pk[0]); err != nil {
+		t.Fatalf("valid opening rejected: %v", err)
+	}
+
+	wrongValues := newValues(7, 8, 10)
+	if err := VerifyOpening(commit, wrongValues, opening, pk[0]); !errors.Is(err, ErrInvalidOpening) {
+		t.Fatalf("expected %v, got %v", ErrInvalidOpening, err)
+	}
+	forged, err := Open(wrongValues, r, pk[0])
+	if err != nil {
+		t.Fatalf("unable to open: %v", err)
+	}
+	if err := VerifyOpening(commit, wrongValues, forged, pk[0]); !errors.Is(err, ErrInvalidOpening) {
+		t.Fatalf("expected %v, got %v", ErrInvalidOpening, err)
+	}
+
+	wrongRandomness, err := Open(values, DeriveRandomness([]byte("other seed")), pk[0])
+	if err != nil {
+		t.Fatalf("unable to open: %v", err)
+	}
+	if err := VerifyOpening(commit, values, wrongRandomness, pk[0]); !errors.Is(err, ErrInvalidOpening) {
+		t.Fatalf("expected %v, got %v", ErrInvalidOpening, err)
+	}
+}
@@ -0,0 +1,63 @@
+package pedersen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+// ErrInvalidPoint denotes an encoded point that is not a valid G1 point.
+var ErrInvalidPoint = errors.New("invalid g1 point")
+
+// pointJSON is the JSON form of a G1 point: its affine coordinates as decimal strings.
+type pointJSON struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// ProofToJSON encodes a proof of knowledge, or any G1 point, as {"x":"...","y":"..."} with the
+// affine coordinates in decimal, the way web clients and CommitmentToSolidity expect them.
+func ProofToJSON(proof bn254.G1Affine) ([]byte, error) {
+	x, y := CommitmentToSolidity(proof)
+
+	data, err := json.Marshal(pointJSON{X: x.String(), Y: y.String()})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal proof: %w", err)
+	}
+	return data, nil
+}
+
+// ProofFromJSON decodes a point encoded by ProofToJSON. The point must be in the G1 subgroup; (0, 0)
+// decodes to the point at infinity.
+func ProofFromJSON(data []byte) (bn254.G1Affine, error) {
+	var encoded pointJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return bn254.G1Affine{}, fmt.Errorf("unable to unmarshal proof: %w", err)
+	}
+
+	var p bn254.G1Affine
+	for _, c := range []struct {
+		name  string
+		value string
+		coord *fp.Element
+	}{
+		{"x", encoded.X, &p.X},
+		{"y", encoded.Y, &p.Y},
+	} {
+		v, ok := new(big.Int).SetString(c.value, 10)
+		if !ok || v.Sign() < 0 || v.Cmp(fp.Modulus()) >= 0 {
+			return bn254.G1Affine{}, fmt.Errorf("%w: %s coordinate %q is not a base field element", ErrInvalidPoint, c.name, c.value)
+		}
+		c.coord.SetBigInt(v)
+	}
+
+	if !p.IsInfinity() && (!p.IsOnCurve() || !p.IsInSubGroup()) {
+		return bn254.G1Affine{}, fmt.Errorf("%w: point is not in the g1 subgroup", ErrInvalidPoint)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,50 @@
+package pedersen
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestProofJSON(t *testing.T) {
+	pk, vk := newTestKeys(t, 3)
+	values := newValues(1, 2, 3)
+
+	commitment, err := pk[0].Commit(values)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	pok, err := pk[0].ProveKnowledge(values)
+	if err != nil {
+		t.Fatalf("unable to prove knowledge: %v", err)
+	}
+
+	data, err := ProofToJSON(pok)
+	if err != nil {
+		t.Fatalf("unable to encode proof: %v", err)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) != 2 || fields["x"] == "" || fields["y"] == "" {
+		t.Fatalf("unexpected encoding %s", data)
+	}
+
+	decoded, err := ProofFromJSON(data)
+	if err != nil {
+		t.Fatalf("unable to decode proof: %v", err)
+	}
+	if err := vk.Verify(commitment, decoded); err != nil {
+		t.Fatalf("round-tripped proof rejected: %v", err)
+	}
+
+	for name, data := range map[string]string{
+		"not on curve": `{"x":"1","y":"3"}`,
+		"not a number": `{"x":"0x1","y":"2"}`,
+		"out of range": `{"x":"21888242871839275222246405745257275088696311157297823662689037894645226208583","y":"2"}`,
+		"negative":     `{"x":"-1","y":"2"}`,
+	} {
+		if _, err := ProofFromJSON([]byte(data)); !errors.Is(err, ErrInvalidPoint) {
+			t.Fatalf("%s: expected %v, got %v", name, ErrInvalidPoint, err)
+		}
+	}
+}
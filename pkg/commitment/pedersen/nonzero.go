@@ -0,0 +1,71 @@
+package pedersen
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ErrZeroValue denotes a nonzero proof requested for a commitment to zero.
+var ErrZeroValue = errors.New("committed value is zero")
+
+// NonZeroProof proves that a Pedersen commitment hides a nonzero value.
+type NonZeroProof struct {
+	T      bn254.G1Affine // commitment to the nonces
+	SA, SB fr.Element     // responses
+}
+
+// ProveNonZero proves that C = value⋅G + rand⋅H hides a nonzero value, without revealing it. With
+// w = value⁻¹, G = w⋅C - w⋅rand⋅H, so the prover shows knowledge of a representation of G in the
+// bases C and H. For value = 0, C is a multiple of H and no such representation can be found
+// without knowing the discrete logarithm of G in base H.
+func ProveNonZero(value, rand fr.Element, gens Generators, transcript *Transcript) (NonZeroProof, error) {
+	if value.IsZero() {
+		return NonZeroProof{}, ErrZeroValue
+	}
+
+	commit := gens.Commit(value, rand)
+
+	var a, b fr.Element
+	a.Inverse(&value)
+	b.Mul(&a, &rand).Neg(&b)
+
+	k1, err := randomScalar()
+	if err != nil {
+		return NonZeroProof{}, err
+	}
+	k2, err := randomScalar()
+	if err != nil {
+		return NonZeroProof{}, err
+	}
+
+	proof := NonZeroProof{T: combine(commit, k1, gens.H, k2)}
+
+	c := nonZeroChallenge(commit, gens, proof, transcript)
+
+	proof.SA = response(k1, c, a)
+	proof.SB = response(k2, c, b)
+
+	return proof, nil
+}
+
+// VerifyNonZero verifies that commit hides a nonzero value, checking SA⋅C + SB⋅H == T + c⋅G.
+func VerifyNonZero(commit bn254.G1Affine, gens Generators, proof NonZeroProof, transcript *Transcript) error {
+	c := nonZeroChallenge(commit, gens, proof, transcript)
+
+	lhs := combine(commit, proof.SA, gens.H, proof.SB)
+	rhs := combine(proof.T, fr.One(), gens.G, c)
+	if !lhs.Equal(&rhs) {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+func nonZeroChallenge(commit bn254.G1Affine, gens Generators, proof NonZeroProof, transcript *Transcript) fr.Element {
+	transcript.Append("generators", gens.Marshal())
+	transcript.Append("commitment", commit.Marshal())
+	transcript.Append("nonce", proof.T.Marshal())
+	return transcript.Challenge("nonzero")
+}
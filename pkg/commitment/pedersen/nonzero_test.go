@@ -0,0 +1,36 @@
+package pedersen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestNonZeroProof(t *testing.T) {
+	gens := newTestGenerators(t, "test")
+	value, rand := newValues(5)[0], newValues(99)[0]
+
+	proof, err := ProveNonZero(value, rand, gens, NewTranscript("test"))
+	if err != nil {
+		t.Fatalf("unable to prove nonzero: %v", err)
+	}
+
+	commit := gens.Commit(value, rand)
+	if err := VerifyNonZero(commit, gens, proof, NewTranscript("test")); err != nil {
+		t.Fatalf("nonzero proof rejected: %v", err)
+	}
+
+	tampered, err := Tamper(commit)
+	if err != nil {
+		t.Fatalf("unable to tamper with commitment: %v", err)
+	}
+	if err := VerifyNonZero(tampered, gens, proof, NewTranscript("test")); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", ErrInvalidProof, err)
+	}
+
+	var zero fr.Element
+	if _, err := ProveNonZero(zero, rand, gens, NewTranscript("test")); !errors.Is(err, ErrZeroValue) {
+		t.Fatalf("expected %v, got %v", ErrZeroValue, err)
+	}
+}
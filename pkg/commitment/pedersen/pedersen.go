@@ -0,0 +1,118 @@
+package pedersen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+var (
+	// ErrLengthMismatch denotes that the number of commitments does not match the number of keys.
+	ErrLengthMismatch = errors.New("length mismatch")
+)
+
+// SetupContext is pedersen_bn254.Setup returning ctx.Err() as soon as ctx is done. The setup
+// itself cannot be interrupted: it goes on in the background and its result is dropped.
+func SetupContext(ctx context.Context, bases [][]bn254.G1Affine, opts ...pedersen_bn254.SetupOption) ([]pedersen_bn254.ProvingKey, pedersen_bn254.VerifyingKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, pedersen_bn254.VerifyingKey{}, err
+	}
+
+	type result struct {
+		pk  []pedersen_bn254.ProvingKey
+		vk  pedersen_bn254.VerifyingKey
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		pk, vk, err := pedersen_bn254.Setup(bases, opts...)
+		done <- result{pk, vk, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, pedersen_bn254.VerifyingKey{}, fmt.Errorf("unable to run setup: %w", r.err)
+		}
+		return r.pk, r.vk, nil
+	case <-ctx.Done():
+		return nil, pedersen_bn254.VerifyingKey{}, ctx.Err()
+	}
+}
+
+// BatchProve commits to every value vector and proves knowledge of all of them with a single
+// proof. The combination coefficient is derived from the transcript after binding the commitments,
+// which must therefore be in the same state as the one later given to BatchVerify.
+func BatchProve(pk []pedersen_bn254.ProvingKey, values [][]fr.Element, transcript *Transcript) ([]bn254.G1Affine, bn254.G1Affine, error) {
+	if len(pk) != len(values) {
+		return nil, bn254.G1Affine{}, fmt.Errorf("%w: %d proving keys, %d value vectors", ErrLengthMismatch, len(pk), len(values))
+	}
+
+	commitments := make([]bn254.G1Affine, len(pk))
+	for i := range pk {
+		commitment, err := pk[i].Commit(values[i])
+		if err != nil {
+			return nil, bn254.G1Affine{}, fmt.Errorf("unable to commit to values %d: %w", i, err)
+		}
+		commitments[i] = commitment
+	}
+
+	coeff := combinationCoeff(commitments, transcript)
+
+	pok, err := pedersen_bn254.BatchProve(pk, values, coeff)
+	if err != nil {
+		return nil, bn254.G1Affine{}, fmt.Errorf("unable to batch prove: %w", err)
+	}
+
+	return commitments, pok, nil
+}
+
+// BatchVerify verifies a proof of knowledge produced by BatchProve, re-deriving the combination
+// coefficient from the transcript and the commitments.
+func BatchVerify(vk []pedersen_bn254.VerifyingKey, commitments []bn254.G1Affine, pok bn254.G1Affine, transcript *Transcript) error {
+	if len(vk) != len(commitments) {
+		return fmt.Errorf("%w: %d verifying keys, %d commitments", ErrLengthMismatch, len(vk), len(commitments))
+	}
+
+	coeff := combinationCoeff(commitments, transcript)
+
+	if err := batchVerifyMultiVk(vk, commitments, []bn254.G1Affine{pok}, coeff); err != nil {
+		return fmt.Errorf("unable to verify batch proof: %w", err)
+	}
+
+	return nil
+}
+
+// FoldCommitments derives the combination coefficient from the commitments, as BatchProve does,
+// and returns Σ coeffⁱ⋅commits[i]. When all commitments were made with keys of the same setup,
+// the folded commitment verifies against the batch proof with the single verifying key. The
+// transcript must be in the same state as the one given to BatchProve.
+func FoldCommitments(commits []bn254.G1Affine, transcript *Transcript) (folded bn254.G1Affine, coeff fr.Element, err error) {
+	coeff = combinationCoeff(commits, transcript)
+
+	powers := make([]fr.Element, len(commits))
+	if len(powers) > 0 {
+		powers[0].SetOne()
+	}
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &coeff)
+	}
+
+	if _, err := folded.MultiExp(commits, powers, ecc.MultiExpConfig{}); err != nil {
+		return bn254.G1Affine{}, fr.Element{}, fmt.Errorf("unable to fold commitments: %w", err)
+	}
+	return folded, coeff, nil
+}
+
+// combinationCoeff binds the commitments into the transcript and derives the folding coefficient.
+func combinationCoeff(commitments []bn254.G1Affine, transcript *Transcript) fr.Element {
+	for i := range commitments {
+		transcript.Append("commitment", commitments[i].Marshal())
+	}
+	return transcript.Challenge("combination")
+}
@@ -0,0 +1,149 @@
+package pedersen
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// randomBases returns n random G1 points.
+func randomBases(t testing.TB, n int) []bn254.G1Affine {
+	t.Helper()
+
+	bases := make([]bn254.G1Affine, n)
+	for i := range bases {
+		var s fr.Element
+		if _, err := s.SetRandom(); err != nil {
+			t.Fatalf("unable to sample scalar: %v", err)
+		}
+		bases[i].ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+	}
+	return bases
+}
+
+// newTestKeys runs a setup with one proving key per entry of sizes.
+func newTestKeys(t testing.TB, sizes ...int) ([]pedersen_bn254.ProvingKey, pedersen_bn254.VerifyingKey) {
+	t.Helper()
+
+	bases := make([][]bn254.G1Affine, len(sizes))
+	for i, size := range sizes {
+		bases[i] = randomBases(t, size)
+	}
+
+	pk, vk, err := pedersen_bn254.Setup(bases)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	return pk, vk
+}
+
+// newValues returns field elements for the given integers.
+func newValues(values ...uint64) []fr.Element {
+	res := make([]fr.Element, len(values))
+	for i, v := range values {
+		res[i].SetUint64(v)
+	}
+	return res
+}
+
+func TestBatchProveVerify(t *testing.T) {
+	pk, vk := newTestKeys(t, 2, 3)
+	vks := []pedersen_bn254.VerifyingKey{vk, vk}
+	values := [][]fr.Element{newValues(1, 2), newValues(3, 4, 5)}
+
+	commitments, pok, err := BatchProve(pk, values, NewTranscript("test"))
+	if err != nil {
+		t.Fatalf("unable to batch prove: %v", err)
+	}
+
+	if err := BatchVerify(vks, commitments, pok, NewTranscript("test")); err != nil {
+		t.Fatalf("batch proof rejected: %v", err)
+	}
+
+	if err := BatchVerify(vks, commitments, pok, NewTranscript("other")); err == nil {
+		t.Fatal("batch proof accepted under a different transcript domain")
+	}
+
+	swapped := []bn254.G1Affine{commitments[1], commitments[0]}
+	if err := BatchVerify(vks, swapped, pok, NewTranscript("test")); err == nil {
+		t.Fatal("batch proof accepted with reordered commitments")
+	}
+}
+
+func TestFoldCommitments(t *testing.T) {
+	pk, vk := newTestKeys(t, 2, 2, 2)
+	values := [][]fr.Element{newValues(1, 2), newValues(3, 4), newValues(5, 6)}
+
+	commitments, pok, err := BatchProve(pk, values, NewTranscript("test"))
+	if err != nil {
+		t.Fatalf("unable to batch prove: %v", err)
+	}
+
+	folded, coeff, err := FoldCommitments(commitments, NewTranscript("test"))
+	if err != nil {
+		t.Fatalf("unable to fold commitments: %v", err)
+	}
+	if err := vk.Verify(folded, pok); err != nil {
+		t.Fatalf("folded commitment rejected: %v", err)
+	}
+
+	// the coefficient is the one BatchProve derived from the same transcript
+	direct, err := pedersen_bn254.BatchProve(pk, values, coeff)
+	if err != nil {
+		t.Fatalf("unable to batch prove: %v", err)
+	}
+	if !direct.Equal(&pok) {
+		t.Fatal("coefficient differs from the one used by BatchProve")
+	}
+
+	other, _, err := FoldCommitments(commitments, NewTranscript("other"))
+	if err != nil {
+		t.Fatalf("unable to fold commitments: %v", err)
+	}
+	if vk.Verify(other, pok) == nil {
+		t.Fatal("commitment folded under another transcript verified")
+	}
+}
+
+func TestSetupContext(t *testing.T) {
+	pk, vk, err := SetupContext(t.Context(), [][]bn254.G1Affine{randomBases(t, 2)})
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	values := newValues(1, 2)
+	commitment, err := pk[0].Commit(values)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	pok, err := pk[0].ProveKnowledge(values)
+	if err != nil {
+		t.Fatalf("unable to prove knowledge: %v", err)
+	}
+	if err := vk.Verify(commitment, pok); err != nil {
+		t.Fatalf("proof of knowledge rejected: %v", err)
+	}
+
+	// repeating the generator keeps the bases cheap to build, the setup still scales with their number
+	_, _, g1, _ := bn254.Generators()
+	large := make([]bn254.G1Affine, 1<<16)
+	for i := range large {
+		large[i] = g1
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, _, err := SetupContext(ctx, [][]bn254.G1Affine{large}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned %s after the deadline", elapsed)
+	}
+}
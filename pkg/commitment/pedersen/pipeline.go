@@ -0,0 +1,63 @@
+package pedersen
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// CommitAndProveBatch commits to every value set with its proving key and proves knowledge of the
+// committed values, i.e. commitments[i] = pk[i].Commit(valuesSets[i]) and proofs[i] =
+// pk[i].ProveKnowledge(valuesSets[i]). Sets are spread over up to GOMAXPROCS goroutines, each
+// committing to and proving one set before taking the next, so results are returned in input
+// order regardless of which set finishes first. If several sets fail, the error of the first one
+// is returned.
+func CommitAndProveBatch(valuesSets [][]fr.Element, pk []pedersen_bn254.ProvingKey) ([]bn254.G1Affine, []bn254.G1Affine, error) {
+	if len(pk) != len(valuesSets) {
+		return nil, nil, fmt.Errorf("%w: %d proving keys, %d value sets", ErrLengthMismatch, len(pk), len(valuesSets))
+	}
+
+	commitments := make([]bn254.G1Affine, len(valuesSets))
+	proofs := make([]bn254.G1Affine, len(valuesSets))
+	errs := make([]error, len(valuesSets))
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(valuesSets)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				commitments[i], proofs[i], errs[i] = commitAndProve(valuesSets[i], pk[i])
+			}
+		}()
+	}
+	for i := range valuesSets {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to commit to and prove values %d: %w", i, err)
+		}
+	}
+
+	return commitments, proofs, nil
+}
+
+// commitAndProve commits to values and proves knowledge of them.
+func commitAndProve(values []fr.Element, pk pedersen_bn254.ProvingKey) (commitment, proof bn254.G1Affine, err error) {
+	if commitment, err = pk.Commit(values); err != nil {
+		return bn254.G1Affine{}, bn254.G1Affine{}, err
+	}
+	if proof, err = pk.ProveKnowledge(values); err != nil {
+		return bn254.G1Affine{}, bn254.G1Affine{}, err
+	}
+	return commitment, proof, nil
+}
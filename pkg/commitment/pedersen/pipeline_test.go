@@ -0,0 +1,56 @@
+package pedersen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestCommitAndProveBatch(t *testing.T) {
+	const nbSets = 16
+
+	sizes := make([]int, nbSets)
+	valuesSets := make([][]fr.Element, nbSets)
+	for i := range sizes {
+		sizes[i] = 1 + i%4
+		valuesSets[i] = make([]fr.Element, sizes[i])
+		for j := range valuesSets[i] {
+			valuesSets[i][j].SetUint64(uint64(100*i + j + 1))
+		}
+	}
+	pk, vk := newTestKeys(t, sizes...)
+
+	commitments, proofs, err := CommitAndProveBatch(valuesSets, pk)
+	if err != nil {
+		t.Fatalf("unable to commit and prove: %v", err)
+	}
+	if len(commitments) != nbSets || len(proofs) != nbSets {
+		t.Fatalf("got %d commitments and %d proofs, want %d", len(commitments), len(proofs), nbSets)
+	}
+
+	for i := range valuesSets {
+		want, err := pk[i].Commit(valuesSets[i])
+		if err != nil {
+			t.Fatalf("unable to commit to values %d: %v", i, err)
+		}
+		if !commitments[i].Equal(&want) {
+			t.Fatalf("commitment %d is not the one of value set %d", i, i)
+		}
+		if err := vk.Verify(commitments[i], proofs[i]); err != nil {
+			t.Fatalf("proof %d rejected: %v", i, err)
+		}
+	}
+
+	if _, _, err := CommitAndProveBatch(valuesSets[1:], pk); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+
+	// a value set that does not fit its key fails the whole batch, naming the first failing set
+	valuesSets[5] = append(valuesSets[5], fr.One())
+	valuesSets[9] = nil
+	if _, _, err := CommitAndProveBatch(valuesSets, pk); err == nil || !strings.Contains(err.Error(), "values 5") {
+		t.Fatalf("expected an error for values 5, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package pedersen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+	"github.com/hblocks/keyless/pkg/commitment"
+)
+
+var _ commitment.CommitmentScheme = (*scheme)(nil)
+
+type scheme struct {
+	pk pedersen_bn254.ProvingKey
+	vk pedersen_bn254.VerifyingKey
+}
+
+// NewScheme returns the Pedersen commitment scheme for the keys of a setup. Commitments and proofs
+// are *bn254.G1Affine; the proof is a proof of knowledge of the committed values.
+func NewScheme(pk pedersen_bn254.ProvingKey, vk pedersen_bn254.VerifyingKey) commitment.CommitmentScheme {
+	return &scheme{pk: pk, vk: vk}
+}
+
+func (s *scheme) Commit(values []fr.Element) (commitment.Commitment, error) {
+	c, err := s.pk.Commit(values)
+	if err != nil {
+		return nil, fmt.Errorf("unable to commit: %w", err)
+	}
+	return &c, nil
+}
+
+func (s *scheme) Open(values []fr.Element) (commitment.Proof, error) {
+	pok, err := s.pk.ProveKnowledge(values)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove knowledge: %w", err)
+	}
+	return &pok, nil
+}
+
+func (s *scheme) Verify(c commitment.Commitment, values []fr.Element, proof commitment.Proof) error {
+	point, ok := c.(*bn254.G1Affine)
+	if !ok {
+		return fmt.Errorf("%w: commitment is %T", commitment.ErrWrongScheme, c)
+	}
+	pok, ok := proof.(*bn254.G1Affine)
+	if !ok {
+		return fmt.Errorf("%w: proof is %T", commitment.ErrWrongScheme, proof)
+	}
+
+	if err := s.vk.Verify(*point, *pok); err != nil {
+		return fmt.Errorf("unable to verify proof of knowledge: %w", err)
+	}
+
+	expected, err := s.pk.Commit(values)
+	if err != nil {
+		return fmt.Errorf("unable to commit: %w", err)
+	}
+	if !expected.Equal(point) {
+		return errors.New("commitment does not open to the values")
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package pedersen
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// CommitmentToSolidity returns the affine coordinates of c as the uint256 pair of a Solidity
+// G1Point struct. The point at infinity maps to (0, 0), as in the EIP-196 precompiles.
+func CommitmentToSolidity(c bn254.G1Affine) (x, y *big.Int) {
+	x, y = new(big.Int), new(big.Int)
+	c.X.BigInt(x)
+	c.Y.BigInt(y)
+	return x, y
+}
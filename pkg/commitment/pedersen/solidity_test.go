@@ -0,0 +1,41 @@
+package pedersen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+func TestCommitmentToSolidity(t *testing.T) {
+	pk, _ := newTestKeys(t, 3)
+
+	commitment, err := pk[0].Commit(newValues(1, 2, 3))
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+
+	x, y := CommitmentToSolidity(commitment)
+
+	var p bn254.G1Affine
+	if _, err := p.X.SetString(x.String()); err != nil {
+		t.Fatalf("unable to set x: %v", err)
+	}
+	if _, err := p.Y.SetString(y.String()); err != nil {
+		t.Fatalf("unable to set y: %v", err)
+	}
+	if !p.Equal(&commitment) {
+		t.Fatal("coordinates do not reconstruct the commitment")
+	}
+
+	// abi.encode(G1Point) is the 32-byte big-endian x followed by y, the uncompressed encoding
+	encoded := append(x.FillBytes(make([]byte, 32)), y.FillBytes(make([]byte, 32))...)
+	if raw := commitment.RawBytes(); !bytes.Equal(encoded, raw[:]) {
+		t.Fatalf("abi encoding %x, want %x", encoded, raw)
+	}
+
+	var infinity bn254.G1Affine
+	if x, y := CommitmentToSolidity(infinity); x.Sign() != 0 || y.Sign() != 0 {
+		t.Fatalf("point at infinity maps to (%s, %s), want (0, 0)", x, y)
+	}
+}
@@ -0,0 +1,221 @@
+package pedersen
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+var (
+	// ErrNotStruct denotes a value given to CommitStruct that is neither a struct nor a pointer to one.
+	ErrNotStruct = errors.New("value is not a struct")
+	// ErrUnsupportedField denotes a struct field whose type has no field element encoding.
+	ErrUnsupportedField = errors.New("unsupported struct field")
+)
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	frElementType = reflect.TypeOf(fr.Element{})
+)
+
+// CommitStruct commits to the exported fields of v, a struct or a pointer to one, as encoded by
+// StructValues. pk must have one base per committed field plus one for the schema hash.
+func CommitStruct(v interface{}, pk pedersen_bn254.ProvingKey) (bn254.G1Affine, error) {
+	values, err := StructValues(v)
+	if err != nil {
+		return bn254.G1Affine{}, err
+	}
+
+	commitment, err := pk.Commit(values)
+	if err != nil {
+		return bn254.G1Affine{}, fmt.Errorf("unable to commit to struct: %w", err)
+	}
+
+	return commitment, nil
+}
+
+// StructValues returns the values CommitStruct commits to: the SchemaHash of v followed by one
+// element per exported field, in declaration order, with nested structs flattened in place.
+// Fields tagged `pedersen:"-"` are skipped. Fields are encoded as follows:
+//
+//   - signed and unsigned integers, big.Int and *big.Int are reduced with ToField;
+//   - fr.Element is taken as is;
+//   - []byte, byte arrays and strings are hashed with MiMCToField.
+//
+// A nil *big.Int or any other field type is rejected with ErrUnsupportedField. Leading with the
+// schema hash means that renaming, reordering or retyping fields changes the commitment instead of
+// silently committing the same values in another order.
+func StructValues(v interface{}) ([]fr.Element, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := SchemaHash(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []fr.Element{schema}
+	return appendStructValues(values, rv, "")
+}
+
+// SchemaHash hashes the names and types of the fields StructValues encodes, in order, and
+// reduces the digest to a field element. Two structs have the same schema hash if and only if
+// their committed fields match, whatever their package or type name.
+func SchemaHash(v interface{}) (fr.Element, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return fr.Element{}, err
+	}
+
+	var schema strings.Builder
+	if err := writeSchema(&schema, rv.Type(), ""); err != nil {
+		return fr.Element{}, err
+	}
+
+	digest := sha256.Sum256([]byte(schema.String()))
+
+	var e fr.Element
+	e.SetBytes(digest[:])
+	return e, nil
+}
+
+// structValue dereferences v down to a struct.
+func structValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%w: nil %s", ErrNotStruct, rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: %T", ErrNotStruct, v)
+	}
+	return rv, nil
+}
+
+// committedFields returns the indices of the fields of t that are committed to.
+func committedFields(t reflect.Type) []int {
+	var indices []int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("pedersen") == "-" {
+			continue
+		}
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// isNestedStruct tells whether a field of type t is flattened rather than encoded as one value.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != bigIntType
+}
+
+// writeSchema writes "name:type;" for every committed field of t, prefixing nested field names
+// with the name of their parent.
+func writeSchema(schema *strings.Builder, t reflect.Type, prefix string) error {
+	for _, i := range committedFields(t) {
+		f := t.Field(i)
+		name := prefix + f.Name
+
+		if isNestedStruct(f.Type) {
+			if err := writeSchema(schema, f.Type, name+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		if !isSupportedField(f.Type) {
+			return fmt.Errorf("%w: %s has type %s", ErrUnsupportedField, name, f.Type)
+		}
+		fmt.Fprintf(schema, "%s:%s;", name, f.Type)
+	}
+	return nil
+}
+
+// isSupportedField tells whether a field of type t has an encoding, see StructValues.
+func isSupportedField(t reflect.Type) bool {
+	switch {
+	case t == frElementType, t == bigIntType, t == reflect.PointerTo(bigIntType):
+		return true
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.String:
+		return true
+	}
+	return false
+}
+
+// appendStructValues appends the encoding of every committed field of rv to values, naming nested
+// fields as writeSchema does.
+func appendStructValues(values []fr.Element, rv reflect.Value, prefix string) ([]fr.Element, error) {
+	t := rv.Type()
+	for _, i := range committedFields(t) {
+		name := prefix + t.Field(i).Name
+		field := rv.Field(i)
+
+		if isNestedStruct(field.Type()) {
+			var err error
+			if values, err = appendStructValues(values, field, name+"."); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		e, err := fieldElement(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrUnsupportedField, name, err)
+		}
+		values = append(values, e)
+	}
+	return values, nil
+}
+
+// fieldElement encodes a single field, see StructValues.
+func fieldElement(field reflect.Value) (fr.Element, error) {
+	switch t := field.Type(); {
+	case t == frElementType:
+		return field.Interface().(fr.Element), nil
+	case t == bigIntType:
+		v := field.Interface().(big.Int)
+		return ToField(&v), nil
+	case t == reflect.PointerTo(bigIntType):
+		if field.IsNil() {
+			return fr.Element{}, errors.New("nil big.Int")
+		}
+		return ToField(field.Interface().(*big.Int)), nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return MiMCToField(field.Bytes()), nil
+	case t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8:
+		b := make([]byte, field.Len())
+		reflect.Copy(reflect.ValueOf(b), field)
+		return MiMCToField(b), nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ToField(big.NewInt(field.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var e fr.Element
+		e.SetUint64(field.Uint())
+		return e, nil
+	case reflect.String:
+		return MiMCToField([]byte(field.String())), nil
+	}
+
+	return fr.Element{}, fmt.Errorf("type %s", field.Type())
+}
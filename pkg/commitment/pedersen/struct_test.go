@@ -0,0 +1,111 @@
+package pedersen
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+type account struct {
+	Balance uint64
+	Delta   int32
+	Owner   [20]byte
+	Limits  struct {
+		Daily *big.Int
+	}
+	Memo    string `pedersen:"-"`
+	private uint64
+}
+
+// reorderedAccount holds the fields of account in another order.
+type reorderedAccount struct {
+	Delta   int32
+	Balance uint64
+	Owner   [20]byte
+	Limits  struct {
+		Daily *big.Int
+	}
+}
+
+func TestCommitStruct(t *testing.T) {
+	pk, vk := newTestKeys(t, 5)
+
+	newAccount := func() *account {
+		a := &account{Balance: 100, Delta: -3, Owner: [20]byte{1, 2, 3}, Memo: "ignored", private: 7}
+		a.Limits.Daily = big.NewInt(1000)
+		return a
+	}
+
+	first, err := CommitStruct(newAccount(), pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	second, err := CommitStruct(*newAccount(), pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if !first.Equal(&second) {
+		t.Fatal("same struct committed differently")
+	}
+
+	// skipped and unexported fields are not committed to
+	other := newAccount()
+	other.Memo, other.private = "changed", 8
+	if third, err := CommitStruct(other, pk[0]); err != nil || !third.Equal(&first) {
+		t.Fatalf("skipped fields changed the commitment, err: %v", err)
+	}
+
+	schema := sha256.Sum256([]byte("Balance:uint64;Delta:int32;Owner:[20]uint8;Limits.Daily:*big.Int;"))
+	owner := [20]byte{1, 2, 3}
+	var want [5]fr.Element
+	want[0].SetBytes(schema[:])
+	want[1].SetUint64(100)
+	want[2] = ToField(big.NewInt(-3))
+	want[3] = MiMCToField(owner[:])
+	want[4].SetUint64(1000)
+
+	values, err := StructValues(newAccount())
+	if err != nil {
+		t.Fatalf("unable to encode struct: %v", err)
+	}
+	if len(values) != len(want) {
+		t.Fatalf("got %d values, want %d", len(values), len(want))
+	}
+	for i := range want {
+		if !values[i].Equal(&want[i]) {
+			t.Fatalf("value %d: got %s, want %s", i, values[i].String(), want[i].String())
+		}
+	}
+
+	pok, err := pk[0].ProveKnowledge(values)
+	if err != nil {
+		t.Fatalf("unable to prove knowledge: %v", err)
+	}
+	if err := vk.Verify(first, pok); err != nil {
+		t.Fatalf("proof of knowledge rejected: %v", err)
+	}
+
+	reordered := reorderedAccount{Delta: -3, Balance: 100, Owner: owner}
+	reordered.Limits.Daily = big.NewInt(1000)
+	commitment, err := CommitStruct(reordered, pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if commitment.Equal(&first) {
+		t.Fatal("reordered fields committed identically")
+	}
+
+	unsupported := struct{ Ratio float64 }{0.5}
+	if _, err := CommitStruct(unsupported, pk[0]); !errors.Is(err, ErrUnsupportedField) {
+		t.Fatalf("expected %v, got %v", ErrUnsupportedField, err)
+	}
+	if _, err := CommitStruct(&account{}, pk[0]); !errors.Is(err, ErrUnsupportedField) {
+		t.Fatalf("expected %v for a nil big.Int, got %v", ErrUnsupportedField, err)
+	}
+	if _, err := CommitStruct(42, pk[0]); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("expected %v, got %v", ErrNotStruct, err)
+	}
+}
@@ -0,0 +1,40 @@
+package pedersen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+var (
+	// ErrUnexpectedVerification denotes that a proof verified although it was expected to be rejected.
+	ErrUnexpectedVerification = errors.New("verification unexpectedly succeeded")
+)
+
+// Tamper returns c multiplied by a random scalar other than 0 and 1, i.e. a valid curve point
+// that is distinct from c. It is meant for tests showing that verification rejects altered commitments.
+func Tamper(c bn254.G1Affine) (bn254.G1Affine, error) {
+	var s fr.Element
+	for s.IsZero() || s.IsOne() {
+		var err error
+		if s, err = randomScalar(); err != nil {
+			return bn254.G1Affine{}, err
+		}
+	}
+
+	var tampered bn254.G1Affine
+	tampered.ScalarMultiplication(&c, s.BigInt(new(big.Int)))
+	return tampered, nil
+}
+
+// AssertVerifyFails returns nil if vk rejects the proof of knowledge for commit, and
+// ErrUnexpectedVerification if it accepts it.
+func AssertVerifyFails(vk pedersen_bn254.VerifyingKey, commit, proof bn254.G1Affine) error {
+	if err := vk.Verify(commit, proof); err != nil {
+		return nil
+	}
+	return ErrUnexpectedVerification
+}
@@ -0,0 +1,46 @@
+package pedersen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTamper(t *testing.T) {
+	pk, vk := newTestKeys(t, 3)
+	values := newValues(10, 20, 30)
+
+	commit, err := pk[0].Commit(values)
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	proof, err := pk[0].ProveKnowledge(values)
+	if err != nil {
+		t.Fatalf("unable to prove knowledge: %v", err)
+	}
+
+	tampered, err := Tamper(commit)
+	if err != nil {
+		t.Fatalf("unable to tamper with commitment: %v", err)
+	}
+	if tampered.Equal(&commit) {
+		t.Fatal("tampered commitment equals the original")
+	}
+	if !tampered.IsOnCurve() || !tampered.IsInSubGroup() {
+		t.Fatal("tampered commitment is not a valid group element")
+	}
+
+	if err := AssertVerifyFails(vk, tampered, proof); err != nil {
+		t.Fatalf("tampered commitment was accepted: %v", err)
+	}
+	tamperedProof, err := Tamper(proof)
+	if err != nil {
+		t.Fatalf("unable to tamper with proof: %v", err)
+	}
+	if err := AssertVerifyFails(vk, commit, tamperedProof); err != nil {
+		t.Fatalf("tampered proof was accepted: %v", err)
+	}
+
+	if err := AssertVerifyFails(vk, commit, proof); !errors.Is(err, ErrUnexpectedVerification) {
+		t.Fatalf("expected %v for a valid proof, got %v", ErrUnexpectedVerification, err)
+	}
+}
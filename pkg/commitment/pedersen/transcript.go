@@ -0,0 +1,65 @@
+package pedersen
+
+import (
+	"encoding/binary"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// Transcript is a Fiat-Shamir transcript. Every message appended to it is bound, together with
+// its label, into the challenges derived afterwards, so a prover cannot pick the challenge.
+type Transcript struct {
+	state       []byte
+	hashToField HashToField
+}
+
+// TranscriptOption configures a Transcript.
+type TranscriptOption interface {
+	apply(*Transcript)
+}
+
+type transcriptOptionFunc func(*Transcript)
+
+func (f transcriptOptionFunc) apply(t *Transcript) { f(t) }
+
+// WithHashToField sets the hash challenges are derived with. Defaults to SHA256ToField.
+func WithHashToField(h HashToField) TranscriptOption {
+	return transcriptOptionFunc(func(t *Transcript) {
+		t.hashToField = h
+	})
+}
+
+// NewTranscript returns an empty transcript separated by the given domain label.
+func NewTranscript(domain string, opts ...TranscriptOption) *Transcript {
+	t := &Transcript{hashToField: SHA256ToField}
+	for _, opt := range opts {
+		opt.apply(t)
+	}
+
+	t.Append("domain", []byte(domain))
+	return t
+}
+
+// Append binds a labelled message into the transcript.
+func (t *Transcript) Append(label string, data []byte) {
+	t.writeFramed([]byte(label))
+	t.writeFramed(data)
+}
+
+// Challenge derives a field element from everything appended so far. The challenge is itself
+// bound into the transcript, so consecutive challenges differ.
+func (t *Transcript) Challenge(label string) fr.Element {
+	t.writeFramed([]byte(label))
+
+	challenge := t.hashToField(t.state)
+	challengeBytes := challenge.Bytes()
+	t.writeFramed(challengeBytes[:])
+
+	return challenge
+}
+
+// writeFramed writes data prefixed by its length, so that concatenations are unambiguous.
+func (t *Transcript) writeFramed(data []byte) {
+	t.state = binary.BigEndian.AppendUint64(t.state, uint64(len(data)))
+	t.state = append(t.state, data...)
+}
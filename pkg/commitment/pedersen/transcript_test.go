@@ -0,0 +1,97 @@
+package pedersen
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+func TestTranscriptChallenge(t *testing.T) {
+	pk, _ := newTestKeys(t, 1, 1)
+
+	a, err := pk[0].Commit(newValues(1))
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	b, err := pk[1].Commit(newValues(2))
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+
+	challenge := func(domain string, first, second []byte) fr.Element {
+		transcript := NewTranscript(domain)
+		transcript.Append("commitment", first)
+		transcript.Append("commitment", second)
+		return transcript.Challenge("combination")
+	}
+
+	ab := challenge("test", a.Marshal(), b.Marshal())
+	ba := challenge("test", b.Marshal(), a.Marshal())
+	if ab.Equal(&ba) {
+		t.Fatal("different commitment orders yield the same challenge")
+	}
+
+	if again := challenge("test", a.Marshal(), b.Marshal()); !again.Equal(&ab) {
+		t.Fatal("identical transcripts yield different challenges")
+	}
+
+	if other := challenge("other", a.Marshal(), b.Marshal()); other.Equal(&ab) {
+		t.Fatal("different domains yield the same challenge")
+	}
+}
+
+func TestTranscriptFraming(t *testing.T) {
+	first := NewTranscript("test")
+	first.Append("ab", []byte("c"))
+
+	second := NewTranscript("test")
+	second.Append("a", []byte("bc"))
+
+	c1, c2 := first.Challenge("x"), second.Challenge("x")
+	if c1.Equal(&c2) {
+		t.Fatal("ambiguous label/data split yields the same challenge")
+	}
+
+	c3 := first.Challenge("x")
+	if c1.Equal(&c3) {
+		t.Fatal("consecutive challenges are equal")
+	}
+}
+
+func TestTranscriptHashToField(t *testing.T) {
+	challenge := func(opts ...TranscriptOption) fr.Element {
+		transcript := NewTranscript("test", opts...)
+		transcript.Append("commitment", []byte("data"))
+		return transcript.Challenge("combination")
+	}
+
+	sha := challenge()
+	if explicit := challenge(WithHashToField(SHA256ToField)); !explicit.Equal(&sha) {
+		t.Fatal("default hash is not SHA256ToField")
+	}
+
+	mimc := challenge(WithHashToField(MiMCToField))
+	if mimc.Equal(&sha) {
+		t.Fatal("swapping the hash does not change the challenge")
+	}
+	if again := challenge(WithHashToField(MiMCToField)); !again.Equal(&mimc) {
+		t.Fatal("MiMC challenges are not deterministic")
+	}
+
+	// a batch proof only verifies with the hash it was produced with
+	pk, vk := newTestKeys(t, 2, 3)
+	vks := []pedersen_bn254.VerifyingKey{vk, vk}
+	values := [][]fr.Element{newValues(1, 2), newValues(3, 4, 5)}
+
+	commitments, pok, err := BatchProve(pk, values, NewTranscript("test", WithHashToField(MiMCToField)))
+	if err != nil {
+		t.Fatalf("unable to batch prove: %v", err)
+	}
+	if err := BatchVerify(vks, commitments, pok, NewTranscript("test", WithHashToField(MiMCToField))); err != nil {
+		t.Fatalf("proof rejected with matching hash: %v", err)
+	}
+	if err := BatchVerify(vks, commitments, pok, NewTranscript("test")); err == nil {
+		t.Fatal("proof accepted with a different hash")
+	}
+}
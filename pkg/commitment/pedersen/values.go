@@ -0,0 +1,38 @@
+package pedersen
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// ToField reduces v modulo the scalar field order. Negative values map to their additive inverse,
+// e.g. -1 maps to r - 1.
+func ToField(v *big.Int) fr.Element {
+	reduced := new(big.Int).Mod(v, fr.Modulus()) // Euclidean modulus, always non-negative
+
+	var e fr.Element
+	e.SetBigInt(reduced)
+	return e
+}
+
+// CommitBig commits to arbitrary integers, reducing each of them with ToField.
+func CommitBig(values []*big.Int, pk pedersen_bn254.ProvingKey) (bn254.G1Affine, error) {
+	elements := make([]fr.Element, len(values))
+	for i, v := range values {
+		if v == nil {
+			return bn254.G1Affine{}, fmt.Errorf("value %d is nil", i)
+		}
+		elements[i] = ToField(v)
+	}
+
+	commitment, err := pk.Commit(elements)
+	if err != nil {
+		return bn254.G1Affine{}, fmt.Errorf("unable to commit to values: %w", err)
+	}
+
+	return commitment, nil
+}
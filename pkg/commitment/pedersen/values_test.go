@@ -0,0 +1,45 @@
+package pedersen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestCommitBig(t *testing.T) {
+	pk, vk := newTestKeys(t, 2)
+
+	negative := big.NewInt(-5)
+	large := new(big.Int).Lsh(big.NewInt(3), 70) // does not fit in an uint64
+
+	commitment, err := CommitBig([]*big.Int{negative, large}, pk[0])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+
+	// -5 is committed as r - 5
+	var expected [2]fr.Element
+	expected[0].SetBigInt(new(big.Int).Sub(fr.Modulus(), big.NewInt(5)))
+	expected[1].SetBigInt(large)
+
+	want, err := pk[0].Commit(expected[:])
+	if err != nil {
+		t.Fatalf("unable to commit: %v", err)
+	}
+	if !commitment.Equal(&want) {
+		t.Fatal("commitment of reduced values differs")
+	}
+
+	pok, err := pk[0].ProveKnowledge(expected[:])
+	if err != nil {
+		t.Fatalf("unable to prove knowledge: %v", err)
+	}
+	if err := vk.Verify(commitment, pok); err != nil {
+		t.Fatalf("proof of knowledge rejected: %v", err)
+	}
+
+	if _, err := CommitBig([]*big.Int{nil}, pk[0]); err == nil {
+		t.Fatal("expected an error for a nil value")
+	}
+}
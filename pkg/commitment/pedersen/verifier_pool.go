@@ -0,0 +1,90 @@
+package pedersen
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	pedersen_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
+)
+
+// ErrPoolClosed denotes a proof submitted to a VerifierPool after Close.
+var ErrPoolClosed = errors.New("verifier pool closed")
+
+// VerifierPool verifies proofs of knowledge against a verifying key on a fixed number of
+// goroutines, so that callers can pipeline verifications without spawning one goroutine each.
+type VerifierPool struct {
+	vk   pedersen_bn254.VerifyingKey
+	jobs chan verifyJob
+	wg   sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// verifyJob is a proof waiting for a worker, with the channel its result is sent on.
+type verifyJob struct {
+	commitment bn254.G1Affine
+	proof      bn254.G1Affine
+	res        chan error
+}
+
+// NewVerifierPool starts workers goroutines verifying proofs with vk, or GOMAXPROCS of them if
+// workers is not positive. The pool must be closed once no more proofs are submitted.
+func NewVerifierPool(vk pedersen_bn254.VerifyingKey, workers int) *VerifierPool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	p := &VerifierPool{
+		vk:   vk,
+		jobs: make(chan verifyJob, workers),
+	}
+	for w := 0; w < workers; w++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit queues the verification of proof for commitment and returns the channel its result,
+// nil or the error of vk.Verify, is sent on. The channel is buffered, so results that are never
+// received do not hold up the workers. Submit blocks while all the workers are busy and the
+// queue, as long as the number of workers, is full. Once the pool is closed, the result is
+// ErrPoolClosed.
+func (p *VerifierPool) Submit(commitment, proof bn254.G1Affine) <-chan error {
+	res := make(chan error, 1)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		res <- ErrPoolClosed
+		return res
+	}
+	p.jobs <- verifyJob{commitment: commitment, proof: proof, res: res}
+
+	return res
+}
+
+// Close stops accepting proofs and waits for the submitted ones to be verified.
+func (p *VerifierPool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *VerifierPool) work() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		job.res <- p.vk.Verify(job.commitment, job.proof)
+	}
+}
@@ -0,0 +1,61 @@
+package pedersen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+func TestVerifierPool(t *testing.T) {
+	const nbProofs = 32
+
+	pk, vk := newTestKeys(t, 3)
+
+	commitments := make([]bn254.G1Affine, nbProofs)
+	proofs := make([]bn254.G1Affine, nbProofs)
+	for i := range commitments {
+		values := newValues(uint64(i), uint64(i+1), uint64(i+2))
+
+		var err error
+		if commitments[i], err = pk[0].Commit(values); err != nil {
+			t.Fatalf("unable to commit to values %d: %v", i, err)
+		}
+		if proofs[i], err = pk[0].ProveKnowledge(values); err != nil {
+			t.Fatalf("unable to prove knowledge of values %d: %v", i, err)
+		}
+	}
+
+	// every third proof is swapped with the one of the next commitment
+	tampered := func(i int) bool { return i%3 == 1 }
+	for i := range proofs {
+		if tampered(i) {
+			proofs[i] = proofs[(i+1)%nbProofs]
+		}
+	}
+
+	pool := NewVerifierPool(vk, 4)
+
+	results := make([]<-chan error, nbProofs)
+	for i := range commitments {
+		results[i] = pool.Submit(commitments[i], proofs[i])
+	}
+
+	// results are received in reverse order, each on its own channel
+	for i := nbProofs - 1; i >= 0; i-- {
+		err := <-results[i]
+		if tampered(i) && err == nil {
+			t.Fatalf("tampered proof %d accepted", i)
+		}
+		if !tampered(i) && err != nil {
+			t.Fatalf("proof %d rejected: %v", i, err)
+		}
+	}
+
+	pool.Close()
+	pool.Close()
+
+	if err := <-pool.Submit(commitments[0], proofs[0]); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("expected %v, got %v", ErrPoolClosed, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package resources holds the assets embedded into the binary, such as the code generation
+// templates, so they can be read regardless of the working directory or where the binary runs.
+package resources
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"text/template"
+)
+
+// templatesDir is the directory of the embedded templates.
+const templatesDir = "templates"
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// Templates returns the file system of the embedded templates, rooted at the templates directory.
+func Templates() fs.FS {
+	templates, err := fs.Sub(embedded, templatesDir)
+	if err != nil {
+		panic(err) // templatesDir is embedded, so it is always a valid sub directory
+	}
+	return templates
+}
+
+// TemplateNames returns the names of the embedded templates, e.g. "eddsa.go.tmpl", sorted.
+func TemplateNames() ([]string, error) {
+	names, err := fs.Glob(Templates(), "*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list templates: %w", err)
+	}
+	return names, nil
+}
+
+// ReadTemplate returns the content of the embedded template called name.
+func ReadTemplate(name string) ([]byte, error) {
+	data, err := fs.ReadFile(Templates(), path.Clean(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// ParseTemplates parses every embedded template into a template set called name. Each template is
+// associated under its file name, so it can be run with ExecuteTemplate(w, "eddsa.go.tmpl", data).
+func ParseTemplates(name string) (*template.Template, error) {
+	tmpl, err := template.New(name).ParseFS(Templates(), "*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse templates: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+)
+
+func TestTemplatesIndependentOfWorkingDirectory(t *testing.T) {
+	// the templates are embedded, so they load from a directory unrelated to the repository
+	t.Chdir(t.TempDir())
+
+	names, err := TemplateNames()
+	if err != nil {
+		t.Fatalf("unable to list templates: %v", err)
+	}
+	want := []string{"doc.go.tmpl", "eddsa.go.tmpl", "eddsa.test.go.tmpl", "marshal.go.tmpl"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("templates %v, want %v", names, want)
+	}
+
+	tmpl, err := ParseTemplates("test")
+	if err != nil {
+		t.Fatalf("unable to parse templates: %v", err)
+	}
+	for _, name := range names {
+		data, err := ReadTemplate(name)
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", name, err)
+		}
+		if len(data) == 0 {
+			t.Fatalf("template %s is empty", name)
+		}
+		if tmpl.Lookup(name) == nil {
+			t.Fatalf("template %s is not defined in the parsed set", name)
+		}
+	}
+
+	if _, err := ReadTemplate("missing.tmpl"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected %v, got %v", fs.ErrNotExist, err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package selftest runs small end-to-end checks of the commitment schemes and the prover, to
+// validate that an environment can run keyless.
+package selftest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/hblocks/keyless/pkg/commitment"
+	"github.com/hblocks/keyless/pkg/commitment/kzg"
+	"github.com/hblocks/keyless/pkg/commitment/pedersen"
+	prover "github.com/hblocks/keyless/pkg/zk/prover"
+	"github.com/sirupsen/logrus"
+)
+
+// Check is a named self-test.
+type Check struct {
+	Name string
+	Run  func() error
+}
+
+// Result is the outcome of a Check.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// DefaultChecks returns the checks of keyless selftest: the Pedersen and KZG commitment schemes
+// and a small Groth16 proof.
+func DefaultChecks() []Check {
+	return []Check{
+		{Name: "pedersen scheme", Run: checkPedersenScheme},
+		{Name: "pedersen domain commitment", Run: checkPedersenDomain},
+		{Name: "kzg scheme", Run: checkKZGScheme},
+		{Name: "kzg batch opening", Run: checkKZGBatch},
+		{Name: "groth16 proof", Run: checkProof},
+	}
+}
+
+// Run runs every check, recovering from panics so that one broken section does not hide the
+// others.
+func Run(checks []Check) []Result {
+	results := make([]Result, len(checks))
+	for i, check := range checks {
+		start := time.Now()
+		results[i] = Result{Name: check.Name, Err: runCheck(check), Duration: time.Since(start)}
+	}
+	return results
+}
+
+func runCheck(check Check) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return check.Run()
+}
+
+// WriteSummary writes a table of the results to w and reports whether all checks passed.
+func WriteSummary(w io.Writer, results []Result) bool {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tRESULT\tTIME\tERROR")
+
+	passed := true
+	for _, r := range results {
+		status, msg := "PASS", ""
+		if r.Err != nil {
+			status, msg, passed = "FAIL", r.Err.Error(), false
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, status, r.Duration.Round(time.Millisecond), msg)
+	}
+	tw.Flush()
+
+	return passed
+}
+
+// checkScheme commits to values, verifies the opening and makes sure other values are rejected.
+func checkScheme(s commitment.CommitmentScheme, values []fr.Element) error {
+	c, err := s.Commit(values)
+	if err != nil {
+		return err
+	}
+	proof, err := s.Open(values)
+	if err != nil {
+		return err
+	}
+	if err := s.Verify(c, values, proof); err != nil {
+		return fmt.Errorf("valid opening rejected: %w", err)
+	}
+
+	other := make([]fr.Element, len(values))
+	copy(other, values)
+	other[0].SetUint64(1337)
+	if s.Verify(c, other, proof) == nil {
+		return errors.New("opening to other values accepted")
+	}
+
+	return nil
+}
+
+func checkPedersenScheme() error {
+	pk, vk, err := pedersen.SetupWithDomain(3, []byte("keyless/selftest"))
+	if err != nil {
+		return err
+	}
+	return checkScheme(pedersen.NewScheme(pk.ProvingKey, vk.VerifyingKey), values(1, 2, 3))
+}
+
+func checkPedersenDomain() error {
+	pk, vk, err := pedersen.SetupWithDomain(2, []byte("keyless/selftest/a"))
+	if err != nil {
+		return err
+	}
+	_, other, err := pedersen.SetupWithDomain(2, []byte("keyless/selftest/b"))
+	if err != nil {
+		return err
+	}
+
+	c, err := pk.Commit(values(4, 5))
+	if err != nil {
+		return err
+	}
+	if err := vk.Verify(c); err != nil {
+		return fmt.Errorf("commitment rejected: %w", err)
+	}
+	if !errors.Is(other.Verify(c), pedersen.ErrDomainMismatch) {
+		return errors.New("commitment accepted under another domain")
+	}
+
+	return nil
+}
+
+func checkKZGScheme() error {
+	srs, err := kzg.GenerateSRS(8, nil)
+	if err != nil {
+		return err
+	}
+	return checkScheme(kzg.NewScheme(srs), values(1, 2, 3, 4))
+}
+
+func checkKZGBatch() error {
+	srs, err := kzg.GenerateSRS(8, nil)
+	if err != nil {
+		return err
+	}
+
+	var point fr.Element
+	point.SetUint64(7)
+
+	polys := [][]fr.Element{values(1, 2), values(3, 4, 5)}
+	commits := make([]kzg_bn254.Digest, len(polys))
+	proofs := make([]kzg_bn254.OpeningProof, len(polys))
+	evals := make([]fr.Element, len(polys))
+	for i, p := range polys {
+		if commits[i], err = kzg.Commit(p, srs.Pk); err != nil {
+			return err
+		}
+		if proofs[i], err = kzg.Open(p, point, srs.Pk); err != nil {
+			return err
+		}
+		evals[i] = kzg.Eval(p, point)
+	}
+
+	return kzg.BatchVerifyAtPoint(commits, proofs, point, evals, srs.Vk)
+}
+
+func checkProof() error {
+	dir, err := os.MkdirTemp("", "keyless-selftest")
+	if err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := prover.DefaultConfig()
+	cfg.OutDir = dir
+	cfg.Logger = logrus.New()
+	cfg.Logger.SetOutput(&bytes.Buffer{})
+
+	return prover.ProveAndExport(cfg, "cubic/v1", &prover.CubicCircuit{}, prover.NewCubicAssignment(big.NewInt(3)))
+}
+
+// values returns field elements for the given integers.
+func values(v ...uint64) []fr.Element {
+	res := make([]fr.Element, len(v))
+	for i := range v {
+		res[i].SetUint64(v[i])
+	}
+	return res
+}
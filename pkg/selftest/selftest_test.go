@@ -0,0 +1,41 @@
+package selftest
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDefaultChecksPass(t *testing.T) {
+	results := Run(DefaultChecks())
+
+	var summary bytes.Buffer
+	if !WriteSummary(&summary, results) {
+		t.Fatalf("self-test failed:\n%s", summary.String())
+	}
+	if got := strings.Count(summary.String(), "PASS"); got != len(DefaultChecks()) {
+		t.Fatalf("%d checks passed, want %d:\n%s", got, len(DefaultChecks()), summary.String())
+	}
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	results := Run([]Check{
+		{Name: "ok", Run: func() error { return nil }},
+		{Name: "error", Run: func() error { return errors.New("boom") }},
+		{Name: "panic", Run: func() error { panic("oops") }},
+	})
+
+	var summary bytes.Buffer
+	if WriteSummary(&summary, results) {
+		t.Fatalf("failing checks reported as passing:\n%s", summary.String())
+	}
+	if results[0].Err != nil || results[1].Err == nil || results[2].Err == nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	for _, want := range []string{"boom", "panic: oops"} {
+		if !strings.Contains(summary.String(), want) {
+			t.Fatalf("summary does not contain %q:\n%s", want, summary.String())
+		}
+	}
+}
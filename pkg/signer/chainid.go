@@ -0,0 +1,73 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// chainIDTimeout bounds the request fetching the chain ID from a ChainIDReader.
+const chainIDTimeout = 10 * time.Second
+
+// ChainIDReader provides the chain ID of a network, e.g. an ethclient.Client.
+type ChainIDReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// chainIDCache holds the configured chain ID of a signer. It is either set up front or fetched
+// once from client; a failed fetch is not cached, so the next signature tries again.
+type chainIDCache struct {
+	mu     sync.Mutex
+	id     *big.Int
+	client ChainIDReader
+}
+
+// get returns the chain ID, fetching it from the client if it is not known yet.
+func (c *chainIDCache) get() (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.id != nil {
+		return c.id, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chainIDTimeout)
+	defer cancel()
+
+	id, err := c.client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch chain id: %w", err)
+	}
+	c.id = id
+
+	return id, nil
+}
+
+// cached returns the chain ID if it is already known, without fetching it.
+func (c *chainIDCache) cached() *big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.id
+}
+
+// resolveChainID returns the chain ID to sign for. A nil chainID defaults to the configured one,
+// if any. An explicit chainID is always honored, but one that differs from the known configured
+// chain ID is logged, as it usually means the transaction is meant for another network.
+func (c *signer) resolveChainID(chainID *big.Int) (*big.Int, error) {
+	if c.chain == nil {
+		return chainID, nil
+	}
+	if chainID == nil {
+		return c.chain.get()
+	}
+
+	if configured := c.chain.cached(); configured != nil && configured.Cmp(chainID) != 0 {
+		c.logger.WithField("chain_id", chainID).WithField("configured_chain_id", configured).
+			Warn("signing for a chain id other than the configured one")
+	}
+
+	return chainID, nil
+}
@@ -0,0 +1,114 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+)
+
+// countingClient returns a fixed chain ID, or err if set, and counts how often it was asked.
+type countingClient struct {
+	chainID *big.Int
+	err     error
+	calls   int
+}
+
+func (c *countingClient) ChainID(ctx context.Context) (*big.Int, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.chainID, nil
+}
+
+func TestSignTxConfiguredChainID(t *testing.T) {
+	const key = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	from := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	newTx := func() *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(5),
+			To:        &to,
+			Gas:       21000,
+			GasFeeCap: big.NewInt(2e9),
+			GasTipCap: big.NewInt(1e9),
+		})
+	}
+
+	client := &countingClient{err: errors.New("connection refused")}
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+
+	s, err := NewFromHex(key, WithChainIDFrom(client), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+
+	if _, err := s.SignTx(newTx(), nil); err == nil {
+		t.Fatal("expected an error while the chain id cannot be fetched")
+	}
+
+	client.chainID, client.err = big.NewInt(5), nil
+	for i := 0; i < 2; i++ {
+		signedTx, err := s.SignTx(newTx(), nil)
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %v", err)
+		}
+		sender, err := types.Sender(types.NewLondonSigner(big.NewInt(5)), signedTx)
+		if err != nil {
+			t.Fatalf("unable to recover sender: %v", err)
+		}
+		if sender != from {
+			t.Fatalf("transaction signed by %s, want %s", sender, from)
+		}
+	}
+	if client.calls != 2 {
+		t.Fatalf("chain id fetched %d times, want 2 (one failure, then cached)", client.calls)
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("unexpected log output: %s", logs.String())
+	}
+
+	// an explicit chain id is honored, but reported when it differs from the configured one
+	signedTx, err := s.SignTx(types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(1e9)}), big.NewInt(1))
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %v", err)
+	}
+	if signedTx.ChainId().Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("transaction signed for chain %s, want 1", signedTx.ChainId())
+	}
+	if !strings.Contains(logs.String(), "configured_chain_id=5") {
+		t.Fatalf("mismatching chain id was not logged: %q", logs.String())
+	}
+}
+
+func TestWithChainID(t *testing.T) {
+	chainID := big.NewInt(1)
+	s := NewWithKeyBackend(newRecordingBackend(t).keyPair, WithChainID(chainID))
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	signedTx, err := s.SignTx(types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(1e9)}), nil)
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %v", err)
+	}
+	if !signedTx.Protected() || signedTx.ChainId().Cmp(chainID) != 0 {
+		t.Fatalf("transaction not replay protected for chain %s", chainID)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		t.Fatalf("unable to recover sender: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(*s.GetPublicKey()); sender != want {
+		t.Fatalf("transaction signed by %s, want %s", sender, want)
+	}
+}
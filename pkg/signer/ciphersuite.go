@@ -0,0 +1,43 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CipherSuite selects the AEAD EncryptAndGetHash uses. Both take 32-byte keys and 12-byte nonces,
+// as returned by GetSharedKey and GenNonce. The suite is recorded in the first byte of every
+// ciphertext, from which DecryptMessage picks the AEAD to open it with, so its values are part of
+// the ciphertext format and must not be renumbered.
+type CipherSuite int
+
+const (
+	// CipherAESGCM is AES-256 in Galois/Counter Mode, the default. It is the fastest choice on
+	// CPUs with AES instructions.
+	CipherAESGCM CipherSuite = iota
+	// CipherChaCha20Poly1305 is ChaCha20-Poly1305 (RFC 8439), faster than AES-GCM in software,
+	// e.g. on mobile and embedded CPUs without AES instructions.
+	CipherChaCha20Poly1305
+)
+
+// ErrUnknownCipherSuite denotes a CipherSuite that is not supported.
+var ErrUnknownCipherSuite = errors.New("unknown cipher suite")
+
+func (s CipherSuite) String() string {
+	switch s {
+	case CipherAESGCM:
+		return "aes-256-gcm"
+	case CipherChaCha20Poly1305:
+		return "chacha20-poly1305"
+	default:
+		return fmt.Sprintf("CipherSuite(%d)", int(s))
+	}
+}
+
+// WithCipherSuite sets the AEAD the signer encrypts messages with. Signers decrypt messages of
+// every supported suite, so the parties of an exchange may each pick their own.
+func WithCipherSuite(suite CipherSuite) Option {
+	return optionFunc(func(s *signer) {
+		s.cipherSuite = suite
+	})
+}
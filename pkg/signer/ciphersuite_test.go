@@ -0,0 +1,100 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestCipherSuite(t *testing.T) {
+	alice, err := New(&chaincfg.MainNetParams, WithCipherSuite(CipherChaCha20Poly1305))
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	bob, err := New(&chaincfg.MainNetParams, WithCipherSuite(CipherChaCha20Poly1305))
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	key := sharedKey(t, alice, bob)
+	nonce := alice.GenNonce()
+	message := []byte("meet at the usual place")
+
+	hash, ciphertext, err := alice.EncryptAndGetHash(key, nonce, message)
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+	if hash != sha256.Sum256(ciphertext) {
+		t.Fatal("hash does not match the ciphertext")
+	}
+
+	decrypted, err := bob.DecryptMessage(sharedKey(t, bob, alice), ciphertext, nonce)
+	if err != nil {
+		t.Fatalf("unable to decrypt: %v", err)
+	}
+	if decrypted != string(message) {
+		t.Fatalf("decrypted %q, want %q", decrypted, message)
+	}
+
+	if ciphertext[0] != byte(CipherChaCha20Poly1305) {
+		t.Fatalf("ciphertext records suite %d, want %d", ciphertext[0], CipherChaCha20Poly1305)
+	}
+
+	// a receiver configured with the default suite opens the message with the suite it records
+	receiver := NewWithKeyBackend(nil)
+	if decrypted, err := receiver.DecryptMessage(key, ciphertext, nonce); err != nil || decrypted != string(message) {
+		t.Fatalf("default receiver decrypted %q, %v", decrypted, err)
+	}
+
+	// the same key and nonce seal to a different ciphertext with AES-GCM, which chacha20-poly1305 signers open too
+	_, aesCiphertext, err := receiver.EncryptAndGetHash(key, nonce, message)
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+	if aesCiphertext[0] != byte(CipherAESGCM) || bytes.Equal(aesCiphertext[1:], ciphertext[1:]) {
+		t.Fatal("both cipher suites produced the same ciphertext")
+	}
+	if decrypted, err := bob.DecryptMessage(key, aesCiphertext, nonce); err != nil || decrypted != string(message) {
+		t.Fatalf("chacha20-poly1305 receiver decrypted %q, %v", decrypted, err)
+	}
+
+	// the suite byte is authenticated: switching it does not open the message with the other suite
+	switched := bytes.Clone(ciphertext)
+	switched[0] = byte(CipherAESGCM)
+	if _, err := receiver.DecryptMessage(key, switched, nonce); err == nil {
+		t.Fatal("chacha20-poly1305 ciphertext opened with aes-256-gcm")
+	}
+
+	// ciphertexts of signers predating the suite byte were bare AES-GCM ciphertexts, which still open
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		t.Fatalf("unable to create cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("unable to create gcm: %v", err)
+	}
+	legacy := gcm.Seal(nil, nonce, message, nil)
+	for _, s := range []Signer{receiver, bob} {
+		if decrypted, err := s.DecryptMessage(key, legacy, nonce); err != nil || decrypted != string(message) {
+			t.Fatalf("legacy ciphertext decrypted to %q, %v", decrypted, err)
+		}
+	}
+
+	unknownSuite := bytes.Clone(ciphertext)
+	unknownSuite[0] = 42
+	for _, c := range [][]byte{unknownSuite, nil} {
+		if _, err := receiver.DecryptMessage(key, c, nonce); !errors.Is(err, ErrUnknownCipherSuite) {
+			t.Fatalf("expected %v, got %v", ErrUnknownCipherSuite, err)
+		}
+	}
+
+	unknown := NewWithKeyBackend(nil, WithCipherSuite(CipherSuite(42)))
+	if _, _, err := unknown.EncryptAndGetHash(key, nonce, message); !errors.Is(err, ErrUnknownCipherSuite) {
+		t.Fatalf("expected %v, got %v", ErrUnknownCipherSuite, err)
+	}
+}
@@ -0,0 +1,133 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CompactSignatureLength is the length of a [R || S] signature without recovery id.
+const CompactSignatureLength = crypto.RecoveryIDOffset
+
+var (
+	// ErrInvalidSignatureLength denotes a signature that is not as long as its encoding requires.
+	ErrInvalidSignatureLength = errors.New("invalid signature length")
+	// ErrInvalidSignature denotes a signature that is malformed or does not verify against the key.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// SignCompact signs the hash and returns the 64-byte [R || S] signature. The public key cannot be
+// recovered from a compact signature, so verifiers need it out of band, see VerifyCompact.
+func (c *signer) SignCompact(hash [32]byte) ([]byte, error) {
+	signature, err := c.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: key backend returned %d bytes", ErrInvalidSignatureLength, len(signature))
+	}
+
+	return signature[:CompactSignatureLength], nil
+}
+
+// VerifyCompact verifies a 64-byte [R || S] signature of the hash against the public key.
+func VerifyCompact(publicKey *ecdsa.PublicKey, hash [32]byte, signature []byte) error {
+	if len(signature) != CompactSignatureLength {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidSignatureLength, len(signature), CompactSignatureLength)
+	}
+	if !crypto.VerifySignature(crypto.FromECDSAPub(publicKey), hash[:], signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// ToEIP2098 converts a 65-byte [R || S || V] signature into the 64-byte EIP-2098 form
+// [R || yParity << 255 | S] accepted by Solidity ECDSA libraries. V may be in {0, 1} or {27, 28}.
+func ToEIP2098(sig []byte) ([]byte, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidSignatureLength, len(sig), crypto.SignatureLength)
+	}
+
+	v := sig[crypto.RecoveryIDOffset]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("%w: recovery id %d", ErrInvalidSignature, sig[crypto.RecoveryIDOffset])
+	}
+	if sig[32]&0x80 != 0 {
+		return nil, fmt.Errorf("%w: s is not in the lower half of the curve order", ErrInvalidSignature)
+	}
+
+	compact := make([]byte, CompactSignatureLength)
+	copy(compact, sig[:CompactSignatureLength])
+	compact[32] |= v << 7
+
+	return compact, nil
+}
+
+// FromEIP2098 converts a 64-byte EIP-2098 signature back to the 65-byte [R || S || V] form,
+// with V in {0, 1} as returned by Sign.
+func FromEIP2098(compact []byte) ([]byte, error) {
+	if len(compact) != CompactSignatureLength {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidSignatureLength, len(compact), CompactSignatureLength)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, compact)
+	sig[crypto.RecoveryIDOffset] = compact[32] >> 7
+	sig[32] &= 0x7f
+
+	return sig, nil
+}
+
+// SplitOption configures SignSplit.
+type SplitOption interface {
+	apply(*splitConfig)
+}
+
+type splitConfig struct {
+	rawRecoveryID bool
+}
+
+type splitOptionFunc func(*splitConfig)
+
+func (f splitOptionFunc) apply(c *splitConfig) { f(c) }
+
+// WithRawRecoveryID makes SignSplit return V as the raw y parity in {0, 1}, as used by typed
+// transactions, instead of {27, 28}.
+func WithRawRecoveryID() SplitOption {
+	return splitOptionFunc(func(c *splitConfig) {
+		c.rawRecoveryID = true
+	})
+}
+
+// SignSplit signs the hash and returns the R, S and V values of the signature separately, as taken
+// by ecrecover. V follows the 27/28 convention unless WithRawRecoveryID is given.
+func (c *signer) SignSplit(hash [32]byte, opts ...SplitOption) (r, s *big.Int, v uint8, err error) {
+	var cfg splitConfig
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	signature, err := c.Sign(hash)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, nil, 0, fmt.Errorf("%w: key backend returned %d bytes", ErrInvalidSignatureLength, len(signature))
+	}
+
+	r = new(big.Int).SetBytes(signature[:32])
+	s = new(big.Int).SetBytes(signature[32:crypto.RecoveryIDOffset])
+	v = signature[crypto.RecoveryIDOffset]
+	if !cfg.rawRecoveryID {
+		v += 27
+	}
+
+	return r, s, v, nil
+}
@@ -0,0 +1,126 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSignCompact(t *testing.T) {
+	s := newTestSigner(t)
+	hash := crypto.Keccak256Hash([]byte("compact"))
+
+	signature, err := s.SignCompact(hash)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	if len(signature) != CompactSignatureLength {
+		t.Fatalf("signature is %d bytes, want %d", len(signature), CompactSignatureLength)
+	}
+
+	if err := VerifyCompact(s.GetPublicKey(), hash, signature); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+
+	other := crypto.Keccak256Hash([]byte("other"))
+	if err := VerifyCompact(s.GetPublicKey(), other, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ErrInvalidSignature, err)
+	}
+
+	full, err := s.Sign(hash)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	if err := VerifyCompact(s.GetPublicKey(), hash, full); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Fatalf("expected %v, got %v", ErrInvalidSignatureLength, err)
+	}
+}
+
+func TestEIP2098(t *testing.T) {
+	s := newTestSigner(t)
+	want := crypto.PubkeyToAddress(*s.GetPublicKey())
+
+	// sign a few messages so both values of the recovery id are likely exercised
+	for i := 0; i < 8; i++ {
+		hash := crypto.Keccak256Hash([]byte{byte(i)})
+
+		signature, err := s.Sign(hash)
+		if err != nil {
+			t.Fatalf("unable to sign: %v", err)
+		}
+
+		compact, err := ToEIP2098(signature)
+		if err != nil {
+			t.Fatalf("unable to convert to EIP-2098: %v", err)
+		}
+		if len(compact) != CompactSignatureLength {
+			t.Fatalf("EIP-2098 signature is %d bytes, want %d", len(compact), CompactSignatureLength)
+		}
+
+		expanded, err := FromEIP2098(compact)
+		if err != nil {
+			t.Fatalf("unable to convert from EIP-2098: %v", err)
+		}
+		if !bytes.Equal(expanded, signature) {
+			t.Fatalf("round trip yields %x, want %x", expanded, signature)
+		}
+
+		publicKey, err := crypto.SigToPub(hash[:], expanded)
+		if err != nil {
+			t.Fatalf("unable to recover public key: %v", err)
+		}
+		if got := crypto.PubkeyToAddress(*publicKey); got != want {
+			t.Fatalf("recovered %s, want %s", got, want)
+		}
+	}
+
+	if _, err := ToEIP2098(make([]byte, CompactSignatureLength)); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Fatalf("expected %v, got %v", ErrInvalidSignatureLength, err)
+	}
+}
+
+func TestSignSplit(t *testing.T) {
+	s := newTestSigner(t)
+	want := crypto.FromECDSAPub(s.GetPublicKey())
+
+	parities := make(map[uint8]bool)
+	for i := 0; i < 16; i++ {
+		hash := crypto.Keccak256Hash([]byte{byte(i)})
+
+		r, sv, v, err := s.SignSplit(hash)
+		if err != nil {
+			t.Fatalf("unable to sign: %v", err)
+		}
+		if v != 27 && v != 28 {
+			t.Fatalf("v is %d, want 27 or 28", v)
+		}
+		parities[v] = true
+
+		// reassemble the 65-byte [R || S || V] form ecrecover takes, with V in {0, 1}
+		signature := make([]byte, crypto.SignatureLength)
+		r.FillBytes(signature[:32])
+		sv.FillBytes(signature[32:crypto.RecoveryIDOffset])
+		signature[crypto.RecoveryIDOffset] = v - 27
+
+		recovered, err := crypto.Ecrecover(hash[:], signature)
+		if err != nil {
+			t.Fatalf("unable to recover public key: %v", err)
+		}
+		if !bytes.Equal(recovered, want) {
+			t.Fatal("recovered public key does not match the signer")
+		}
+
+		rawR, rawS, rawV, err := s.SignSplit(hash, WithRawRecoveryID())
+		if err != nil {
+			t.Fatalf("unable to sign: %v", err)
+		}
+		if rawV != v-27 || rawR.Cmp(r) != 0 || rawS.Cmp(sv) != 0 {
+			t.Fatalf("raw signature (%d) differs from the 27/28 one (%d)", rawV, v)
+		}
+	}
+	if len(parities) != 2 {
+		t.Fatalf("16 signatures all have v = %v", parities)
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/ecdsa"
+	"crypto/hkdf"
 	"crypto/rand"
 	"crypto/sha256"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/chacha20poly1305"
 )
 
 type ECDSAKeyPair struct {
@@ -18,14 +21,26 @@ type ECDSAKeyPair struct {
 }
 
 func (c *signer) GetPublicKey() *ecdsa.PublicKey {
-	return c.Wallet.EcdsaKeyPair.publicKey
+	return c.backend.PublicKey()
 }
 
-// SignTx signs an ethereum transaction.
+// SignTx signs an ethereum transaction. A nil chainID defaults to the chain ID the signer was
+// configured with, see WithChainID and WithChainIDFrom. A nil or zero chainID that is left signs a
+// legacy transaction without replay protection (homestead), any other chainID signs with the
+// latest signer for that chain.
 func (c *signer) SignTx(transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
-	txSigner := types.NewLondonSigner(chainID)
+	chainID, err := c.resolveChainID(chainID)
+	if err != nil {
+		return nil, err
+	}
+	txSigner := latestSigner(chainID)
+
+	signature, err := c.backend.Sign(txSigner.Hash(transaction))
+	if err != nil {
+		return nil, err
+	}
 
-	signedTx, err := types.SignTx(transaction, txSigner, c.Wallet.EcdsaKeyPair.privateKey)
+	signedTx, err := transaction.WithSignature(txSigner, signature)
 	if err != nil {
 		return nil, err
 	}
@@ -33,10 +48,105 @@ func (c *signer) SignTx(transaction *types.Transaction, chainID *big.Int) (*type
 	return signedTx, nil
 }
 
-// GetSharedKey returns the shared key using the private and public key.
-func (c *signer) GetSharedKey(their ecdsa.PublicKey) [32]byte {
-	sharedKey, _ := their.Curve.ScalarMult(their.X, their.Y, c.Wallet.EcdsaKeyPair.privateKey.D.Bytes())
-	return sha256.Sum256(sharedKey.Bytes())
+// latestSigner returns the transaction signer for chainID. Unlike types.LatestSignerForChainID,
+// a zero chainID is treated as no chainID, as an EIP-155 signer would otherwise sign for chain 0.
+func latestSigner(chainID *big.Int) types.Signer {
+	if chainID == nil || chainID.Sign() == 0 {
+		return types.HomesteadSigner{}
+	}
+	return types.LatestSignerForChainID(chainID)
+}
+
+// SignRawTx decodes a binary encoded transaction (legacy RLP or typed envelope), signs it and
+// returns the signed transaction in the same encoding.
+func (c *signer) SignRawTx(rlpBytes []byte, chainID *big.Int) ([]byte, error) {
+	transaction := new(types.Transaction)
+	if err := transaction.UnmarshalBinary(rlpBytes); err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+
+	signedTx, err := c.SignTx(transaction, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+
+	signedBytes, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode signed transaction: %w", err)
+	}
+
+	return signedBytes, nil
+}
+
+// GetSharedKey returns the shared key using the private and public key. It needs the in-memory key
+// of the signer, so signers whose key is held by another KeyBackend return ErrUnsupported.
+func (c *signer) GetSharedKey(their ecdsa.PublicKey) ([32]byte, error) {
+	x, err := c.ecdh(their)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return sha256.Sum256(x.Bytes()), nil
+}
+
+// ecdhSalt is the HKDF salt DeriveKeys extracts the ECDH shared secret with.
+const ecdhSalt = "keyless/ecdh/v1"
+
+// DeriveKeys derives independent encryption and MAC keys from the ECDH shared secret with their
+// public key. The shared secret is extracted with HKDF-SHA256 and a fixed salt, then expanded once
+// per purpose with info, e.g. a protocol or channel name, so keys of different purposes or
+// different info never coincide, unlike the single hash of GetSharedKey. Like GetSharedKey, it
+// returns ErrUnsupported for signers without an in-memory key.
+func (c *signer) DeriveKeys(their ecdsa.PublicKey, info []byte) (encKey, macKey [32]byte, err error) {
+	x, err := c.ecdh(their)
+	if err != nil {
+		return [32]byte{}, [32]byte{}, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, x.FillBytes(make([]byte, 32)), []byte(ecdhSalt))
+	if err != nil {
+		return [32]byte{}, [32]byte{}, fmt.Errorf("unable to extract shared secret: %w", err)
+	}
+
+	encKey, err = expandKey(prk, "enc", info)
+	if err != nil {
+		return [32]byte{}, [32]byte{}, err
+	}
+	macKey, err = expandKey(prk, "mac", info)
+	if err != nil {
+		return [32]byte{}, [32]byte{}, err
+	}
+	return encKey, macKey, nil
+}
+
+// ecdh returns the x coordinate of the product of their public key with the private key of the
+// signer. Their key must be a secp256k1 point: any other curve or point not on the curve, which
+// could leak bits of the private key through the product, is rejected with ErrInvalidPublicKey.
+func (c *signer) ecdh(their ecdsa.PublicKey) (*big.Int, error) {
+	if their.Curve != crypto.S256() {
+		return nil, fmt.Errorf("%w: not a secp256k1 key", ErrInvalidPublicKey)
+	}
+	if their.X == nil || their.Y == nil || !their.Curve.IsOnCurve(their.X, their.Y) {
+		return nil, fmt.Errorf("%w: point is not on secp256k1", ErrInvalidPublicKey)
+	}
+
+	privateKey, err := c.privateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	x, _ := their.Curve.ScalarMult(their.X, their.Y, privateKey.D.Bytes())
+	return x, nil
+}
+
+// expandKey expands prk into a 32-byte key for purpose and info. The purpose labels have the same
+// length, so the purpose and info cannot be shifted into one another.
+func expandKey(prk []byte, purpose string, info []byte) ([32]byte, error) {
+	key, err := hkdf.Expand(sha256.New, prk, purpose+"\x00"+string(info), 32)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("unable to expand %s key: %w", purpose, err)
+	}
+	return [32]byte(key), nil
 }
 
 // GenNonce for message hash for encryption.
@@ -49,50 +159,99 @@ func (c *signer) GenNonce() []byte {
 	return nonce
 }
 
-// EncryptAndGetHash using the shared key, nonce and message.
+// EncryptAndGetHash using the shared key, nonce and message, with the cipher suite of the signer.
+// The ciphertext starts with one byte recording the suite, which is authenticated along with the
+// message, so DecryptMessage opens it whatever the suite of the receiving signer.
+//
+// This changes the wire format: signers used to return the bare AES-GCM ciphertext, which
+// DecryptMessage still opens, but older signers cannot open the prefixed ciphertext, and the hash
+// of the same message differs from the one they returned.
 func (c *signer) EncryptAndGetHash(key [32]byte, nonce []byte, message []byte) ([32]byte, []byte, error) {
-	aesgcm, err := c.getCipherMode(key[:]) // generate cipher block with an aes key
+	aead, err := c.getCipherMode(c.cipherSuite, key[:])
 	if err != nil {
 		return [32]byte{}, nil, fmt.Errorf("error getting cipher mode: %w", err)
 	}
 
-	ciphertext := aesgcm.Seal(nil, nonce, message, nil) // encrypt the message using nonce
+	suite := []byte{byte(c.cipherSuite)}
+	ciphertext := aead.Seal(suite, nonce, message, suite) // encrypt the message using nonce
 
 	return sha256.Sum256(ciphertext), ciphertext, nil
 }
 
-// DecryptMessage using sharedKey, ciphered text and the nonce used to encrypt it.
+// DecryptMessage using sharedKey, ciphered text and the nonce used to encrypt it. The message is
+// opened with the cipher suite recorded in its first byte, see EncryptAndGetHash. Ciphertexts that
+// do not open that way are tried as the bare AES-GCM ciphertexts signers produced before the suite
+// was recorded; if that fails too, ErrUnknownCipherSuite is returned for a suite the signer does
+// not support, or the error of the recorded suite otherwise.
 func (c *signer) DecryptMessage(sharedKey [32]byte, cipherText []byte, nonce []byte) (string, error) {
-	aesgcm, err := c.getCipherMode(sharedKey[:])
-	if err != nil {
-		return "", fmt.Errorf("error getting cipher mode: %w", err)
+	if len(cipherText) == 0 {
+		return "", fmt.Errorf("%w: empty ciphertext", ErrUnknownCipherSuite)
 	}
+	suite := CipherSuite(cipherText[0])
 
-	deciphered, err := aesgcm.Open(nil, nonce, cipherText, nil) // decrypts the message
-	if err != nil {
-		return "", fmt.Errorf("error deciphering the message: %w", err)
+	deciphered, err := c.open(suite, sharedKey, nonce, cipherText[1:], cipherText[:1])
+	if err == nil {
+		return string(deciphered), nil
+	}
+
+	// the first byte of a legacy ciphertext is a random byte of the encrypted message
+	if legacy, legacyErr := c.open(CipherAESGCM, sharedKey, nonce, cipherText, nil); legacyErr == nil {
+		return string(legacy), nil
 	}
 
-	return string(deciphered), nil
+	return "", err
 }
 
-// getCipherMode to either seal or open ciphered data using AEAD cipher mode
-func (c *signer) getCipherMode(key []byte) (cipher.AEAD, error) {
-	block, err := aes.NewCipher(key) // generate cipher block with an aes key
+// open decrypts cipherText with the AEAD of suite.
+func (c *signer) open(suite CipherSuite, key [32]byte, nonce, cipherText, additionalData []byte) ([]byte, error) {
+	aead, err := c.getCipherMode(suite, key[:])
 	if err != nil {
-		return nil, fmt.Errorf("error generating cipher block: %w", err)
+		return nil, fmt.Errorf("error getting cipher mode: %w", err)
 	}
 
-	aesgcm, err := cipher.NewGCM(block)
+	deciphered, err := aead.Open(nil, nonce, cipherText, additionalData) // decrypts the message
 	if err != nil {
-		return nil, fmt.Errorf("error returning new GCM: %w", err)
+		return nil, fmt.Errorf("error deciphering the message with %s: %w", suite, err)
 	}
 
-	return aesgcm, nil
+	return deciphered, nil
 }
 
-// VerifySignature using ecdsa.
+// getCipherMode to either seal or open ciphered data using the AEAD of the cipher suite
+func (c *signer) getCipherMode(suite CipherSuite, key []byte) (cipher.AEAD, error) {
+	switch suite {
+	case CipherAESGCM:
+		block, err := aes.NewCipher(key) // generate cipher block with an aes key
+		if err != nil {
+			return nil, fmt.Errorf("error generating cipher block: %w", err)
+		}
+
+		aesgcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("error returning new GCM: %w", err)
+		}
+
+		return aesgcm, nil
+	case CipherChaCha20Poly1305:
+		aead, err := chacha20poly1305.New(key)
+		if err != nil {
+			return nil, fmt.Errorf("error returning new ChaCha20-Poly1305: %w", err)
+		}
+
+		return aead, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCipherSuite, suite)
+	}
+}
+
+// VerifySignature using ecdsa. The signature can either be the [R || S || V] returned by Sign or
+// the [R || S] signers returned before, which is verified as it was: split in two halves, and
+// without rejecting the high S values go-ethereum refuses.
 func (c *signer) VerifySignature(publicKey ecdsa.PublicKey, signature, messageHash []byte) bool {
+	if len(signature) == crypto.SignatureLength {
+		signature = signature[:crypto.RecoveryIDOffset] // the recovery id is not needed to verify
+	}
+
 	// parse the signature into r and s components
 	r := new(big.Int).SetBytes(signature[:len(signature)/2])
 	s := new(big.Int).SetBytes(signature[len(signature)/2:])
@@ -101,14 +260,14 @@ func (c *signer) VerifySignature(publicKey ecdsa.PublicKey, signature, messageHa
 	return ecdsa.Verify(&publicKey, messageHash, r, s)
 }
 
-// Sign the hash with privateKey of encrypter.
+// Sign the hash with the key backend of the signer, returning a 65-byte [R || S || V] signature.
+// Signers used to return the 64-byte [R || S] alone: callers storing or sending that format take
+// the first 64 bytes, which VerifySignature keeps accepting.
 func (c *signer) Sign(hash [32]byte) ([]byte, error) {
-	r, s, err := ecdsa.Sign(rand.Reader, c.Wallet.EcdsaKeyPair.privateKey, hash[:])
+	signature, err := c.backend.Sign(hash)
 	if err != nil {
-		return nil, fmt.Errorf("error signing using private key: %w", err)
+		return nil, fmt.Errorf("error signing hash: %w", err)
 	}
 
-	// combine r and s to create the signature
-	signature := append(r.Bytes(), s.Bytes()...)
 	return signature, nil
 }
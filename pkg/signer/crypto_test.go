@@ -0,0 +1,85 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// deriveKeys returns the DeriveKeys of s with the public key of their.
+func deriveKeys(t *testing.T, s, their Signer, info string) (encKey, macKey [32]byte) {
+	t.Helper()
+
+	encKey, macKey, err := s.DeriveKeys(*their.GetPublicKey(), []byte(info))
+	if err != nil {
+		t.Fatalf("unable to derive keys: %v", err)
+	}
+	return encKey, macKey
+}
+
+func TestDeriveKeys(t *testing.T) {
+	alice, bob := newTestSigner(t), newTestSigner(t)
+
+	aliceEnc, aliceMac := deriveKeys(t, alice, bob, "keyless/chat")
+	bobEnc, bobMac := deriveKeys(t, bob, alice, "keyless/chat")
+	if aliceEnc != bobEnc || aliceMac != bobMac {
+		t.Fatal("both sides of the exchange derived different keys")
+	}
+
+	otherEnc, otherMac := deriveKeys(t, alice, bob, "keyless/files")
+	keys := map[[32]byte]string{
+		aliceEnc:                 "chat encryption",
+		aliceMac:                 "chat mac",
+		otherEnc:                 "files encryption",
+		otherMac:                 "files mac",
+		sharedKey(t, alice, bob): "shared key",
+	}
+	if len(keys) != 5 {
+		t.Fatalf("derived keys are not independent: %v", keys)
+	}
+
+	nonce := alice.GenNonce()
+	_, ciphertext, err := alice.EncryptAndGetHash(aliceEnc, nonce, []byte("hello"))
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+	if message, err := bob.DecryptMessage(bobEnc, ciphertext, nonce); err != nil || message != "hello" {
+		t.Fatalf("unable to decrypt with the derived key: %q, %v", message, err)
+	}
+	if _, err := bob.DecryptMessage(otherEnc, ciphertext, nonce); err == nil {
+		t.Fatal("message decrypted with the key of another label")
+	}
+}
+
+func TestDeriveKeysInvalidPublicKey(t *testing.T) {
+	s := newTestSigner(t)
+	valid := *newTestSigner(t).GetPublicKey()
+
+	offCurve := valid
+	offCurve.Y = new(big.Int).Add(valid.Y, big.NewInt(1))
+
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	for name, their := range map[string]ecdsa.PublicKey{
+		"off curve":   offCurve,
+		"other curve": p256.PublicKey,
+		"no point":    {Curve: valid.Curve},
+	} {
+		if _, _, err := s.DeriveKeys(their, []byte("keyless/chat")); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Fatalf("%s: expected %v, got %v", name, ErrInvalidPublicKey, err)
+		}
+		if _, err := s.GetSharedKey(their); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Fatalf("%s: expected %v, got %v", name, ErrInvalidPublicKey, err)
+		}
+	}
+
+	if _, _, err := NewWithKeyBackend(newRecordingBackend(t)).DeriveKeys(valid, nil); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected %v, got %v", ErrUnsupported, err)
+	}
+}
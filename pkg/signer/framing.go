@@ -0,0 +1,103 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// frameLength is the length of the big endian ciphertext length prefixed to every framed part.
+const frameLength = 4
+
+// ErrInvalidFrame denotes a framed payload that does not split into whole parts.
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// EncryptFramed encrypts every part with s under key, see EncryptAndGetHash, and concatenates the
+// ciphertexts, each prefixed with its big endian length, so SplitFrames can split them again. The
+// returned hash covers the whole framed payload.
+//
+// Part i is encrypted with nonce XORed with i in its last 4 bytes, see PartNonce, so one nonce
+// serves all the parts without being reused, and parts that are reordered do not decrypt. Parts
+// dropped from the end of the payload are not detected, which callers sending the number of parts
+// along with the nonce can check themselves.
+func EncryptFramed(s Signer, key [32]byte, nonce []byte, parts ...[]byte) ([32]byte, []byte, error) {
+	var framed []byte
+	for i, part := range parts {
+		partNonce, err := PartNonce(nonce, i)
+		if err != nil {
+			return [32]byte{}, nil, err
+		}
+
+		_, ciphertext, err := s.EncryptAndGetHash(key, partNonce, part)
+		if err != nil {
+			return [32]byte{}, nil, fmt.Errorf("unable to encrypt part %d: %w", i, err)
+		}
+
+		framed = binary.BigEndian.AppendUint32(framed, uint32(len(ciphertext)))
+		framed = append(framed, ciphertext...)
+	}
+
+	return sha256.Sum256(framed), framed, nil
+}
+
+// SplitFrames splits a payload of EncryptFramed into the ciphertexts of its parts, in order.
+func SplitFrames(payload []byte) ([][]byte, error) {
+	var ciphertexts [][]byte
+	for len(payload) > 0 {
+		if len(payload) < frameLength {
+			return nil, fmt.Errorf("%w: %d trailing bytes after part %d", ErrInvalidFrame, len(payload), len(ciphertexts))
+		}
+
+		n := binary.BigEndian.Uint32(payload)
+		payload = payload[frameLength:]
+		if uint64(n) > uint64(len(payload)) {
+			return nil, fmt.Errorf("%w: part %d of %d bytes, %d left", ErrInvalidFrame, len(ciphertexts), n, len(payload))
+		}
+
+		ciphertexts = append(ciphertexts, payload[:n])
+		payload = payload[n:]
+	}
+
+	return ciphertexts, nil
+}
+
+// DecryptFramed splits a payload of EncryptFramed and decrypts its parts with s under key and the
+// nonce given to EncryptFramed, in order.
+func DecryptFramed(s Signer, key [32]byte, nonce []byte, payload []byte) ([]string, error) {
+	ciphertexts, err := SplitFrames(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]string, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		partNonce, err := PartNonce(nonce, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if messages[i], err = s.DecryptMessage(key, ciphertext, partNonce); err != nil {
+			return nil, fmt.Errorf("unable to decrypt part %d: %w", i, err)
+		}
+	}
+
+	return messages, nil
+}
+
+// PartNonce returns the nonce part i of a framed payload is encrypted with: nonce with i XORed into
+// its last 4 bytes, big endian, as TLS 1.3 derives per-record nonces.
+func PartNonce(nonce []byte, i int) ([]byte, error) {
+	if len(nonce) < frameLength {
+		return nil, fmt.Errorf("nonce of %d bytes is too short to derive part nonces", len(nonce))
+	}
+	if uint64(i) > uint64(^uint32(0)) {
+		return nil, fmt.Errorf("too many parts to derive a nonce for part %d", i)
+	}
+
+	partNonce := append([]byte(nil), nonce...)
+	tail := partNonce[len(partNonce)-frameLength:]
+	binary.BigEndian.PutUint32(tail, binary.BigEndian.Uint32(tail)^uint32(i))
+
+	return partNonce, nil
+}
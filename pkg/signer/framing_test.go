@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestEncryptFramed(t *testing.T) {
+	alice, bob := newTestSigner(t), newTestSigner(t)
+	key := sharedKey(t, alice, bob)
+	nonce := alice.GenNonce()
+	parts := []string{"first part", "", "the third and longest part"}
+
+	hash, payload, err := EncryptFramed(alice, key, nonce, []byte(parts[0]), []byte(parts[1]), []byte(parts[2]))
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+	if hash != sha256.Sum256(payload) {
+		t.Fatal("hash does not cover the framed payload")
+	}
+
+	messages, err := DecryptFramed(bob, sharedKey(t, bob, alice), nonce, payload)
+	if err != nil {
+		t.Fatalf("unable to decrypt: %v", err)
+	}
+	if len(messages) != len(parts) {
+		t.Fatalf("got %d parts, want %d", len(messages), len(parts))
+	}
+	for i := range parts {
+		if messages[i] != parts[i] {
+			t.Fatalf("part %d: got %q, want %q", i, messages[i], parts[i])
+		}
+	}
+
+	ciphertexts, err := SplitFrames(payload)
+	if err != nil {
+		t.Fatalf("unable to split: %v", err)
+	}
+
+	// every part opens on its own with its part nonce, and only with it
+	for i, ciphertext := range ciphertexts {
+		partNonce, err := PartNonce(nonce, i)
+		if err != nil {
+			t.Fatalf("unable to derive nonce of part %d: %v", i, err)
+		}
+		if message, err := bob.DecryptMessage(key, ciphertext, partNonce); err != nil || message != parts[i] {
+			t.Fatalf("unable to decrypt part %d: %q, %v", i, message, err)
+		}
+		if i > 0 && bytes.Equal(partNonce, nonce) {
+			t.Fatalf("part %d reuses the nonce", i)
+		}
+	}
+
+	// swapping the first and last parts keeps a valid framing but does not decrypt
+	var swapped []byte
+	for _, i := range []int{2, 1, 0} {
+		swapped = binary.BigEndian.AppendUint32(swapped, uint32(len(ciphertexts[i])))
+		swapped = append(swapped, ciphertexts[i]...)
+	}
+	if _, err := SplitFrames(swapped); err != nil {
+		t.Fatalf("unable to split reordered parts: %v", err)
+	}
+	if _, err := DecryptFramed(bob, key, nonce, swapped); err == nil {
+		t.Fatal("reordered parts decrypted")
+	}
+
+	if _, err := DecryptFramed(bob, key, nonce, payload[:len(payload)-1]); !errors.Is(err, ErrInvalidFrame) {
+		t.Fatalf("expected %v, got %v", ErrInvalidFrame, err)
+	}
+	if _, err := SplitFrames(append(payload, 0, 0)); !errors.Is(err, ErrInvalidFrame) {
+		t.Fatalf("expected %v, got %v", ErrInvalidFrame, err)
+	}
+}
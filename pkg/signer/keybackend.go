@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	_ KeyBackend = (*ECDSAKeyPair)(nil)
+)
+
+// ErrUnsupported denotes an operation on key material that the key backend of the signer does not
+// expose, e.g. ECDH with a key held by an HSM.
+var ErrUnsupported = errors.New("operation not supported by the key backend")
+
+// KeyBackend holds the signing key of a signer. Implementations can keep the key in memory
+// or delegate signing to an HSM or cloud KMS without ever exposing the raw key material.
+type KeyBackend interface {
+	// Sign returns a 65-byte [R || S || V] secp256k1 signature of the hash, with V in {0, 1}.
+	Sign(hash [32]byte) ([]byte, error)
+	// PublicKey returns the public key matching the signing key.
+	PublicKey() *ecdsa.PublicKey
+}
+
+// Sign signs the hash with the in-memory private key.
+func (k *ECDSAKeyPair) Sign(hash [32]byte) ([]byte, error) {
+	signature, err := crypto.Sign(hash[:], k.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing using private key: %w", err)
+	}
+	return signature, nil
+}
+
+// PublicKey returns the public key of the pair.
+func (k *ECDSAKeyPair) PublicKey() *ecdsa.PublicKey {
+	return k.publicKey
+}
+
+// privateKey returns the in-memory private key of the signer. Signers created with
+// NewWithKeyBackend have none unless the backend is an ECDSAKeyPair, and operations needing the raw
+// key return ErrUnsupported for them.
+func (c *signer) privateKey() (*ecdsa.PrivateKey, error) {
+	keyPair, ok := c.backend.(*ECDSAKeyPair)
+	if !ok || keyPair == nil || keyPair.privateKey == nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupported, ErrNoPrivateKey)
+	}
+	return keyPair.privateKey, nil
+}
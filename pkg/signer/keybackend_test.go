@@ -0,0 +1,155 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// recordingBackend signs with an in-memory key and records every hash it was asked to sign.
+type recordingBackend struct {
+	keyPair *ECDSAKeyPair
+	hashes  [][32]byte
+}
+
+func (b *recordingBackend) Sign(hash [32]byte) ([]byte, error) {
+	b.hashes = append(b.hashes, hash)
+	return b.keyPair.Sign(hash)
+}
+
+func (b *recordingBackend) PublicKey() *ecdsa.PublicKey {
+	return b.keyPair.PublicKey()
+}
+
+func newRecordingBackend(t *testing.T) *recordingBackend {
+	t.Helper()
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	return &recordingBackend{
+		keyPair: &ECDSAKeyPair{publicKey: &privateKey.PublicKey, privateKey: privateKey},
+	}
+}
+
+func TestKeyBackendSign(t *testing.T) {
+	backend := newRecordingBackend(t)
+	s := NewWithKeyBackend(backend)
+
+	hash := crypto.Keccak256Hash([]byte("keyless"))
+	signature, err := s.Sign(hash)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+
+	if len(backend.hashes) != 1 || backend.hashes[0] != hash {
+		t.Fatalf("backend signed %x, want [%x]", backend.hashes, hash)
+	}
+	if !s.VerifySignature(*s.GetPublicKey(), signature, hash[:]) {
+		t.Fatal("signature produced by the backend did not verify")
+	}
+
+	recovered, err := crypto.SigToPub(hash[:], signature)
+	if err != nil {
+		t.Fatalf("unable to recover public key: %v", err)
+	}
+	if crypto.PubkeyToAddress(*recovered) != crypto.PubkeyToAddress(*backend.PublicKey()) {
+		t.Fatal("recovered public key does not match the backend key")
+	}
+}
+
+func TestKeyBackendSignTx(t *testing.T) {
+	backend := newRecordingBackend(t)
+	s := NewWithKeyBackend(backend)
+
+	chainID := big.NewInt(5)
+	to := common.HexToAddress("0x000000000000000000000000000000000000beef")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Gas:       21000,
+		GasFeeCap: big.NewInt(2e9),
+		GasTipCap: big.NewInt(1e9),
+	})
+
+	signedTx, err := s.SignTx(tx, chainID)
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %v", err)
+	}
+
+	txSigner := types.NewLondonSigner(chainID)
+	if len(backend.hashes) != 1 || backend.hashes[0] != txSigner.Hash(tx) {
+		t.Fatalf("backend signed %x, want the transaction signing hash", backend.hashes)
+	}
+
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil {
+		t.Fatalf("unable to recover sender: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(*backend.PublicKey()); sender != want {
+		t.Fatalf("recovered sender %s, want %s", sender, want)
+	}
+}
+
+func TestKeyBackendUnsupported(t *testing.T) {
+	s := NewWithKeyBackend(newRecordingBackend(t))
+	peer := newTestSigner(t)
+
+	if _, err := s.GetSharedKey(*peer.GetPublicKey()); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected %v, got %v", ErrUnsupported, err)
+	}
+	if _, err := s.ExportKeystore("passphrase"); !errors.Is(err, ErrUnsupported) || !errors.Is(err, ErrNoPrivateKey) {
+		t.Fatalf("expected %v, got %v", ErrUnsupported, err)
+	}
+
+	// an in-memory key pair passed as backend keeps them
+	keyPair := newRecordingBackend(t).keyPair
+	if _, err := NewWithKeyBackend(keyPair).GetSharedKey(*peer.GetPublicKey()); err != nil {
+		t.Fatalf("unable to get shared key: %v", err)
+	}
+}
+
+// TestVerifySignatureLegacyFormat pins the 64-byte [R || S] signatures signers returned before
+// signing went through a KeyBackend: they still verify, and are the first 64 bytes of Sign.
+func TestVerifySignatureLegacyFormat(t *testing.T) {
+	s := NewWithKeyBackend(newRecordingBackend(t))
+	hash := crypto.Keccak256Hash([]byte("keyless"))
+
+	signature, err := s.Sign(hash)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	if len(signature) != 65 || signature[64] > 1 {
+		t.Fatalf("signature %x is not [R || S || V]", signature)
+	}
+	if !s.VerifySignature(*s.GetPublicKey(), signature[:64], hash[:]) {
+		t.Fatal("[R || S] prefix of the signature did not verify")
+	}
+
+	// signatures as produced by ecdsa.Sign before, high S values included
+	privateKey := newRecordingBackend(t).keyPair.privateKey
+	r, sig, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	highS := new(big.Int).Sub(crypto.S256().Params().N, sig)
+	for _, legacy := range [][]byte{
+		append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...),
+		append(r.FillBytes(make([]byte, 32)), highS.FillBytes(make([]byte, 32))...),
+	} {
+		if !s.VerifySignature(privateKey.PublicKey, legacy, hash[:]) {
+			t.Fatalf("legacy [R || S] signature %x did not verify", legacy)
+		}
+		if s.VerifySignature(*s.GetPublicKey(), legacy, hash[:]) {
+			t.Fatalf("legacy signature %x verified against another key", legacy)
+		}
+	}
+}
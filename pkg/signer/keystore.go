@@ -0,0 +1,56 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// ErrNoPrivateKey denotes a signer whose key is held by an external KeyBackend.
+var ErrNoPrivateKey = errors.New("signer holds no private key")
+
+// keystoreScryptN and keystoreScryptP are the scrypt parameters of exported keystores; kept as
+// variables so tests can use lighter ones.
+var (
+	keystoreScryptN = keystore.StandardScryptN
+	keystoreScryptP = keystore.StandardScryptP
+)
+
+// ExportKeystore encrypts the default key of the wallet with password into a Web3 Secret Storage
+// (v3) JSON document, as read by geth and MetaMask.
+func (c *signer) ExportKeystore(password string) ([]byte, error) {
+	privateKey, err := c.privateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate key id: %w", err)
+	}
+
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, password, keystoreScryptN, keystoreScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt keystore: %w", err)
+	}
+
+	return keyJSON, nil
+}
+
+// ImportKeystore decrypts a Web3 Secret Storage (v3) JSON document with password and returns a
+// signer for the key it holds. A wrong password returns an error wrapping keystore.ErrDecrypt.
+func ImportKeystore(keyJSON []byte, password string, opts ...Option) (Signer, error) {
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt keystore: %w", err)
+	}
+
+	return NewFromPrivateKey(key.PrivateKey, opts...)
+}
@@ -0,0 +1,66 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	defer func(n, p int) {
+		keystoreScryptN, keystoreScryptP = n, p
+	}(keystoreScryptN, keystoreScryptP)
+	keystoreScryptN, keystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+
+	for name, newSigner := range map[string]func() (Signer, error){
+		"hd wallet": func() (Signer, error) { return New(&chaincfg.MainNetParams) },
+		"imported": func() (Signer, error) {
+			return NewFromHex("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, err := newSigner()
+			if err != nil {
+				t.Fatalf("unable to create signer: %v", err)
+			}
+			address := crypto.PubkeyToAddress(*s.GetPublicKey())
+
+			keyJSON, err := s.ExportKeystore("correct horse")
+			if err != nil {
+				t.Fatalf("unable to export keystore: %v", err)
+			}
+
+			var document struct {
+				Version int    `json:"version"`
+				Address string `json:"address"`
+			}
+			if err := json.Unmarshal(keyJSON, &document); err != nil {
+				t.Fatalf("unable to decode keystore: %v", err)
+			}
+			if document.Version != 3 || !strings.EqualFold(document.Address, address.Hex()[2:]) {
+				t.Fatalf("keystore v%d for %s, want v3 for %s", document.Version, document.Address, address)
+			}
+
+			imported, err := ImportKeystore(keyJSON, "correct horse")
+			if err != nil {
+				t.Fatalf("unable to import keystore: %v", err)
+			}
+			if got := crypto.PubkeyToAddress(*imported.GetPublicKey()); got != address {
+				t.Fatalf("imported address %s, want %s", got, address)
+			}
+
+			if _, err := ImportKeystore(keyJSON, "wrong horse"); !errors.Is(err, keystore.ErrDecrypt) {
+				t.Fatalf("expected %v, got %v", keystore.ErrDecrypt, err)
+			}
+		})
+	}
+
+	if _, err := NewWithKeyBackend(newRecordingBackend(t)).ExportKeystore("password"); !errors.Is(err, ErrNoPrivateKey) {
+		t.Fatalf("expected %v, got %v", ErrNoPrivateKey, err)
+	}
+}
@@ -0,0 +1,49 @@
+package signer
+
+import (
+	"math/big"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Option configures a signer.
+type Option interface {
+	apply(*signer)
+}
+
+type optionFunc func(*signer)
+
+func (f optionFunc) apply(s *signer) { f(s) }
+
+// WithChainID sets the chain ID SignTx and SignRawTx sign for when called with a nil chainID.
+func WithChainID(chainID *big.Int) Option {
+	return optionFunc(func(s *signer) {
+		s.chain = &chainIDCache{id: new(big.Int).Set(chainID)}
+	})
+}
+
+// WithChainIDFrom is like WithChainID, but the chain ID is fetched from client the first time it
+// is needed and cached from then on.
+func WithChainIDFrom(client ChainIDReader) Option {
+	return optionFunc(func(s *signer) {
+		s.chain = &chainIDCache{client: client}
+	})
+}
+
+// WithLogger sets the logger used to report explicit chain IDs that differ from the configured one.
+func WithLogger(logger *logrus.Logger) Option {
+	return optionFunc(func(s *signer) {
+		s.logger = logger
+	})
+}
+
+// newSigner returns a signer with opts applied over the defaults.
+func newSigner(opts ...Option) *signer {
+	s := &signer{
+		logger: logrus.StandardLogger(),
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
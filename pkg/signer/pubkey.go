@@ -0,0 +1,41 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// uncompressedPrefix starts the SEC 1 uncompressed encoding of a public key.
+const uncompressedPrefix = 0x04
+
+// ErrInvalidPublicKey denotes public key bytes that do not encode a secp256k1 point.
+var ErrInvalidPublicKey = errors.New("invalid public key")
+
+// SplitPublicKey returns the affine coordinates of a secp256k1 public key given either in the
+// 65-byte uncompressed form 0x04 || X || Y, as crypto.FromECDSAPub returns it, or as the 64-byte
+// X || Y without prefix. The point must be on the curve.
+func SplitPublicKey(pub []byte) (x, y *big.Int, err error) {
+	const coordinateLength = 32
+
+	switch len(pub) {
+	case 2 * coordinateLength:
+	case 2*coordinateLength + 1:
+		if pub[0] != uncompressedPrefix {
+			return nil, nil, fmt.Errorf("%w: prefix 0x%02x, want 0x%02x", ErrInvalidPublicKey, pub[0], uncompressedPrefix)
+		}
+		pub = pub[1:]
+	default:
+		return nil, nil, fmt.Errorf("%w: got %d bytes, want %d or %d", ErrInvalidPublicKey, len(pub), 2*coordinateLength, 2*coordinateLength+1)
+	}
+
+	x = new(big.Int).SetBytes(pub[:coordinateLength])
+	y = new(big.Int).SetBytes(pub[coordinateLength:])
+	if !crypto.S256().IsOnCurve(x, y) {
+		return nil, nil, fmt.Errorf("%w: point is not on secp256k1", ErrInvalidPublicKey)
+	}
+
+	return x, y, nil
+}
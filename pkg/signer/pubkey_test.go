@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSplitPublicKey(t *testing.T) {
+	publicKey := newTestSigner(t).GetPublicKey()
+	uncompressed := crypto.FromECDSAPub(publicKey)
+
+	wrongPrefix := append([]byte{}, uncompressed...)
+	wrongPrefix[0] = 0x02
+
+	offCurve := append([]byte{}, uncompressed[1:]...)
+	offCurve[63] ^= 1
+
+	cases := []struct {
+		name string
+		pub  []byte
+		err  error
+	}{
+		{"uncompressed", uncompressed, nil},
+		{"coordinates", uncompressed[1:], nil},
+		{"wrong prefix", wrongPrefix, ErrInvalidPublicKey},
+		{"compressed", crypto.CompressPubkey(publicKey), ErrInvalidPublicKey},
+		{"too long", append(append([]byte{}, uncompressed...), 0), ErrInvalidPublicKey},
+		{"empty", nil, ErrInvalidPublicKey},
+		{"off curve", offCurve, ErrInvalidPublicKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			x, y, err := SplitPublicKey(tc.pub)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to split public key: %v", err)
+			}
+			if x.Cmp(publicKey.X) != 0 || y.Cmp(publicKey.Y) != 0 {
+				t.Fatalf("got (%s, %s), want (%s, %s)", x, y, publicKey.X, publicKey.Y)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package signer
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// epochLength is the length of the big endian key epoch prefixed to ratcheted ciphertexts.
+const epochLength = 4
+
+// maxEpochSkip bounds how far a ciphertext can move a KeyRatchet forward, so a forged epoch cannot
+// make the receiver run billions of key derivations.
+const maxEpochSkip = 1 << 10
+
+var (
+	// ErrStaleEpoch denotes a ciphertext of an epoch whose key has been rotated away.
+	ErrStaleEpoch = errors.New("key epoch already rotated away")
+	// ErrEpochTooFar denotes a ciphertext whose epoch is too far ahead of the current one.
+	ErrEpochTooFar = errors.New("key epoch too far ahead")
+)
+
+// RotateSharedKey derives the next key of a channel from oldKey with HKDF-SHA256, binding info,
+// e.g. the channel and protocol names. The old key cannot be recovered from the new one, so
+// deleting it keeps past messages secret should a later key leak.
+func RotateSharedKey(oldKey [32]byte, info []byte) [32]byte {
+	key, err := hkdf.Key(sha256.New, oldKey[:], nil, string(info), 32)
+	if err != nil {
+		panic(err) // only returned for lengths HKDF-SHA256 cannot produce
+	}
+	return [32]byte(key)
+}
+
+// KeyRatchet holds the key of the current epoch of a channel, the shared key being epoch 0, and
+// ratchets it forward with RotateSharedKey. Keys of past epochs are not kept. It is safe for
+// concurrent use.
+type KeyRatchet struct {
+	mu    sync.Mutex
+	info  []byte
+	epoch uint32
+	key   [32]byte
+}
+
+// NewKeyRatchet returns a ratchet at epoch 0 with sharedKey, rotating with info.
+func NewKeyRatchet(sharedKey [32]byte, info []byte) *KeyRatchet {
+	return &KeyRatchet{
+		info: append([]byte(nil), info...),
+		key:  sharedKey,
+	}
+}
+
+// Epoch returns the current epoch.
+func (r *KeyRatchet) Epoch() uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.epoch
+}
+
+// Rotate moves to the next epoch and returns it.
+func (r *KeyRatchet) Rotate() uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.key = RotateSharedKey(r.key, r.info)
+	r.epoch++
+
+	return r.epoch
+}
+
+// keyAt returns the key of epoch, derived from the current key if epoch is ahead of it. The
+// ratchet itself does not move, see advance.
+func (r *KeyRatchet) keyAt(epoch uint32) ([32]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case epoch < r.epoch:
+		return [32]byte{}, fmt.Errorf("%w: epoch %d, current epoch %d", ErrStaleEpoch, epoch, r.epoch)
+	case epoch-r.epoch > maxEpochSkip:
+		return [32]byte{}, fmt.Errorf("%w: epoch %d, current epoch %d", ErrEpochTooFar, epoch, r.epoch)
+	}
+
+	key := r.key
+	for e := r.epoch; e < epoch; e++ {
+		key = RotateSharedKey(key, r.info)
+	}
+
+	return key, nil
+}
+
+// advance moves the ratchet to epoch, whose key is key, unless it is already there or beyond.
+func (r *KeyRatchet) advance(epoch uint32, key [32]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if epoch > r.epoch {
+		r.epoch, r.key = epoch, key
+	}
+}
+
+// Encrypt encrypts message with s under the key of the current epoch, see EncryptAndGetHash. The
+// ciphertext is prefixed with the epoch, so Decrypt knows which key opens it.
+func (r *KeyRatchet) Encrypt(s Signer, nonce []byte, message []byte) ([32]byte, []byte, error) {
+	r.mu.Lock()
+	epoch, key := r.epoch, r.key
+	r.mu.Unlock()
+
+	_, ciphertext, err := s.EncryptAndGetHash(key, nonce, message)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	framed := binary.BigEndian.AppendUint32(make([]byte, 0, epochLength+len(ciphertext)), epoch)
+	framed = append(framed, ciphertext...)
+
+	return sha256.Sum256(framed), framed, nil
+}
+
+// Decrypt decrypts a ciphertext of Encrypt with s, ratcheting forward to its epoch if it is ahead
+// of the current one. Ciphertexts of past epochs cannot be decrypted anymore and return
+// ErrStaleEpoch.
+func (r *KeyRatchet) Decrypt(s Signer, cipherText []byte, nonce []byte) (string, error) {
+	if len(cipherText) < epochLength {
+		return "", fmt.Errorf("error deciphering the message: ciphertext of %d bytes has no epoch", len(cipherText))
+	}
+
+	epoch := binary.BigEndian.Uint32(cipherText)
+	key, err := r.keyAt(epoch)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := s.DecryptMessage(key, cipherText[epochLength:], nonce)
+	if err != nil {
+		return "", err
+	}
+	// only an authenticated message moves the ratchet, so a forged epoch cannot
+	r.advance(epoch, key)
+
+	return message, nil
+}
@@ -0,0 +1,75 @@
+package signer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRotateSharedKey(t *testing.T) {
+	s := NewWithKeyBackend(nil)
+	info := []byte("keyless/channel/42")
+	var epoch0 [32]byte
+	copy(epoch0[:], "a shared key of thirty two bytes")
+
+	epoch1 := RotateSharedKey(epoch0, info)
+	if epoch1 == epoch0 || epoch1 != RotateSharedKey(epoch0, info) {
+		t.Fatal("rotation is not a deterministic derivation of a fresh key")
+	}
+	if epoch1 == RotateSharedKey(epoch0, []byte("keyless/channel/43")) {
+		t.Fatal("rotation does not bind its context")
+	}
+
+	// a message of epoch 1 opens only with the key of epoch 1
+	nonce := s.GenNonce()
+	_, ciphertext, err := s.EncryptAndGetHash(epoch1, nonce, []byte("epoch one"))
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+	if _, err := s.DecryptMessage(epoch0, ciphertext, nonce); err == nil {
+		t.Fatal("epoch 1 message decrypted with the epoch 0 key")
+	}
+	if _, err := s.DecryptMessage(RotateSharedKey(epoch1, info), ciphertext, nonce); err == nil {
+		t.Fatal("epoch 1 message decrypted with the epoch 2 key")
+	}
+	if message, err := s.DecryptMessage(epoch1, ciphertext, nonce); err != nil || message != "epoch one" {
+		t.Fatalf("unable to decrypt with the epoch 1 key: %q, %v", message, err)
+	}
+
+	sender, receiver := NewKeyRatchet(epoch0, info), NewKeyRatchet(epoch0, info)
+
+	nonce0 := s.GenNonce()
+	_, old, err := sender.Encrypt(s, nonce0, []byte("epoch zero"))
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+	if sender.Rotate() != 1 || sender.Rotate() != 2 {
+		t.Fatal("rotation does not move to the next epoch")
+	}
+	nonce2 := s.GenNonce()
+	_, current, err := sender.Encrypt(s, nonce2, []byte("epoch two"))
+	if err != nil {
+		t.Fatalf("unable to encrypt: %v", err)
+	}
+
+	// a forged epoch does not move the receiver
+	forged := append([]byte{0, 0, 0, 5}, current[epochLength:]...)
+	if _, err := receiver.Decrypt(s, forged, nonce2); err == nil || receiver.Epoch() != 0 {
+		t.Fatalf("forged epoch accepted or moved the ratchet to epoch %d: %v", receiver.Epoch(), err)
+	}
+
+	message, err := receiver.Decrypt(s, current, nonce2)
+	if err != nil || message != "epoch two" {
+		t.Fatalf("unable to decrypt epoch 2 message: %q, %v", message, err)
+	}
+	if receiver.Epoch() != 2 {
+		t.Fatalf("receiver at epoch %d, want 2", receiver.Epoch())
+	}
+
+	// the epoch 0 key has been rotated away
+	if _, err := receiver.Decrypt(s, old, nonce0); !errors.Is(err, ErrStaleEpoch) {
+		t.Fatalf("expected %v, got %v", ErrStaleEpoch, err)
+	}
+	if _, err := receiver.Decrypt(s, append([]byte{0xff, 0xff, 0xff, 0xff}, current[epochLength:]...), nonce2); !errors.Is(err, ErrEpochTooFar) {
+		t.Fatalf("expected %v, got %v", ErrEpochTooFar, err)
+	}
+}
@@ -3,40 +3,113 @@ package signer
 import (
 	"crypto/cipher"
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
 )
 
+// ErrInvalidPrivateKey denotes a private key that cannot be imported.
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
 type Signer interface {
 	SignTx(transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignRawTx(rlpBytes []byte, chainID *big.Int) ([]byte, error)
 	NewHDWallet(params *chaincfg.Params) error
 	DeriveFromParent(parent *hdkeychain.ExtendedKey) (*hdkeychain.ExtendedKey, error)
+	DeriveKeyPairFromParent(parent *hdkeychain.ExtendedKey, index uint32) (*ECDSAKeyPair, error)
+	DeriveBip44(coinType, account, change, index uint32) (*ECDSAKeyPair, error)
+	DeriveRange(coinType, account, change, start, count uint32) ([]common.Address, error)
+	NextReceiveKey() (*ECDSAKeyPair, error)
 	defaultBip44Path() []uint32
 	deriveCustomBip44Path(coinType, account, change, index uint32) []uint32
-	GetSharedKey(their ecdsa.PublicKey) [32]byte
+	GetSharedKey(their ecdsa.PublicKey) ([32]byte, error)
+	DeriveKeys(their ecdsa.PublicKey, info []byte) (encKey, macKey [32]byte, err error)
 	GenNonce() []byte
 	EncryptAndGetHash(key [32]byte, nonce []byte, message []byte) ([32]byte, []byte, error)
 	DecryptMessage(sharedKey [32]byte, cipherText []byte, nonce []byte) (string, error)
-	getCipherMode(key []byte) (cipher.AEAD, error)
+	getCipherMode(suite CipherSuite, key []byte) (cipher.AEAD, error)
 	VerifySignature(publicKey ecdsa.PublicKey, signature, messageHash []byte) bool
 	Sign(hash [32]byte) ([]byte, error)
+	SignCompact(hash [32]byte) ([]byte, error)
+	SignSplit(hash [32]byte, opts ...SplitOption) (r, s *big.Int, v uint8, err error)
 	GetPublicKey() *ecdsa.PublicKey
+	ExportKeystore(password string) ([]byte, error)
 }
 
 type signer struct {
-	Wallet *hdWallet
+	Wallet  *hdWallet
+	backend KeyBackend
+	chain   *chainIDCache // chain ID used when SignTx is called without one, nil if not configured
+	logger  *logrus.Logger
+
+	cipherSuite CipherSuite // AEAD used by EncryptAndGetHash
 }
 
-func New(params *chaincfg.Params) (Signer, error) {
-	newSigner := &signer{}
+func New(params *chaincfg.Params, opts ...Option) (Signer, error) {
+	newSigner := newSigner(opts...)
 
 	err := newSigner.NewHDWallet(params)
 	if err != nil {
 		return nil, err
 	}
 
+	newSigner.backend = newSigner.Wallet.EcdsaKeyPair
+
 	return newSigner, nil
 }
+
+// NewFromPrivateKey returns a signer for an existing secp256k1 private key. The signer has no HD
+// master key, so the key derivation methods return ErrNoMasterKey.
+func NewFromPrivateKey(priv *ecdsa.PrivateKey, opts ...Option) (Signer, error) {
+	if priv == nil {
+		return nil, errors.New("private key is nil")
+	}
+
+	keyPair := &ECDSAKeyPair{
+		publicKey:  &priv.PublicKey,
+		privateKey: priv,
+	}
+
+	s := newSigner(opts...)
+	s.Wallet = &hdWallet{
+		EcdsaKeyPair: keyPair,
+		Paths:        make(map[string]string),
+	}
+	s.backend = keyPair
+
+	return s, nil
+}
+
+// NewFromHex returns a signer for a hex encoded 32-byte private key, with or without 0x prefix.
+func NewFromHex(hexKey string, opts ...Option) (Signer, error) {
+	hexKey = strings.TrimPrefix(strings.TrimPrefix(hexKey, "0x"), "0X")
+	if len(hexKey) != 64 {
+		return nil, fmt.Errorf("%w: private key must be 32 bytes (64 hex characters), got %d characters", ErrInvalidPrivateKey, len(hexKey))
+	}
+
+	priv, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	return NewFromPrivateKey(priv, opts...)
+}
+
+// NewWithKeyBackend returns a signer that delegates signing to the given backend. The signer has
+// no HD wallet, so only signing, verification and GetPublicKey are available: key derivation
+// returns ErrNoMasterKey, and shared key (ECDH) helpers and keystore export return ErrUnsupported
+// unless the backend is an in-memory ECDSAKeyPair.
+func NewWithKeyBackend(backend KeyBackend, opts ...Option) Signer {
+	s := newSigner(opts...)
+	s.backend = backend
+
+	return s
+}
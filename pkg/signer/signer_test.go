@@ -0,0 +1,271 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newTestSigner(t *testing.T) Signer {
+	t.Helper()
+
+	s, err := New(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	return s
+}
+
+// sharedKey returns the GetSharedKey of s with the public key of their.
+func sharedKey(t *testing.T, s, their Signer) [32]byte {
+	t.Helper()
+
+	key, err := s.GetSharedKey(*their.GetPublicKey())
+	if err != nil {
+		t.Fatalf("unable to get shared key: %v", err)
+	}
+	return key
+}
+
+func TestSignRawTx(t *testing.T) {
+	s := newTestSigner(t)
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+
+	unsigned, err := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		To:        &to,
+		Value:     big.NewInt(1000),
+		Gas:       21000,
+		GasFeeCap: big.NewInt(2e9),
+		GasTipCap: big.NewInt(1e9),
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to encode transaction: %v", err)
+	}
+
+	signedBytes, err := s.SignRawTx(unsigned, chainID)
+	if err != nil {
+		t.Fatalf("unable to sign raw transaction: %v", err)
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(signedBytes); err != nil {
+		t.Fatalf("unable to decode signed transaction: %v", err)
+	}
+	if signedTx.Nonce() != 7 || *signedTx.To() != to {
+		t.Fatalf("signed transaction does not match the original")
+	}
+
+	sender, err := types.Sender(types.NewLondonSigner(chainID), signedTx)
+	if err != nil {
+		t.Fatalf("unable to recover sender: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(*s.GetPublicKey()); sender != want {
+		t.Fatalf("recovered sender %s, want %s", sender, want)
+	}
+}
+
+func TestSignRawTxInvalidEncoding(t *testing.T) {
+	s := newTestSigner(t)
+
+	if _, err := s.SignRawTx([]byte{0x02, 0xff}, big.NewInt(1)); err == nil {
+		t.Fatal("expected an error for malformed transaction bytes")
+	}
+}
+
+func TestDeriveRange(t *testing.T) {
+	s := newTestSigner(t)
+
+	const (
+		coinType = 60
+		start    = 3
+		count    = 5
+	)
+
+	addresses, err := s.DeriveRange(coinType, 0, 0, start, count)
+	if err != nil {
+		t.Fatalf("unable to derive range: %v", err)
+	}
+	if len(addresses) != count {
+		t.Fatalf("derived %d addresses, want %d", len(addresses), count)
+	}
+
+	seen := make(map[common.Address]bool)
+	for i, address := range addresses {
+		keyPair, err := s.DeriveBip44(coinType, 0, 0, start+uint32(i))
+		if err != nil {
+			t.Fatalf("unable to derive index %d: %v", start+i, err)
+		}
+		if want := crypto.PubkeyToAddress(*keyPair.publicKey); address != want {
+			t.Fatalf("address at index %d is %s, want %s", start+i, address, want)
+		}
+		if seen[address] {
+			t.Fatalf("duplicate address %s", address)
+		}
+		seen[address] = true
+	}
+}
+
+func TestDeriveKeyPairFromParent(t *testing.T) {
+	s := newTestSigner(t)
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+	if err != nil {
+		t.Fatalf("unable to generate seed: %v", err)
+	}
+	parent, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+
+	keyPair, err := s.DeriveKeyPairFromParent(parent, 7)
+	if err != nil {
+		t.Fatalf("unable to derive key pair: %v", err)
+	}
+
+	hash := crypto.Keccak256Hash([]byte("child"))
+	signature, err := keyPair.Sign(hash)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	if !s.VerifySignature(*keyPair.PublicKey(), signature, hash[:]) {
+		t.Fatal("signature of the child key does not verify")
+	}
+
+	// a neutered parent holds no private key to derive from
+	neutered, err := parent.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter parent: %v", err)
+	}
+	if _, err := s.DeriveKeyPairFromParent(neutered, 7); !errors.Is(err, ErrNeuteredKey) {
+		t.Fatalf("expected %v, got %v", ErrNeuteredKey, err)
+	}
+}
+
+func TestNewFromPrivateKey(t *testing.T) {
+	priv, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatalf("unable to parse private key: %v", err)
+	}
+
+	s, err := NewFromPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+
+	want := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	if got := crypto.PubkeyToAddress(*s.GetPublicKey()); got != want {
+		t.Fatalf("address %s, want %s", got, want)
+	}
+
+	chainID := big.NewInt(1)
+	signedTx, err := s.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &want,
+		Gas:       21000,
+		GasFeeCap: big.NewInt(2e9),
+		GasTipCap: big.NewInt(1e9),
+	}), chainID)
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %v", err)
+	}
+	sender, err := types.Sender(types.NewLondonSigner(chainID), signedTx)
+	if err != nil {
+		t.Fatalf("unable to recover sender: %v", err)
+	}
+	if sender != want {
+		t.Fatalf("transaction signed by %s, want %s", sender, want)
+	}
+
+	if _, err := s.DeriveBip44(60, 0, 0, 0); !errors.Is(err, ErrNoMasterKey) {
+		t.Fatalf("expected %v, got %v", ErrNoMasterKey, err)
+	}
+}
+
+func TestNewFromHex(t *testing.T) {
+	const key = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	want := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+
+	for _, hexKey := range []string{key, "0x" + key} {
+		s, err := NewFromHex(hexKey)
+		if err != nil {
+			t.Fatalf("unable to import %q: %v", hexKey, err)
+		}
+		if got := crypto.PubkeyToAddress(*s.GetPublicKey()); got != want {
+			t.Fatalf("address %s, want %s", got, want)
+		}
+	}
+
+	for name, hexKey := range map[string]string{
+		"too short":   key[:62],
+		"invalid hex": "zz" + key[2:],
+		"empty":       "0x",
+	} {
+		if _, err := NewFromHex(hexKey); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Fatalf("%s: expected %v, got %v", name, ErrInvalidPrivateKey, err)
+		}
+	}
+}
+
+func TestNextReceiveKeyConcurrent(t *testing.T) {
+	const (
+		workers   = 16
+		perWorker = 8
+	)
+	s := newTestSigner(t)
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		addresses = make(map[common.Address]bool)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				keyPair, err := s.NextReceiveKey()
+				if err != nil {
+					t.Errorf("unable to derive next key: %v", err)
+					return
+				}
+				address := crypto.PubkeyToAddress(*keyPair.publicKey)
+
+				mu.Lock()
+				if addresses[address] {
+					t.Errorf("duplicate key %s", address)
+				}
+				addresses[address] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	wallet := s.(*signer).Wallet
+	if len(addresses) != workers*perWorker || wallet.NextChildIndex != workers*perWorker {
+		t.Fatalf("derived %d keys up to index %d, want %d", len(addresses), wallet.NextChildIndex, workers*perWorker)
+	}
+	if len(wallet.Paths) != workers*perWorker {
+		t.Fatalf("recorded %d paths, want %d", len(wallet.Paths), workers*perWorker)
+	}
+
+	// every derived key is the one at its recorded path
+	first, err := s.DeriveBip44(0, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("unable to derive index 0: %v", err)
+	}
+	address := crypto.PubkeyToAddress(*first.publicKey)
+	if path := wallet.Paths[address.Hex()]; path != "m/44'/0'/0'/0/0" {
+		t.Fatalf("path of %s is %q, want m/44'/0'/0'/0/0", address, path)
+	}
+}
@@ -0,0 +1,124 @@
+package signer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// vectorKey and vectorTo are the private key and recipient of the example in EIP-155.
+const vectorKey = "4646464646464646464646464646464646464646464646464646464646464646"
+
+var vectorTo = common.HexToAddress("0x3535353535353535353535353535353535353535")
+
+func mustBigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+	return n
+}
+
+func TestSignTxVectors(t *testing.T) {
+	s, err := NewFromHex(vectorKey)
+	if err != nil {
+		t.Fatalf("unable to import key: %v", err)
+	}
+
+	legacy := &types.LegacyTx{
+		Nonce:    9,
+		GasPrice: big.NewInt(20e9),
+		Gas:      21000,
+		To:       &vectorTo,
+		Value:    big.NewInt(1e18),
+	}
+
+	cases := []struct {
+		name    string
+		tx      types.TxData
+		chainID *big.Int
+		raw     string
+		v, r, s string
+	}{
+		{
+			name: "legacy homestead",
+			tx:   legacy,
+			raw:  "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000801ba08383adc8b8ae116f918fb44ca7ff9dfd8012596a5c130c6246a2cc717ba41cdaa053ddfacf5bd4aa7e46d1575acf52636ea659b91f29e2fb91c75567a279738f38",
+			v:    "27",
+			r:    "59485639543450262555276255741419961843919917718841446994873102661036022176986",
+			s:    "37934170660693695168448626737356350749141639305614298108112256265929102692152",
+		},
+		{
+			name:    "legacy zero chain id",
+			tx:      legacy,
+			chainID: big.NewInt(0),
+			raw:     "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000801ba08383adc8b8ae116f918fb44ca7ff9dfd8012596a5c130c6246a2cc717ba41cdaa053ddfacf5bd4aa7e46d1575acf52636ea659b91f29e2fb91c75567a279738f38",
+			v:       "27",
+			r:       "59485639543450262555276255741419961843919917718841446994873102661036022176986",
+			s:       "37934170660693695168448626737356350749141639305614298108112256265929102692152",
+		},
+		{
+			// the example transaction of EIP-155
+			name:    "eip155",
+			tx:      legacy,
+			chainID: big.NewInt(1),
+			raw:     "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+			v:       "37",
+			r:       "18515461264373351373200002665853028612451056578545711640558177340181847433846",
+			s:       "46948507304638947509940763649030358759909902576025900602547168820602576006531",
+		},
+		{
+			name: "eip1559",
+			tx: &types.DynamicFeeTx{
+				ChainID:   big.NewInt(1),
+				Nonce:     9,
+				GasTipCap: big.NewInt(1e9),
+				GasFeeCap: big.NewInt(20e9),
+				Gas:       21000,
+				To:        &vectorTo,
+				Value:     big.NewInt(1e18),
+			},
+			chainID: big.NewInt(1),
+			raw:     "0x02f8730109843b9aca008504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080c080a04e87ced8b47d801c979c6baa52bbd78b42c9db2515c9d1f473e06f65d49aaa90a02357671517c59544ebd95012d1988c102292eb570cc840ac9af72bb4c52e5edd",
+			v:       "0",
+			r:       "35520354145343619523594023241878581587327031125497181026949872498250322979472",
+			s:       "15985376843586106142193028908041788455375653768377442367663754371184615907037",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signedTx, err := s.SignTx(types.NewTx(tc.tx), tc.chainID)
+			if err != nil {
+				t.Fatalf("unable to sign transaction: %v", err)
+			}
+
+			raw, err := signedTx.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unable to encode transaction: %v", err)
+			}
+			if got := hexutil.Encode(raw); got != tc.raw {
+				t.Fatalf("raw transaction %s, want %s", got, tc.raw)
+			}
+
+			v, r, sig := signedTx.RawSignatureValues()
+			for _, value := range []struct {
+				name      string
+				got, want *big.Int
+			}{
+				{"v", v, mustBigInt(t, tc.v)},
+				{"r", r, mustBigInt(t, tc.r)},
+				{"s", sig, mustBigInt(t, tc.s)},
+			} {
+				if value.got.Cmp(value.want) != 0 {
+					t.Fatalf("%s = %s, want %s", value.name, value.got, value.want)
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,26 @@
 package signer
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrNeuteredKey denotes an extended public key where a private key is needed.
+	ErrNeuteredKey = errors.New("extended key is neutered and holds no private key")
+	// ErrNoMasterKey denotes a derivation on a signer imported from a single private key.
+	ErrNoMasterKey = errors.New("signer has no hd master key")
 )
 
 type hdWallet struct {
+	mu             sync.Mutex // guards NextChildIndex and Paths
 	MasterKey      *hdkeychain.ExtendedKey
 	EcdsaKeyPair   *ECDSAKeyPair
 	NextChildIndex uint32
@@ -29,14 +44,155 @@ func (s *signer) NewHDWallet(params *chaincfg.Params) error {
 		Paths:          make(map[string]string),
 	}
 
+	key, err := s.derivePath(s.defaultBip44Path())
+	if err != nil {
+		return err
+	}
+
+	s.Wallet.EcdsaKeyPair, err = toECDSAKeyPair(key)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// DeriveBip44 derives the key pair at m/44'/coinType'/account'/change/index.
+func (s *signer) DeriveBip44(coinType, account, change, index uint32) (*ECDSAKeyPair, error) {
+	key, err := s.derivePath(s.deriveCustomBip44Path(coinType, account, change, index))
+	if err != nil {
+		return nil, err
+	}
+	return toECDSAKeyPair(key)
+}
+
+// DeriveRange derives count consecutive addresses starting at index start under
+// m/44'/coinType'/account'/change. The parent key is derived once and reused for every child.
+func (s *signer) DeriveRange(coinType, account, change, start, count uint32) ([]common.Address, error) {
+	path := s.deriveCustomBip44Path(coinType, account, change, start)
+
+	parent, err := s.derivePath(path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]common.Address, 0, count)
+	for i := uint32(0); i < count; i++ {
+		child, err := parent.Derive(start + i)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive child %d: %w", start+i, err)
+		}
+
+		keyPair, err := toECDSAKeyPair(child)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, crypto.PubkeyToAddress(*keyPair.publicKey))
+	}
+
+	return addresses, nil
+}
+
+// NextReceiveKey derives the key pair at the next unused index of the default receive chain,
+// m/44'/0'/0'/0/index, and records its path. It is safe for concurrent use.
+func (s *signer) NextReceiveKey() (*ECDSAKeyPair, error) {
+	if s.Wallet == nil || s.Wallet.MasterKey == nil {
+		return nil, ErrNoMasterKey
+	}
+
+	s.Wallet.mu.Lock()
+	index := s.Wallet.NextChildIndex
+	s.Wallet.NextChildIndex++
+	s.Wallet.mu.Unlock()
+
+	path := s.defaultBip44Path()
+	path[len(path)-1] = index
+
+	key, err := s.derivePath(path)
+	if err != nil {
+		return nil, err
+	}
+	keyPair, err := toECDSAKeyPair(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Wallet.mu.Lock()
+	s.Wallet.Paths[crypto.PubkeyToAddress(*keyPair.publicKey).Hex()] = formatPath(path)
+	s.Wallet.mu.Unlock()
+
+	return keyPair, nil
+}
+
+// formatPath formats a derivation path the BIP32 way, e.g. m/44'/0'/0'/0/3.
+func formatPath(path []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range path {
+		if index >= hdkeychain.HardenedKeyStart {
+			fmt.Fprintf(&b, "/%d'", index-hdkeychain.HardenedKeyStart)
+		} else {
+			fmt.Fprintf(&b, "/%d", index)
+		}
+	}
+	return b.String()
+}
+
+// derivePath derives the extended key at the given path starting from the master key.
+func (s *signer) derivePath(path []uint32) (*hdkeychain.ExtendedKey, error) {
+	if s.Wallet == nil || s.Wallet.MasterKey == nil {
+		return nil, ErrNoMasterKey
+	}
+
+	key := s.Wallet.MasterKey
+	for _, index := range path {
+		child, err := key.Derive(index)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive child %d: %w", index, err)
+		}
+		key = child
+	}
+	return key, nil
+}
+
+// toECDSAKeyPair converts an extended private key into a secp256k1 key pair usable for ethereum.
+func toECDSAKeyPair(key *hdkeychain.ExtendedKey) (*ECDSAKeyPair, error) {
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get private key: %w", err)
+	}
+
+	privateKey, err := crypto.ToECDSA(privKey.Serialize())
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert private key: %w", err)
+	}
+
+	return &ECDSAKeyPair{
+		publicKey:  &privateKey.PublicKey,
+		privateKey: privateKey,
+	}, nil
+}
+
 func (s *signer) DeriveFromParent(parent *hdkeychain.ExtendedKey) (*hdkeychain.ExtendedKey, error) {
 	parent.Depth()
 	return parent, nil
 }
 
+// DeriveKeyPairFromParent derives the child of parent at index and returns it as a key pair ready to
+// sign with. The parent must be an extended private key.
+func (s *signer) DeriveKeyPairFromParent(parent *hdkeychain.ExtendedKey, index uint32) (*ECDSAKeyPair, error) {
+	if !parent.IsPrivate() {
+		return nil, ErrNeuteredKey
+	}
+
+	child, err := parent.Derive(index)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive child %d: %w", index, err)
+	}
+
+	return toECDSAKeyPair(child)
+}
+
 func (s *signer) defaultBip44Path() []uint32 {
 	return []uint32{
 		44 + hdkeychain.HardenedKeyStart,
@@ -0,0 +1,55 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// ErrNoRPCClient denotes a call needing the RPC client of WithRPCClient on a service without one.
+var ErrNoRPCClient = errors.New("no rpc client configured")
+
+// WithRPCClient sets the RPC client used for the node methods the backend does not expose, such as
+// eth_createAccessList.
+func WithRPCClient(client *rpc.Client) Option {
+	return optionFunc(func(t *TxService) {
+		t.rpcClient = client
+	})
+}
+
+// CreateAccessList asks the node, through eth_createAccessList, for the access list of the request
+// sent from the service's account, along with the gas the transaction uses with that list. The
+// result can be set as TxRequest.AccessList. A request the node cannot execute fails with the
+// error reported by the node, mapped by MapNodeError.
+func (t *TxService) CreateAccessList(ctx context.Context, request *TxRequest) (types.AccessList, uint64, error) {
+	if err := t.checkClosed(); err != nil {
+		return nil, 0, err
+	}
+	if t.rpcClient == nil {
+		return nil, 0, ErrNoRPCClient
+	}
+
+	accessList, gasUsed, vmErr, err := gethclient.New(t.rpcClient).CreateAccessList(ctx, ethereum.CallMsg{
+		From:       t.sender,
+		To:         request.To,
+		Data:       request.Data,
+		Value:      request.Value,
+		AccessList: request.AccessList,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to create access list: %w", MapNodeError(err))
+	}
+	if vmErr != "" {
+		return nil, 0, fmt.Errorf("unable to create access list: %w", MapNodeError(errors.New(vmErr)))
+	}
+	if accessList == nil {
+		return types.AccessList{}, gasUsed, nil
+	}
+
+	return *accessList, gasUsed, nil
+}
@@ -0,0 +1,128 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+var testAccessList = types.AccessList{{
+	Address:     common.HexToAddress("0x000000000000000000000000000000000000beef"),
+	StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+}}
+
+func TestSendAccessList(t *testing.T) {
+	var (
+		estimated types.AccessList
+		broadcast *types.Transaction
+	)
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+			return 0, nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+		backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+			estimated = call.AccessList
+			return 30000, nil
+		}),
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			broadcast = tx
+			return nil
+		}),
+	))
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	if _, err := service.Send(t.Context(), &transaction.TxRequest{To: &to, AccessList: testAccessList}); err != nil {
+		t.Fatalf("unable to send: %v", err)
+	}
+	if !reflect.DeepEqual(estimated, testAccessList) {
+		t.Fatalf("gas estimated with access list %v, want %v", estimated, testAccessList)
+	}
+
+	encoded, err := broadcast.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to encode transaction: %v", err)
+	}
+	var decoded types.Transaction
+	if err := decoded.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("unable to decode transaction: %v", err)
+	}
+	if decoded.Type() != types.DynamicFeeTxType {
+		t.Fatalf("transaction type %d, want %d", decoded.Type(), types.DynamicFeeTxType)
+	}
+	if !reflect.DeepEqual(decoded.AccessList(), testAccessList) {
+		t.Fatalf("decoded access list %v, want %v", decoded.AccessList(), testAccessList)
+	}
+}
+
+// accessListAPI serves eth_createAccessList.
+type accessListAPI struct {
+	calls []map[string]interface{}
+}
+
+type accessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	GasUsed    hexutil.Uint64   `json:"gasUsed"`
+	Error      string           `json:"error,omitempty"`
+}
+
+func (a *accessListAPI) CreateAccessList(args map[string]interface{}) (*accessListResult, error) {
+	a.calls = append(a.calls, args)
+	if args["input"] == "0xdead" {
+		return &accessListResult{AccessList: types.AccessList{}, Error: "execution reverted"}, nil
+	}
+	return &accessListResult{AccessList: testAccessList, GasUsed: 25000}, nil
+}
+
+func TestCreateAccessList(t *testing.T) {
+	api := &accessListAPI{}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatalf("unable to register api: %v", err)
+	}
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+
+	service, _ := newTestService(t, backendMock.New(), transaction.WithRPCClient(client))
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	accessList, gasUsed, err := service.CreateAccessList(t.Context(), &transaction.TxRequest{To: &to, Data: []byte{1}})
+	if err != nil {
+		t.Fatalf("unable to create access list: %v", err)
+	}
+	if !reflect.DeepEqual(accessList, testAccessList) || gasUsed != 25000 {
+		t.Fatalf("got access list %v using %d gas, want %v using 25000", accessList, gasUsed, testAccessList)
+	}
+	if len(api.calls) != 1 || !strings.EqualFold(api.calls[0]["to"].(string), to.Hex()) || api.calls[0]["input"] != "0x01" {
+		t.Fatalf("unexpected call arguments %v", api.calls)
+	}
+
+	if _, _, err := service.CreateAccessList(t.Context(), &transaction.TxRequest{To: &to, Data: []byte{0xde, 0xad}}); !errors.Is(err, transaction.ErrReverted) {
+		t.Fatalf("expected %v, got %v", transaction.ErrReverted, err)
+	}
+
+	withoutClient, _ := newTestService(t, backendMock.New())
+	if _, _, err := withoutClient.CreateAccessList(t.Context(), &transaction.TxRequest{To: &to}); !errors.Is(err, transaction.ErrNoRPCClient) {
+		t.Fatalf("expected %v, got %v", transaction.ErrNoRPCClient, err)
+	}
+}
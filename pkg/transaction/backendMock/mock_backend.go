@@ -0,0 +1,258 @@
+package backendMock
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+)
+
+type backendMock struct {
+	codeAt             func(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	callContract       func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	headerByNumber     func(ctx context.Context, number *big.Int) (*types.Header, error)
+	pendingNonceAt     func(ctx context.Context, account common.Address) (uint64, error)
+	suggestGasPrice    func(ctx context.Context) (*big.Int, error)
+	suggestGasTipCap   func(ctx context.Context) (*big.Int, error)
+	estimateGas        func(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	sendTransaction    func(ctx context.Context, tx *types.Transaction) error
+	transactionReceipt func(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	transactionByHash  func(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	blockNumber        func(ctx context.Context) (uint64, error)
+	balanceAt          func(ctx context.Context, address common.Address, block *big.Int) (*big.Int, error)
+	nonceAt            func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	filterLogs         func(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	chainID            func(ctx context.Context) (*big.Int, error)
+	feeHistory         func(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+func (m *backendMock) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if m.codeAt != nil {
+		return m.codeAt(ctx, contract, blockNumber)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if m.callContract != nil {
+		return m.callContract(ctx, call, blockNumber)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if m.headerByNumber != nil {
+		return m.headerByNumber(ctx, number)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if m.pendingNonceAt != nil {
+		return m.pendingNonceAt(ctx, account)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *backendMock) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if m.suggestGasPrice != nil {
+		return m.suggestGasPrice(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if m.suggestGasTipCap != nil {
+		return m.suggestGasTipCap(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	if m.estimateGas != nil {
+		return m.estimateGas(ctx, call)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *backendMock) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if m.sendTransaction != nil {
+		return m.sendTransaction(ctx, tx)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *backendMock) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if m.transactionReceipt != nil {
+		return m.transactionReceipt(ctx, txHash)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if m.transactionByHash != nil {
+		return m.transactionByHash(ctx, hash)
+	}
+	return nil, false, errors.New("not implemented")
+}
+
+func (m *backendMock) BlockNumber(ctx context.Context) (uint64, error) {
+	if m.blockNumber != nil {
+		return m.blockNumber(ctx)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *backendMock) BalanceAt(ctx context.Context, address common.Address, block *big.Int) (*big.Int, error) {
+	if m.balanceAt != nil {
+		return m.balanceAt(ctx, address, block)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if m.nonceAt != nil {
+		return m.nonceAt(ctx, account, blockNumber)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *backendMock) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if m.filterLogs != nil {
+		return m.filterLogs(ctx, query)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) ChainID(ctx context.Context) (*big.Int, error) {
+	if m.chainID != nil {
+		return m.chainID(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	if m.feeHistory != nil {
+		return m.feeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *backendMock) Close() {}
+
+// Option is the option passed to the mock backend
+type Option interface {
+	apply(*backendMock)
+}
+
+type optionFunc func(*backendMock)
+
+func (f optionFunc) apply(r *backendMock) { f(r) }
+
+func WithCodeAtFunc(f func(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.codeAt = f
+	})
+}
+
+func WithCallContractFunc(f func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.callContract = f
+	})
+}
+
+func WithHeaderByNumberFunc(f func(ctx context.Context, number *big.Int) (*types.Header, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.headerByNumber = f
+	})
+}
+
+func WithPendingNonceAtFunc(f func(ctx context.Context, account common.Address) (uint64, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.pendingNonceAt = f
+	})
+}
+
+func WithSuggestGasPriceFunc(f func(ctx context.Context) (*big.Int, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.suggestGasPrice = f
+	})
+}
+
+func WithSuggestGasTipCapFunc(f func(ctx context.Context) (*big.Int, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.suggestGasTipCap = f
+	})
+}
+
+func WithEstimateGasFunc(f func(ctx context.Context, call ethereum.CallMsg) (uint64, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.estimateGas = f
+	})
+}
+
+func WithSendTransactionFunc(f func(ctx context.Context, tx *types.Transaction) error) Option {
+	return optionFunc(func(s *backendMock) {
+		s.sendTransaction = f
+	})
+}
+
+func WithTransactionReceiptFunc(f func(ctx context.Context, txHash common.Hash) (*types.Receipt, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.transactionReceipt = f
+	})
+}
+
+func WithTransactionByHashFunc(f func(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.transactionByHash = f
+	})
+}
+
+func WithBlockNumberFunc(f func(ctx context.Context) (uint64, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.blockNumber = f
+	})
+}
+
+func WithBalanceAtFunc(f func(ctx context.Context, address common.Address, block *big.Int) (*big.Int, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.balanceAt = f
+	})
+}
+
+func WithNonceAtFunc(f func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.nonceAt = f
+	})
+}
+
+func WithFilterLogsFunc(f func(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.filterLogs = f
+	})
+}
+
+func WithChainIDFunc(f func(ctx context.Context) (*big.Int, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.chainID = f
+	})
+}
+
+func WithFeeHistoryFunc(f func(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)) Option {
+	return optionFunc(func(s *backendMock) {
+		s.feeHistory = f
+	})
+}
+
+func New(opts ...Option) transaction.Backend {
+	mock := new(backendMock)
+	for _, o := range opts {
+		o.apply(mock)
+	}
+	return mock
+}
@@ -0,0 +1,162 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// FlashbotsSignatureHeader is the header carrying the searcher signature of a bundle request.
+const FlashbotsSignatureHeader = "X-Flashbots-Signature"
+
+var (
+	// ErrNoBundleRelay denotes a bundle sent by a service without the relay of WithBundleRelay.
+	ErrNoBundleRelay = errors.New("no bundle relay configured")
+	// ErrEmptyBundle denotes a bundle without any transaction.
+	ErrEmptyBundle = errors.New("empty bundle")
+)
+
+type bundleRelay struct {
+	url    string
+	client *http.Client
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type sendBundleParams struct {
+	Txs         []string `json:"txs"`
+	BlockNumber string   `json:"blockNumber"`
+}
+
+type sendBundleResponse struct {
+	Result *struct {
+		BundleHash common.Hash `json:"bundleHash"`
+	} `json:"result"`
+	Error *jsonRPCError `json:"error"`
+}
+
+// WithBundleRelay configures the relay (e.g. https://relay.flashbots.net) used by SendBundle.
+// A nil client defaults to http.DefaultClient.
+func WithBundleRelay(url string, client *http.Client) Option {
+	return optionFunc(func(t *TxService) {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		t.relay = &bundleRelay{
+			url:    url,
+			client: client,
+		}
+	})
+}
+
+// SendBundle submits already signed transactions as a bundle to the configured relay via eth_sendBundle,
+// targeting inclusion in the given block instead of going through the public mempool.
+func (t *TxService) SendBundle(ctx context.Context, txs []*types.Transaction, targetBlock uint64) (common.Hash, error) {
+	if err := t.checkClosed(); err != nil {
+		return common.Hash{}, err
+	}
+
+	if t.relay == nil {
+		return common.Hash{}, ErrNoBundleRelay
+	}
+	if len(txs) == 0 {
+		return common.Hash{}, ErrEmptyBundle
+	}
+
+	params := sendBundleParams{
+		Txs:         make([]string, len(txs)),
+		BlockNumber: hexutil.EncodeUint64(targetBlock),
+	}
+	for i, tx := range txs {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("unable to encode transaction %s: %w", tx.Hash(), err)
+		}
+		params.Txs[i] = hexutil.Encode(raw)
+	}
+
+	body, err := json.Marshal(jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_sendBundle",
+		Params:  []interface{}{params},
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to marshal bundle request: %w", err)
+	}
+
+	signatureHeader, err := t.flashbotsSignature(body)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.relay.url, bytes.NewReader(body))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to create bundle request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(FlashbotsSignatureHeader, signatureHeader)
+
+	resp, err := t.relay.client.Do(req)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to send bundle: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to read bundle response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return common.Hash{}, fmt.Errorf("relay responded with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result sendBundleResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return common.Hash{}, fmt.Errorf("unable to unmarshal bundle response: %w", err)
+	}
+	if result.Error != nil {
+		return common.Hash{}, fmt.Errorf("relay rejected bundle: %s (code %d)", result.Error.Message, result.Error.Code)
+	}
+	if result.Result == nil {
+		return common.Hash{}, errors.New("relay returned no bundle hash")
+	}
+
+	return result.Result.BundleHash, nil
+}
+
+// flashbotsSignature returns the "<address>:<signature>" header value, where signature is the
+// EIP-191 personal signature of the hex encoded keccak256 hash of the request body.
+func (t *TxService) flashbotsSignature(body []byte) (string, error) {
+	hashedBody := hexutil.Encode(crypto.Keccak256(body))
+
+	var digest [32]byte
+	copy(digest[:], accounts.TextHash([]byte(hashedBody)))
+
+	signature, err := t.signer.Sign(digest)
+	if err != nil {
+		return "", fmt.Errorf("unable to sign bundle: %w", err)
+	}
+
+	return t.sender.Hex() + ":" + hexutil.Encode(signature), nil
+}
@@ -0,0 +1,145 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hblocks/keyless/pkg/signer"
+)
+
+func newTestSigner(t *testing.T) signer.Signer {
+	t.Helper()
+
+	s, err := signer.New(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	return s
+}
+
+func TestSendBundle(t *testing.T) {
+	s := newTestSigner(t)
+	sender := crypto.PubkeyToAddress(*s.GetPublicKey())
+	bundleHash := common.HexToHash("0xb0b0")
+
+	var (
+		gotBody   []byte
+		gotHeader string
+	)
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header.Get(FlashbotsSignatureHeader)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"` + bundleHash.Hex() + `"}}`))
+	}))
+	defer relay.Close()
+
+	service, err := NewTxService(nil, *NewBackend(nil), s, WithBundleRelay(relay.URL, relay.Client()))
+	if err != nil {
+		t.Fatalf("unable to create service: %v", err)
+	}
+
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, err := s.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			To:        &to,
+			Value:     big.NewInt(1),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(2e9),
+			GasTipCap: big.NewInt(1e9),
+		}), chainID)
+		if err != nil {
+			t.Fatalf("unable to sign transaction: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	got, err := service.SendBundle(t.Context(), txs, 100)
+	if err != nil {
+		t.Fatalf("unable to send bundle: %v", err)
+	}
+	if got != bundleHash {
+		t.Fatalf("bundle hash %s, want %s", got, bundleHash)
+	}
+
+	var request struct {
+		JSONRPC string             `json:"jsonrpc"`
+		Method  string             `json:"method"`
+		Params  []sendBundleParams `json:"params"`
+	}
+	if err := json.Unmarshal(gotBody, &request); err != nil {
+		t.Fatalf("unable to unmarshal request body: %v", err)
+	}
+	if request.JSONRPC != "2.0" || request.Method != "eth_sendBundle" || len(request.Params) != 1 {
+		t.Fatalf("unexpected request %s", gotBody)
+	}
+	if request.Params[0].BlockNumber != "0x64" {
+		t.Fatalf("block number %s, want 0x64", request.Params[0].BlockNumber)
+	}
+	for i, raw := range request.Params[0].Txs {
+		want, _ := txs[i].MarshalBinary()
+		if raw != hexutil.Encode(want) {
+			t.Fatalf("transaction %d is %s, want %x", i, raw, want)
+		}
+	}
+
+	address, signature, found := strings.Cut(gotHeader, ":")
+	if !found || common.HexToAddress(address) != sender {
+		t.Fatalf("signature header %q does not start with the sender address %s", gotHeader, sender)
+	}
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		t.Fatalf("unable to decode signature: %v", err)
+	}
+	digest := accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(gotBody))))
+	recovered, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		t.Fatalf("unable to recover signer: %v", err)
+	}
+	if crypto.PubkeyToAddress(*recovered) != sender {
+		t.Fatal("signature header was not produced by the sender")
+	}
+}
+
+func TestSendBundleRelayError(t *testing.T) {
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"bundle too old"}}`))
+	}))
+	defer relay.Close()
+
+	s := newTestSigner(t)
+	service, err := NewTxService(nil, *NewBackend(nil), s, WithBundleRelay(relay.URL, nil))
+	if err != nil {
+		t.Fatalf("unable to create service: %v", err)
+	}
+
+	to := common.Address{}
+	tx, err := s.SignTx(types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), To: &to, Gas: 21000}), big.NewInt(1))
+	if err != nil {
+		t.Fatalf("unable to sign transaction: %v", err)
+	}
+
+	if _, err := service.SendBundle(t.Context(), []*types.Transaction{tx}, 1); err == nil || !strings.Contains(err.Error(), "bundle too old") {
+		t.Fatalf("expected relay error, got %v", err)
+	}
+
+	noRelay, _ := NewTxService(nil, *NewBackend(nil), s)
+	if _, err := noRelay.SendBundle(t.Context(), []*types.Transaction{tx}, 1); !errors.Is(err, ErrNoBundleRelay) {
+		t.Fatalf("expected %v, got %v", ErrNoBundleRelay, err)
+	}
+}
@@ -0,0 +1,56 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hblocks/keyless/pkg/signer"
+)
+
+// DeployContract sends a contract creation transaction from the account of s, with the constructor
+// arguments packed according to contractABI appended to bytecode, and waits for its receipt. The
+// returned address is derived from the sender and the nonce of the transaction, as the EVM does.
+func (t *TxService) DeployContract(ctx context.Context, s signer.Signer, contractABI abi.ABI, bytecode []byte, constructorArgs ...interface{}) (common.Address, common.Hash, error) {
+	if err := t.checkClosed(); err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	if len(bytecode) == 0 {
+		return common.Address{}, common.Hash{}, fmt.Errorf("unable to deploy contract: empty bytecode")
+	}
+
+	args, err := contractABI.Pack("", constructorArgs...)
+	if err != nil {
+		return common.Address{}, common.Hash{}, fmt.Errorf("unable to pack constructor arguments: %w", err)
+	}
+
+	data := make([]byte, 0, len(bytecode)+len(args))
+	data = append(append(data, bytecode...), args...)
+
+	signedTx, err := t.signAndSendTx(ctx, s, &TxRequest{
+		To:          nil, // contract creation
+		Data:        data,
+		Value:       big.NewInt(0),
+		Description: "contract deployment",
+	})
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+
+	txHash := signedTx.Hash()
+	address := crypto.CreateAddress(crypto.PubkeyToAddress(*s.GetPublicKey()), signedTx.Nonce())
+
+	receipt, err := t.WaitForReceipt(ctx, txHash)
+	if err != nil {
+		return common.Address{}, txHash, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, txHash, fmt.Errorf("%w: deployment %s", ErrTransactionReverted, txHash)
+	}
+
+	return address, txHash, nil
+}
@@ -0,0 +1,98 @@
+package transaction_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hblocks/keyless/pkg/signer"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+const constructorABI = `[{"type":"constructor","stateMutability":"nonpayable","inputs":[{"name":"owner","type":"address"},{"name":"limit","type":"uint256"}]}]`
+
+func TestDeployContract(t *testing.T) {
+	deployer, err := signer.NewFromHex("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	account := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+
+	contractABI, err := abi.JSON(strings.NewReader(constructorABI))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %v", err)
+	}
+	bytecode := []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+	owner, limit := common.HexToAddress("0x000000000000000000000000000000000000beef"), big.NewInt(1000)
+
+	var (
+		sent   *types.Transaction
+		nonce  = uint64(7)
+		status = types.ReceiptStatusSuccessful
+	)
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, a common.Address) (uint64, error) {
+			return nonce, nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+		backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+			if call.To != nil {
+				t.Fatalf("deployment estimated as a call to %s", call.To)
+			}
+			return 100000, nil
+		}),
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			sent = tx
+			return nil
+		}),
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+			return &types.Receipt{TxHash: hash, Status: status}, nil
+		}),
+	))
+
+	address, txHash, err := service.DeployContract(t.Context(), deployer, contractABI, bytecode, owner, limit)
+	if err != nil {
+		t.Fatalf("unable to deploy contract: %v", err)
+	}
+	if want := crypto.CreateAddress(account, 7); address != want {
+		t.Fatalf("contract deployed at %s, want %s", address, want)
+	}
+	if sent == nil || sent.Hash() != txHash || sent.To() != nil {
+		t.Fatal("contract creation transaction was not broadcast")
+	}
+
+	args, err := contractABI.Pack("", owner, limit)
+	if err != nil {
+		t.Fatalf("unable to pack arguments: %v", err)
+	}
+	if want := append(bytes.Clone(bytecode), args...); !bytes.Equal(sent.Data(), want) {
+		t.Fatalf("deployment data %x, want %x", sent.Data(), want)
+	}
+
+	if _, _, err := service.DeployContract(t.Context(), deployer, contractABI, bytecode, owner); err == nil {
+		t.Fatal("expected an error for missing constructor arguments")
+	}
+
+	// a fresh nonce, so the reverted deployment is not answered from the receipt cache
+	nonce, status = 8, types.ReceiptStatusFailed
+	if _, _, err := service.DeployContract(t.Context(), deployer, contractABI, bytecode, owner, limit); !errors.Is(err, transaction.ErrTransactionReverted) {
+		t.Fatalf("expected %v, got %v", transaction.ErrTransactionReverted, err)
+	}
+}
@@ -0,0 +1,47 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const erc20ABIJSON = `[{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// erc20ABI is the subset of the ERC-20 ABI used to build token transactions.
+var erc20ABI = mustParseABI(erc20ABIJSON)
+
+// ErrInvalidAmount denotes a token amount that is nil or negative.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// BuildERC20Transfer returns a request calling transfer(to, amount) on the token contract. The
+// request carries no ether value; gas and fees are left to Send.
+func BuildERC20Transfer(token, to common.Address, amount *big.Int) (*TxRequest, error) {
+	if amount == nil || amount.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+
+	data, err := erc20ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pack transfer: %w", err)
+	}
+
+	return &TxRequest{
+		To:          &token,
+		Data:        data,
+		Value:       big.NewInt(0),
+		Description: "erc20 transfer",
+	}, nil
+}
+
+func mustParseABI(json string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(json))
+	if err != nil {
+		panic(fmt.Sprintf("unable to parse abi: %v", err))
+	}
+	return parsed
+}
@@ -0,0 +1,39 @@
+package transaction_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hblocks/keyless/pkg/transaction"
+)
+
+func TestBuildERC20Transfer(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	request, err := transaction.BuildERC20Transfer(token, to, big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatalf("unable to build transfer: %v", err)
+	}
+
+	if request.To == nil || *request.To != token {
+		t.Fatalf("request sent to %v, want the token %s", request.To, token)
+	}
+	if request.Value.Sign() != 0 {
+		t.Fatalf("request carries %s wei, want 0", request.Value)
+	}
+
+	want := "a9059cbb" + // transfer(address,uint256)
+		"000000000000000000000000000000000000000000000000000000000000dead" +
+		"00000000000000000000000000000000000000000000000000000000000f4240"
+	if got := hex.EncodeToString(request.Data); got != want {
+		t.Fatalf("calldata %s, want %s", got, want)
+	}
+
+	if _, err := transaction.BuildERC20Transfer(token, to, big.NewInt(-1)); !errors.Is(err, transaction.ErrInvalidAmount) {
+		t.Fatalf("expected %v, got %v", transaction.ErrInvalidAmount, err)
+	}
+}
@@ -0,0 +1,55 @@
+package transaction
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNonceTooLow denotes that the node rejected a transaction whose nonce was already used.
+	ErrNonceTooLow = errors.New("nonce too low")
+	// ErrInsufficientFunds denotes that the sender cannot pay for the value and gas of a transaction.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrReplacementUnderpriced denotes that a replacement transaction does not bump the fees of the
+	// pending one enough.
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+	// ErrReverted denotes that the node reported a revert while executing a call or estimating gas.
+	ErrReverted = errors.New("execution reverted")
+)
+
+// nodeErrors maps substrings of the error messages of common node implementations to typed errors.
+var nodeErrors = []struct {
+	message string
+	err     error
+}{
+	{"nonce too low", ErrNonceTooLow},
+	{"nonce has already been used", ErrNonceTooLow},
+	{"insufficient funds", ErrInsufficientFunds},
+	{"replacement transaction underpriced", ErrReplacementUnderpriced},
+	{"replacement fee too low", ErrReplacementUnderpriced},
+	{"execution reverted", ErrReverted},
+	{"already known", ErrAlreadyImported},
+}
+
+// MapNodeError wraps err with the typed error matching its message, so that callers can use
+// errors.Is instead of matching strings. The original error stays in the chain, which keeps for
+// example revert data reachable. Errors with an unknown message are returned unchanged.
+func MapNodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, e := range nodeErrors {
+		if strings.Contains(message, e.message) {
+			if errors.Is(err, e.err) {
+				return err
+			}
+			return fmt.Errorf("%w: %w", e.err, err)
+		}
+	}
+
+	return err
+}
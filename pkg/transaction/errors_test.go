@@ -0,0 +1,56 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+func TestMapNodeError(t *testing.T) {
+	cases := []struct {
+		message string
+		want    error
+	}{
+		{"nonce too low: address 0x2c75, tx: 3 state: 5", transaction.ErrNonceTooLow},
+		{"Nonce too low", transaction.ErrNonceTooLow},
+		{"insufficient funds for gas * price + value: balance 0, tx cost 21000000", transaction.ErrInsufficientFunds},
+		{"replacement transaction underpriced", transaction.ErrReplacementUnderpriced},
+		{"execution reverted: ERC20: transfer amount exceeds balance", transaction.ErrReverted},
+		{"already known", transaction.ErrAlreadyImported},
+	}
+
+	for _, tc := range cases {
+		nodeErr := errors.New(tc.message)
+		err := transaction.MapNodeError(nodeErr)
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%q: expected %v, got %v", tc.message, tc.want, err)
+		}
+		if !errors.Is(err, nodeErr) {
+			t.Fatalf("%q: original error dropped from %v", tc.message, err)
+		}
+	}
+
+	unknown := errors.New("header not found")
+	if err := transaction.MapNodeError(unknown); err != unknown {
+		t.Fatalf("unknown error changed to %v", err)
+	}
+	if err := transaction.MapNodeError(nil); err != nil {
+		t.Fatalf("nil error mapped to %v", err)
+	}
+}
+
+func TestWrappedBackendMapsErrors(t *testing.T) {
+	backend := transaction.NewBackend(backendMock.New(
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			return errors.New("nonce too low")
+		}),
+	))
+
+	if err := backend.SendTransaction(t.Context(), types.NewTx(&types.DynamicFeeTx{})); !errors.Is(err, transaction.ErrNonceTooLow) {
+		t.Fatalf("expected %v, got %v", transaction.ErrNonceTooLow, err)
+	}
+}
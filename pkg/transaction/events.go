@@ -12,8 +12,15 @@ import (
 var (
 	ErrEventNotFound = errors.New("event not found")
 	ErrNoTopic       = errors.New("no topic")
+	ErrUnknownEvent  = errors.New("unknown event signature")
 )
 
+// LogDecoder decodes logs of any event of an ABI, dispatching on the log's first topic.
+type LogDecoder struct {
+	abi    *abi.ABI
+	events map[common.Hash]abi.Event
+}
+
 // ParseEvent will parse the specified abi event from the given log
 func ParseEvent(a *abi.ABI, eventName string, c interface{}, e types.Log) error {
 	if len(e.Topics) == 0 {
@@ -53,3 +60,51 @@ func FindSingleEvent(abi *abi.ABI, receipt *types.Receipt, contractAddress commo
 	}
 	return ErrEventNotFound
 }
+
+// NewLogDecoder indexes the events of the ABI by their signature hash.
+func NewLogDecoder(a *abi.ABI) *LogDecoder {
+	events := make(map[common.Hash]abi.Event, len(a.Events))
+	for _, event := range a.Events {
+		if event.Anonymous {
+			continue // anonymous events have no signature topic to dispatch on
+		}
+		events[event.ID] = event
+	}
+
+	return &LogDecoder{
+		abi:    a,
+		events: events,
+	}
+}
+
+// Decode returns the name of the event emitting the log along with its indexed and non-indexed
+// arguments keyed by argument name.
+func (d *LogDecoder) Decode(log types.Log) (name string, out map[string]interface{}, err error) {
+	if len(log.Topics) == 0 {
+		return "", nil, ErrNoTopic
+	}
+
+	event, ok := d.events[log.Topics[0]]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownEvent, log.Topics[0])
+	}
+
+	out = make(map[string]interface{})
+	if len(log.Data) > 0 {
+		if err := event.Inputs.UnpackIntoMap(out, log.Data); err != nil {
+			return "", nil, fmt.Errorf("err unpacking %s data: %w", event.Name, err)
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(out, indexed, log.Topics[1:]); err != nil {
+		return "", nil, fmt.Errorf("err parsing %s topics: %w", event.Name, err)
+	}
+
+	return event.Name, out, nil
+}
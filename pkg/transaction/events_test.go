@@ -0,0 +1,90 @@
+package transaction_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+)
+
+const erc20EventsABI = `[
+	{"anonymous":false,"type":"event","name":"Transfer","inputs":[
+		{"indexed":true,"name":"from","type":"address"},
+		{"indexed":true,"name":"to","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"}]},
+	{"anonymous":false,"type":"event","name":"Approval","inputs":[
+		{"indexed":true,"name":"owner","type":"address"},
+		{"indexed":true,"name":"spender","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"}]}
+]`
+
+func TestLogDecoder(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(erc20EventsABI))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %v", err)
+	}
+	decoder := transaction.NewLogDecoder(&parsed)
+
+	alice := common.HexToAddress("0xa11ce")
+	bob := common.HexToAddress("0xb0b")
+
+	newLog := func(event string, first, second common.Address, value int64) types.Log {
+		data, err := parsed.Events[event].Inputs.NonIndexed().Pack(big.NewInt(value))
+		if err != nil {
+			t.Fatalf("unable to pack %s data: %v", event, err)
+		}
+		return types.Log{
+			Topics: []common.Hash{
+				parsed.Events[event].ID,
+				common.BytesToHash(first.Bytes()),
+				common.BytesToHash(second.Bytes()),
+			},
+			Data: data,
+		}
+	}
+
+	logs := []types.Log{
+		newLog("Transfer", alice, bob, 100),
+		newLog("Approval", bob, alice, 5),
+		newLog("Transfer", bob, alice, 7),
+	}
+	want := []struct {
+		name          string
+		first, second string
+		a, b          common.Address
+		value         int64
+	}{
+		{"Transfer", "from", "to", alice, bob, 100},
+		{"Approval", "owner", "spender", bob, alice, 5},
+		{"Transfer", "from", "to", bob, alice, 7},
+	}
+
+	for i, log := range logs {
+		name, out, err := decoder.Decode(log)
+		if err != nil {
+			t.Fatalf("unable to decode log %d: %v", i, err)
+		}
+		if name != want[i].name {
+			t.Fatalf("log %d decoded as %s, want %s", i, name, want[i].name)
+		}
+		if out[want[i].first] != want[i].a || out[want[i].second] != want[i].b {
+			t.Fatalf("log %d has unexpected indexed arguments %v", i, out)
+		}
+		if value, ok := out["value"].(*big.Int); !ok || value.Int64() != want[i].value {
+			t.Fatalf("log %d has value %v, want %d", i, out["value"], want[i].value)
+		}
+	}
+
+	unknown := types.Log{Topics: []common.Hash{common.HexToHash("0xdeadbeef")}}
+	if _, _, err := decoder.Decode(unknown); !errors.Is(err, transaction.ErrUnknownEvent) {
+		t.Fatalf("expected %v, got %v", transaction.ErrUnknownEvent, err)
+	}
+	if _, _, err := decoder.Decode(types.Log{}); !errors.Is(err, transaction.ErrNoTopic) {
+		t.Fatalf("expected %v, got %v", transaction.ErrNoTopic, err)
+	}
+}
@@ -0,0 +1,112 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	_ GasOracle = (*nodeSuggested)(nil)
+	_ GasOracle = (*percentileFromHistory)(nil)
+	_ GasOracle = (*fixed)(nil)
+
+	// ErrNoFeeHistory denotes that the node returned no fee history to derive fees from.
+	ErrNoFeeHistory = errors.New("no fee history")
+)
+
+// GasOracle decides the EIP-1559 fee fields of transactions whose request leaves them unset.
+type GasOracle interface {
+	// FeeAndTip returns the max fee per gas and the max priority fee per gas to use.
+	FeeAndTip(ctx context.Context) (gasFeeCap, gasTipCap *big.Int, err error)
+}
+
+type nodeSuggested struct {
+	backend Backend
+}
+
+// NodeSuggested uses the gas price and tip suggested by the node; the fee cap is their sum.
+func NodeSuggested(backend Backend) GasOracle {
+	return &nodeSuggested{backend: backend}
+}
+
+func (o *nodeSuggested) FeeAndTip(ctx context.Context) (*big.Int, *big.Int, error) {
+	gasPrice, err := o.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasTipCap, err := o.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return new(big.Int).Add(gasTipCap, gasPrice), gasTipCap, nil
+}
+
+type percentileFromHistory struct {
+	backend    Backend
+	blocks     uint64
+	percentile float64
+}
+
+// PercentileFromHistory derives fees from eth_feeHistory over the last blocks: the tip is the
+// average of the given reward percentile, and the fee cap leaves room for the base fee to double.
+func PercentileFromHistory(backend Backend, blocks uint64, percentile float64) GasOracle {
+	return &percentileFromHistory{
+		backend:    backend,
+		blocks:     blocks,
+		percentile: percentile,
+	}
+}
+
+func (o *percentileFromHistory) FeeAndTip(ctx context.Context) (*big.Int, *big.Int, error) {
+	if o.blocks == 0 {
+		return nil, nil, errors.New("fee history needs at least one block")
+	}
+	if o.percentile < 0 || o.percentile > 100 {
+		return nil, nil, fmt.Errorf("percentile %v is not in [0, 100]", o.percentile)
+	}
+
+	history, err := o.backend.FeeHistory(ctx, o.blocks, nil, []float64{o.percentile})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get fee history: %w", err)
+	}
+	if history == nil || len(history.BaseFee) == 0 || len(history.Reward) == 0 {
+		return nil, nil, ErrNoFeeHistory
+	}
+
+	gasTipCap := new(big.Int)
+	for _, rewards := range history.Reward {
+		if len(rewards) == 0 {
+			return nil, nil, fmt.Errorf("%w: block without rewards", ErrNoFeeHistory)
+		}
+		gasTipCap.Add(gasTipCap, rewards[0])
+	}
+	gasTipCap.Div(gasTipCap, big.NewInt(int64(len(history.Reward))))
+
+	// the last base fee is the one of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), gasTipCap)
+
+	return gasFeeCap, gasTipCap, nil
+}
+
+type fixed struct {
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+}
+
+// Fixed always returns the given fee cap and tip.
+func Fixed(gasFeeCap, gasTipCap *big.Int) GasOracle {
+	return &fixed{
+		gasFeeCap: gasFeeCap,
+		gasTipCap: gasTipCap,
+	}
+}
+
+func (o *fixed) FeeAndTip(context.Context) (*big.Int, *big.Int, error) {
+	return new(big.Int).Set(o.gasFeeCap), new(big.Int).Set(o.gasTipCap), nil
+}
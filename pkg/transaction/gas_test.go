@@ -0,0 +1,219 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+func TestNodeSuggested(t *testing.T) {
+	oracle := transaction.NodeSuggested(backendMock.New(
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(20e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(2e9), nil
+		}),
+	))
+
+	gasFeeCap, gasTipCap, err := oracle.FeeAndTip(t.Context())
+	if err != nil {
+		t.Fatalf("unable to get fees: %v", err)
+	}
+	if gasFeeCap.Cmp(big.NewInt(22e9)) != 0 || gasTipCap.Cmp(big.NewInt(2e9)) != 0 {
+		t.Fatalf("got fee cap %s and tip %s, want 22 gwei and 2 gwei", gasFeeCap, gasTipCap)
+	}
+}
+
+func TestPercentileFromHistory(t *testing.T) {
+	const (
+		blocks     = 3
+		percentile = 60
+	)
+
+	oracle := transaction.PercentileFromHistory(backendMock.New(
+		backendMock.WithFeeHistoryFunc(func(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+			if blockCount != blocks || lastBlock != nil {
+				t.Fatalf("fee history requested for %d blocks up to %v, want %d up to latest", blockCount, lastBlock, blocks)
+			}
+			if len(rewardPercentiles) != 1 || rewardPercentiles[0] != percentile {
+				t.Fatalf("fee history requested for percentiles %v, want [%d]", rewardPercentiles, percentile)
+			}
+			return &ethereum.FeeHistory{
+				OldestBlock: big.NewInt(100),
+				Reward: [][]*big.Int{
+					{big.NewInt(1e9)},
+					{big.NewInt(2e9)},
+					{big.NewInt(3e9)},
+				},
+				BaseFee:      []*big.Int{big.NewInt(8e9), big.NewInt(9e9), big.NewInt(10e9), big.NewInt(11e9)},
+				GasUsedRatio: []float64{0.4, 0.5, 0.6},
+			}, nil
+		}),
+	), blocks, percentile)
+
+	gasFeeCap, gasTipCap, err := oracle.FeeAndTip(t.Context())
+	if err != nil {
+		t.Fatalf("unable to get fees: %v", err)
+	}
+	if want := big.NewInt(2e9); gasTipCap.Cmp(want) != 0 {
+		t.Fatalf("tip %s, want %s", gasTipCap, want)
+	}
+	if want := big.NewInt(2*11e9 + 2e9); gasFeeCap.Cmp(want) != 0 {
+		t.Fatalf("fee cap %s, want %s", gasFeeCap, want)
+	}
+
+	empty := transaction.PercentileFromHistory(backendMock.New(
+		backendMock.WithFeeHistoryFunc(func(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+			return &ethereum.FeeHistory{}, nil
+		}),
+	), blocks, percentile)
+	if _, _, err := empty.FeeAndTip(t.Context()); !errors.Is(err, transaction.ErrNoFeeHistory) {
+		t.Fatalf("expected %v, got %v", transaction.ErrNoFeeHistory, err)
+	}
+
+	if _, _, err := transaction.PercentileFromHistory(backendMock.New(), blocks, 101).FeeAndTip(t.Context()); err == nil {
+		t.Fatal("expected an error for an out of range percentile")
+	}
+}
+
+func TestFixedGasOracle(t *testing.T) {
+	gasFeeCap, gasTipCap := big.NewInt(30e9), big.NewInt(3e9)
+	var sent *types.Transaction
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+			return 0, nil
+		}),
+		backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+			return 21000, nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			sent = tx
+			return nil
+		}),
+	), transaction.WithGasOracle(transaction.Fixed(gasFeeCap, gasTipCap)))
+
+	if _, err := service.Send(t.Context(), &transaction.TxRequest{To: &to, Value: big.NewInt(1)}); err != nil {
+		t.Fatalf("unable to send: %v", err)
+	}
+	if sent.GasFeeCap().Cmp(gasFeeCap) != 0 || sent.GasTipCap().Cmp(gasTipCap) != 0 {
+		t.Fatalf("sent with fee cap %s and tip %s, want %s and %s", sent.GasFeeCap(), sent.GasTipCap(), gasFeeCap, gasTipCap)
+	}
+
+	// fees set on the request take precedence over the oracle
+	requestTip := big.NewInt(5e9)
+	if _, err := service.Send(t.Context(), &transaction.TxRequest{To: &to, GasFeeCap: gasFeeCap, GasTipCap: requestTip}); err != nil {
+		t.Fatalf("unable to send: %v", err)
+	}
+	if sent.GasTipCap().Cmp(requestTip) != 0 {
+		t.Fatalf("sent with tip %s, want %s", sent.GasTipCap(), requestTip)
+	}
+}
+
+func TestSendGasBuffer(t *testing.T) {
+	const estimated = 100000
+	percent := func(p int) *int { return &p }
+
+	cases := []struct {
+		name    string
+		opts    []transaction.Option
+		request transaction.TxRequest
+		want    uint64
+		err     error
+	}{
+		{name: "default", want: 125000},
+		{name: "service buffer", opts: []transaction.Option{transaction.WithGasBufferPercent(20)}, want: 120000},
+		{
+			name:    "request buffer",
+			opts:    []transaction.Option{transaction.WithGasBufferPercent(20)},
+			request: transaction.TxRequest{GasBufferPercent: percent(50)},
+			want:    150000,
+		},
+		{
+			name:    "no request buffer",
+			opts:    []transaction.Option{transaction.WithGasBufferPercent(20)},
+			request: transaction.TxRequest{GasBufferPercent: percent(0)},
+			want:    estimated,
+		},
+		{
+			name:    "negative request buffer",
+			request: transaction.TxRequest{GasBufferPercent: percent(-10)},
+			err:     transaction.ErrInvalidGasBuffer,
+		},
+		{
+			name: "clamped to ceiling",
+			opts: []transaction.Option{transaction.WithGasBufferPercent(20), transaction.WithGasLimitCeiling(110000)},
+			want: 110000,
+		},
+		{
+			name:    "minimum above buffer",
+			request: transaction.TxRequest{MinEstimatedGasLimit: 200000},
+			want:    200000,
+		},
+		{
+			name: "estimate above ceiling",
+			opts: []transaction.Option{transaction.WithGasLimitCeiling(90000)},
+			err:  transaction.ErrGasLimitCeiling,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var broadcast *types.Transaction
+			service, _ := newTestService(t, backendMock.New(
+				backendMock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+					return 0, nil
+				}),
+				backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+					return big.NewInt(1), nil
+				}),
+				backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+					return estimated, nil
+				}),
+				backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+					return big.NewInt(10e9), nil
+				}),
+				backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+					return big.NewInt(1e9), nil
+				}),
+				backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+					broadcast = tx
+					return nil
+				}),
+			), tc.opts...)
+
+			to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+			request := tc.request
+			request.To = &to
+
+			_, err := service.Send(t.Context(), &request)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				if broadcast != nil {
+					t.Fatal("transaction broadcast despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to send: %v", err)
+			}
+			if broadcast.Gas() != tc.want {
+				t.Fatalf("gas limit %d, want %d", broadcast.Gas(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// IdempotencyStore remembers the hash of the transaction broadcast for each idempotency key.
+// Implementations backed by persistent storage let idempotency survive restarts.
+type IdempotencyStore interface {
+	// Get returns the hash recorded for key and whether there is one.
+	Get(key string) (txHash common.Hash, ok bool, err error)
+	// Put records txHash for key.
+	Put(key string, txHash common.Hash) error
+}
+
+// memoryIdempotencyStore is an IdempotencyStore kept in memory.
+type memoryIdempotencyStore struct {
+	mu     sync.Mutex
+	hashes map[string]common.Hash
+}
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore kept in memory. It is the default store.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{hashes: make(map[string]common.Hash)}
+}
+
+func (s *memoryIdempotencyStore) Get(key string) (common.Hash, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txHash, ok := s.hashes[key]
+	return txHash, ok, nil
+}
+
+func (s *memoryIdempotencyStore) Put(key string, txHash common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hashes[key] = txHash
+	return nil
+}
+
+// SendIdempotent sends the request unless a transaction was already broadcast for idempotencyKey,
+// in which case the hash of that transaction is returned instead. Idempotent sends are serialized
+// so that concurrent retries with the same key cannot both broadcast.
+func (t *TxService) SendIdempotent(ctx context.Context, request *TxRequest, idempotencyKey string) (common.Hash, error) {
+	if err := t.checkClosed(); err != nil {
+		return common.Hash{}, err
+	}
+
+	t.idempotencyLock.Lock()
+	defer t.idempotencyLock.Unlock()
+
+	txHash, ok, err := t.idempotency.Get(idempotencyKey)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to look up idempotency key: %w", err)
+	}
+	if ok {
+		return txHash, nil
+	}
+
+	txHash, err = t.Send(ctx, request)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := t.idempotency.Put(idempotencyKey, txHash); err != nil {
+		return common.Hash{}, fmt.Errorf("unable to record idempotency key for transaction %s: %w", txHash, err)
+	}
+
+	return txHash, nil
+}
@@ -0,0 +1,67 @@
+package transaction_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+func TestSendIdempotent(t *testing.T) {
+	var broadcasts []*types.Transaction
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+			return uint64(len(broadcasts)), nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+		backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+			return 21000, nil
+		}),
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			broadcasts = append(broadcasts, tx)
+			return nil
+		}),
+	))
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	request := func() *transaction.TxRequest {
+		return &transaction.TxRequest{To: &to, Value: big.NewInt(1)}
+	}
+
+	first, err := service.SendIdempotent(t.Context(), request(), "job-1")
+	if err != nil {
+		t.Fatalf("unable to send: %v", err)
+	}
+	second, err := service.SendIdempotent(t.Context(), request(), "job-1")
+	if err != nil {
+		t.Fatalf("unable to resend: %v", err)
+	}
+	if len(broadcasts) != 1 {
+		t.Fatalf("broadcast %d transactions, want 1", len(broadcasts))
+	}
+	if first != second || first != broadcasts[0].Hash() {
+		t.Fatalf("got hashes %s and %s, want both %s", first, second, broadcasts[0].Hash())
+	}
+
+	other, err := service.SendIdempotent(t.Context(), request(), "job-2")
+	if err != nil {
+		t.Fatalf("unable to send with another key: %v", err)
+	}
+	if len(broadcasts) != 2 || other == first {
+		t.Fatalf("a new key did not broadcast a new transaction")
+	}
+}
@@ -0,0 +1,267 @@
+package transaction
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// JournalStatus is the state of a journaled transaction.
+type JournalStatus string
+
+const (
+	// JournalPending denotes a transaction broadcast, or about to be, that has no receipt yet.
+	JournalPending JournalStatus = "pending"
+	// JournalConfirmed denotes a transaction mined successfully.
+	JournalConfirmed JournalStatus = "confirmed"
+	// JournalReverted denotes a transaction mined with a failed status.
+	JournalReverted JournalStatus = "reverted"
+	// JournalReplaced denotes a transaction that was never mined while another transaction used its nonce.
+	JournalReplaced JournalStatus = "replaced"
+)
+
+// JournalEntry is a line of the transaction journal. Entries are only ever appended, so the last
+// entry of a hash holds its current status.
+type JournalEntry struct {
+	From   common.Address `json:"from"`
+	Nonce  uint64         `json:"nonce"`
+	Hash   common.Hash    `json:"hash"`
+	Status JournalStatus  `json:"status"`
+}
+
+// journal appends entries to a file, one JSON object per line, opening it on the first write.
+// A nil journal records nothing.
+type journal struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	pending map[common.Hash]JournalEntry
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path, pending: make(map[common.Hash]JournalEntry)}
+}
+
+// append writes the entries and syncs the file, so that they survive a crash right after.
+func (j *journal) append(entries ...JournalEntry) error {
+	if j.file == nil {
+		f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("unable to open journal: %w", err)
+		}
+		j.file = f
+	}
+
+	var data []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("unable to marshal journal entry: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	if _, err := j.file.Write(data); err != nil {
+		return fmt.Errorf("unable to write journal: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync journal: %w", err)
+	}
+	return nil
+}
+
+// recordPending journals tx from sender as pending. It is called before broadcasting, so that a
+// crash in between leaves an entry behind for Recover to look up.
+func (j *journal) recordPending(sender common.Address, tx *types.Transaction) error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry := JournalEntry{From: sender, Nonce: tx.Nonce(), Hash: tx.Hash(), Status: JournalPending}
+	if err := j.append(entry); err != nil {
+		return err
+	}
+	j.pending[entry.Hash] = entry
+
+	return nil
+}
+
+// recordReceipt journals the outcome of a pending transaction.
+func (j *journal) recordReceipt(receipt *types.Receipt) error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.pending[receipt.TxHash]
+	if !ok {
+		return nil
+	}
+	entry.Status = receiptStatus(receipt)
+	if err := j.append(entry); err != nil {
+		return err
+	}
+	delete(j.pending, receipt.TxHash)
+
+	return nil
+}
+
+// forget drops a pending entry whose transaction could not be broadcast. The entry stays in the
+// file and is reconciled by Recover like any other.
+func (j *journal) forget(txHash common.Hash) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	delete(j.pending, txHash)
+}
+
+func (j *journal) close() error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// receiptStatus returns the journal status of a mined transaction.
+func receiptStatus(receipt *types.Receipt) JournalStatus {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return JournalConfirmed
+	}
+	return JournalReverted
+}
+
+// ReadJournal reads the journal at path and returns the current entry of every transaction, in the
+// order they were first journaled.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	index := make(map[common.Hash]int)
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a crash in the middle of a write leaves a truncated last line behind
+			return nil, fmt.Errorf("unable to unmarshal journal line %d: %w", line, err)
+		}
+
+		if i, ok := index[e.Hash]; ok {
+			entries[i] = e
+			continue
+		}
+		index[e.Hash] = len(entries)
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read journal: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Recover reconciles the pending entries of the journal at journalPath, typically written by
+// WithJournal before a crash, against the chain: a transaction with a receipt is confirmed or
+// reverted, and one without a receipt is replaced once its sender's nonce moved past it. Other
+// transactions stay pending and may still be mined. The new statuses are appended to the journal
+// and the current entry of every journaled transaction is returned.
+func (t *TxService) Recover(ctx context.Context, journalPath string) ([]JournalEntry, error) {
+	if err := t.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	entries, err := ReadJournal(journalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var reconciled []JournalEntry
+	for i, e := range entries {
+		if e.Status != JournalPending {
+			continue
+		}
+
+		status, err := t.reconcile(ctx, e)
+		if err != nil {
+			return nil, fmt.Errorf("unable to reconcile transaction %s: %w", e.Hash, err)
+		}
+		if status != JournalPending {
+			entries[i].Status = status
+			reconciled = append(reconciled, entries[i])
+		}
+	}
+
+	if len(reconciled) > 0 {
+		j := t.journal
+		if j == nil || j.path != journalPath {
+			j = newJournal(journalPath)
+			defer j.close()
+		}
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if err := j.append(reconciled...); err != nil {
+			return nil, err
+		}
+		for _, e := range reconciled {
+			delete(j.pending, e.Hash)
+		}
+	}
+
+	return entries, nil
+}
+
+// reconcile looks up the status of a pending journal entry on chain.
+func (t *TxService) reconcile(ctx context.Context, e JournalEntry) (JournalStatus, error) {
+	receipt, err := t.receipt(ctx, e.Hash)
+	if err == nil {
+		return receiptStatus(receipt), nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return "", err
+	}
+
+	nonce, err := t.backend.NonceAt(ctx, e.From, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to get nonce of %s: %w", e.From, err)
+	}
+	if nonce > e.Nonce {
+		return JournalReplaced, nil
+	}
+
+	return JournalPending, nil
+}
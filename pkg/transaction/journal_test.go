@@ -0,0 +1,146 @@
+package transaction_test
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+func TestRecover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	var (
+		mu         sync.Mutex
+		broadcasts []*types.Transaction
+	)
+	sent := func(i int) common.Hash {
+		mu.Lock()
+		defer mu.Unlock()
+		return broadcasts[i].Hash()
+	}
+
+	// the first transaction is mined before the crash, the others are still in flight
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return uint64(len(broadcasts)), nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+		backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+			return 21000, nil
+		}),
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			broadcasts = append(broadcasts, tx)
+			return nil
+		}),
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+			if txHash == sent(0) {
+				return &types.Receipt{TxHash: txHash, Status: types.ReceiptStatusSuccessful}, nil
+			}
+			return nil, ethereum.NotFound
+		}),
+	), transaction.WithJournal(path), transaction.WithPollInterval(10*time.Millisecond))
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	for i := 0; i < 4; i++ {
+		if _, err := service.Send(t.Context(), &transaction.TxRequest{To: &to, Value: big.NewInt(1)}); err != nil {
+			t.Fatalf("unable to send: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, err := transaction.ReadJournal(path)
+		if err != nil {
+			t.Fatalf("unable to read journal: %v", err)
+		}
+		if len(entries) == 4 && entries[0].Status == transaction.JournalConfirmed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("receipt of the first transaction not journaled, got %+v", entries)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// crash: the remaining transactions are abandoned without an outcome
+	_ = service.Close()
+
+	// after the restart, the second transaction was mined and reverted, the third never was but
+	// its nonce is used and the fourth is still waiting
+	var lookups []common.Hash
+	restarted, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+			lookups = append(lookups, txHash)
+			if txHash == sent(1) {
+				return &types.Receipt{TxHash: txHash, Status: types.ReceiptStatusFailed}, nil
+			}
+			return nil, ethereum.NotFound
+		}),
+		backendMock.WithNonceAtFunc(func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+			return 3, nil
+		}),
+	))
+
+	want := []transaction.JournalStatus{
+		transaction.JournalConfirmed,
+		transaction.JournalReverted,
+		transaction.JournalReplaced,
+		transaction.JournalPending,
+	}
+	check := func(entries []transaction.JournalEntry) {
+		t.Helper()
+		if len(entries) != len(want) {
+			t.Fatalf("got %d entries, want %d", len(entries), len(want))
+		}
+		for i, e := range entries {
+			if e.Hash != sent(i) || e.Nonce != uint64(i) || e.Status != want[i] {
+				t.Fatalf("entry %d: got %+v, want hash %s, nonce %d and status %s", i, e, sent(i), i, want[i])
+			}
+		}
+	}
+
+	entries, err := restarted.Recover(t.Context(), path)
+	if err != nil {
+		t.Fatalf("unable to recover: %v", err)
+	}
+	check(entries)
+	if len(lookups) != 3 {
+		t.Fatalf("looked up %d receipts, want the 3 pending transactions", len(lookups))
+	}
+
+	// the outcomes were journaled, only the pending transaction is looked up again
+	persisted, err := transaction.ReadJournal(path)
+	if err != nil {
+		t.Fatalf("unable to read journal: %v", err)
+	}
+	check(persisted)
+
+	lookups = nil
+	if _, err := restarted.Recover(t.Context(), path); err != nil {
+		t.Fatalf("unable to recover again: %v", err)
+	}
+	if len(lookups) != 1 || lookups[0] != sent(3) {
+		t.Fatalf("looked up %v, want only %s", lookups, sent(3))
+	}
+}
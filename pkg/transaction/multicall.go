@@ -0,0 +1,76 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Multicall3Address is the address Multicall3 is deployed at on most EVM chains.
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+const multicall3ABIJSON = `[{"type":"function","name":"aggregate3","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],"outputs":[{"name":"returnData","type":"tuple[]","components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`
+
+// multicall3ABI is the subset of the Multicall3 ABI used by MulticallAggregate.
+var multicall3ABI = mustParseABI(multicall3ABIJSON)
+
+// ErrMulticallResults denotes a multicall response that does not hold one result per call.
+var ErrMulticallResults = errors.New("unexpected number of multicall results")
+
+// Call3 is a single call of a Multicall3 aggregate3 batch.
+type Call3 struct {
+	Target       common.Address // contract to call
+	AllowFailure bool           // whether the batch may continue if this call reverts
+	CallData     []byte         // calldata of the call
+}
+
+// Result is the outcome of a single call of a batch.
+type Result struct {
+	Success    bool   // whether the call succeeded
+	ReturnData []byte // return data, or revert data if the call failed
+}
+
+// WithMulticallAddress sets the address of the Multicall3 contract. Defaults to Multicall3Address.
+func WithMulticallAddress(address common.Address) Option {
+	return optionFunc(func(t *TxService) {
+		t.multicall = address
+	})
+}
+
+// MulticallAggregate executes the read-only calls in a single eth_call through Multicall3's
+// aggregate3 and returns their results in order.
+func (t *TxService) MulticallAggregate(ctx context.Context, calls []Call3) ([]Result, error) {
+	if err := t.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	data, err := multicall3ABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pack multicall: %w", err)
+	}
+
+	output, err := t.backend.CallContract(ctx, ethereum.CallMsg{
+		From: t.sender,
+		To:   &t.multicall,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call multicall: %w", err)
+	}
+
+	unpacked, err := multicall3ABI.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unpack multicall results: %w", err)
+	}
+
+	results := *abi.ConvertType(unpacked[0], new([]Result)).(*[]Result)
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("%w: %d calls, %d results", ErrMulticallResults, len(calls), len(results))
+	}
+
+	return results, nil
+}
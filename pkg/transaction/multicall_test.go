@@ -0,0 +1,87 @@
+package transaction_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+const aggregate3ABI = `[{"type":"function","name":"aggregate3","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],"outputs":[{"name":"returnData","type":"tuple[]","components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`
+
+func TestMulticallAggregate(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(aggregate3ABI))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %v", err)
+	}
+
+	calls := []transaction.Call3{
+		{Target: common.HexToAddress("0x01"), CallData: []byte{0x70, 0xa0, 0x82, 0x31}},
+		{Target: common.HexToAddress("0x02"), AllowFailure: true, CallData: []byte{0x18, 0x16, 0x0d, 0xdd}},
+	}
+	want := []transaction.Result{
+		{Success: true, ReturnData: common.LeftPadBytes([]byte{42}, 32)},
+		{Success: false, ReturnData: []byte{0x08, 0xc3, 0x79, 0xa0}},
+	}
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithCallContractFunc(func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+			if call.To == nil || *call.To != transaction.Multicall3Address {
+				t.Fatalf("call sent to %v, want %s", call.To, transaction.Multicall3Address)
+			}
+
+			args, err := parsed.Methods["aggregate3"].Inputs.Unpack(call.Data[4:])
+			if err != nil {
+				t.Fatalf("unable to unpack calls: %v", err)
+			}
+			var got []transaction.Call3
+			if err := parsed.Methods["aggregate3"].Inputs.Copy(&got, args); err != nil {
+				t.Fatalf("unable to decode calls: %v", err)
+			}
+			if len(got) != len(calls) || got[1].Target != calls[1].Target || !got[1].AllowFailure || !bytes.Equal(got[1].CallData, calls[1].CallData) {
+				t.Fatalf("encoded calls %+v, want %+v", got, calls)
+			}
+
+			return parsed.Methods["aggregate3"].Outputs.Pack(want)
+		}),
+	))
+
+	results, err := service.MulticallAggregate(t.Context(), calls)
+	if err != nil {
+		t.Fatalf("unable to aggregate calls: %v", err)
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if results[i].Success != want[i].Success || !bytes.Equal(results[i].ReturnData, want[i].ReturnData) {
+			t.Fatalf("result %d is %+v, want %+v", i, results[i], want[i])
+		}
+	}
+}
+
+func TestMulticallAggregateResultCount(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(aggregate3ABI))
+	if err != nil {
+		t.Fatalf("unable to parse abi: %v", err)
+	}
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithCallContractFunc(func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+			return parsed.Methods["aggregate3"].Outputs.Pack([]transaction.Result{})
+		}),
+	))
+
+	_, err = service.MulticallAggregate(t.Context(), []transaction.Call3{{Target: common.HexToAddress("0x01")}})
+	if !errors.Is(err, transaction.ErrMulticallResults) {
+		t.Fatalf("expected %v, got %v", transaction.ErrMulticallResults, err)
+	}
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// nonceGapFeeMultiplier is applied to the suggested fee and tip of gap filling transactions, so
+// they replace whatever is stuck at the nonce and get mined quickly.
+const nonceGapFeeMultiplier = 2
+
+// ErrForeignAccount denotes an operation that requires signing for an account other than the sender.
+var ErrForeignAccount = errors.New("account is not the sender of this service")
+
+// DetectNonceGap compares the pending and latest nonce of account. When pending transactions are
+// not being mined, stuckNonce is the lowest nonce that blocks them.
+func (t *TxService) DetectNonceGap(ctx context.Context, account common.Address) (hasGap bool, stuckNonce uint64, err error) {
+	if err := t.checkClosed(); err != nil {
+		return false, 0, err
+	}
+
+	pending, err := t.backend.PendingNonceAt(ctx, account)
+	if err != nil {
+		return false, 0, fmt.Errorf("unable to get pending nonce: %w", err)
+	}
+
+	latest, err := t.backend.NonceAt(ctx, account, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("unable to get latest nonce: %w", err)
+	}
+
+	if pending <= latest {
+		return false, 0, nil
+	}
+
+	return true, latest, nil
+}
+
+// FillNonceGap sends a zero-value self-transfer at nonce with a high fee, replacing the transaction
+// stuck there and unblocking the following ones. Only gaps of the sender can be filled.
+func (t *TxService) FillNonceGap(ctx context.Context, account common.Address, nonce uint64) (common.Hash, error) {
+	if err := t.checkClosed(); err != nil {
+		return common.Hash{}, err
+	}
+
+	if account != t.sender {
+		return common.Hash{}, fmt.Errorf("%w: %s", ErrForeignAccount, account)
+	}
+
+	gasFeeCap, gasTipCap, err := t.SuggestedFeeAndTip(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to suggest fees: %w", err)
+	}
+	gasFeeCap.Mul(gasFeeCap, big.NewInt(nonceGapFeeMultiplier))
+	gasTipCap.Mul(gasTipCap, big.NewInt(nonceGapFeeMultiplier))
+
+	chainID, err := t.getChainID(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	signedTx, err := t.signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		ChainID:   chainID,
+		To:        &t.sender,
+		Value:     big.NewInt(0),
+		Gas:       21000,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	}), chainID)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+
+	if err := t.journal.recordPending(t.sender, signedTx); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := t.backend.SendTransaction(ctx, signedTx); err != nil {
+		t.journal.forget(signedTx.Hash())
+		return common.Hash{}, fmt.Errorf("unable to send transaction: %w", err)
+	}
+
+	txHash := signedTx.Hash()
+
+	t.waitForPendingTx(txHash)
+
+	return txHash, nil
+}
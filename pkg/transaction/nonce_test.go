@@ -0,0 +1,150 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+func TestNonceGap(t *testing.T) {
+	const (
+		latestNonce  = 5
+		pendingNonce = 8
+	)
+	chainID := big.NewInt(1)
+	var sent *types.Transaction
+
+	service, s := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+			return pendingNonce, nil
+		}),
+		backendMock.WithNonceAtFunc(func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+			if blockNumber != nil {
+				t.Fatalf("nonce requested at block %s, want latest", blockNumber)
+			}
+			return latestNonce, nil
+		}),
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return chainID, nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			sent = tx
+			return nil
+		}),
+	))
+	account := crypto.PubkeyToAddress(*s.GetPublicKey())
+
+	hasGap, stuckNonce, err := service.DetectNonceGap(t.Context(), account)
+	if err != nil {
+		t.Fatalf("unable to detect nonce gap: %v", err)
+	}
+	if !hasGap || stuckNonce != latestNonce {
+		t.Fatalf("got gap %t at nonce %d, want gap at nonce %d", hasGap, stuckNonce, latestNonce)
+	}
+
+	txHash, err := service.FillNonceGap(t.Context(), account, stuckNonce)
+	if err != nil {
+		t.Fatalf("unable to fill nonce gap: %v", err)
+	}
+	if sent == nil || sent.Hash() != txHash {
+		t.Fatal("filler transaction was not sent")
+	}
+	if sent.Nonce() != latestNonce {
+		t.Fatalf("filler sent at nonce %d, want %d", sent.Nonce(), latestNonce)
+	}
+	if *sent.To() != account || sent.Value().Sign() != 0 {
+		t.Fatalf("filler is not a zero-value self-transfer")
+	}
+	if want := big.NewInt(2e9); sent.GasTipCap().Cmp(want) < 0 {
+		t.Fatalf("filler tip %s, want at least %s", sent.GasTipCap(), want)
+	}
+
+	sender, err := types.Sender(types.NewLondonSigner(chainID), sent)
+	if err != nil {
+		t.Fatalf("unable to recover sender: %v", err)
+	}
+	if sender != account {
+		t.Fatalf("filler signed by %s, want %s", sender, account)
+	}
+
+	other := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	if _, err := service.FillNonceGap(t.Context(), other, stuckNonce); !errors.Is(err, transaction.ErrForeignAccount) {
+		t.Fatalf("expected %v, got %v", transaction.ErrForeignAccount, err)
+	}
+}
+
+func TestFillNonceGapConcurrentChainID(t *testing.T) {
+	const fillers = 8
+	chainID := big.NewInt(1)
+	var (
+		chainIDCalls atomic.Int32
+		mu           sync.Mutex
+		sent         []*types.Transaction
+	)
+
+	service, s := newTestService(t, backendMock.New(
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			chainIDCalls.Add(1)
+			return chainID, nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			sent = append(sent, tx)
+			return nil
+		}),
+	))
+	account := crypto.PubkeyToAddress(*s.GetPublicKey())
+
+	// the chain id is resolved lazily by whichever call comes first
+	var wg sync.WaitGroup
+	errs := make(chan error, fillers)
+	for i := range fillers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.FillNonceGap(context.Background(), account, uint64(i))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unable to fill nonce gap: %v", err)
+		}
+	}
+	if n := chainIDCalls.Load(); n != 1 {
+		t.Fatalf("chain id queried %d times, want 1", n)
+	}
+	for _, tx := range sent {
+		if sender, err := types.Sender(types.NewLondonSigner(chainID), tx); err != nil || sender != account {
+			t.Fatalf("filler at nonce %d not signed by %s for chain %s: %v", tx.Nonce(), account, chainID, err)
+		}
+	}
+	if len(sent) != fillers {
+		t.Fatalf("sent %d fillers, want %d", len(sent), fillers)
+	}
+}
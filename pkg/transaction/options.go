@@ -0,0 +1,86 @@
+package transaction
+
+import "time"
+
+// Option is the option passed to the transaction service
+type Option interface {
+	apply(*TxService)
+}
+
+type optionFunc func(*TxService)
+
+func (f optionFunc) apply(t *TxService) { f(t) }
+
+// WithReceiptCache sets the size and time to live of the receipt cache. A size of 0 means unbounded
+// and a ttl of 0 means receipts never expire.
+func WithReceiptCache(size int, ttl time.Duration) Option {
+	return optionFunc(func(t *TxService) {
+		t.receipts = newReceiptCache(size, ttl)
+	})
+}
+
+// WithReceiptRecheck sets how long a cached receipt is returned before the block it was included in
+// is checked to still be canonical again. Defaults to 12 seconds, a slot; 0 checks on every lookup.
+func WithReceiptRecheck(d time.Duration) Option {
+	return optionFunc(func(t *TxService) {
+		t.receiptRecheck = d
+	})
+}
+
+// WithGasOracle sets the oracle deciding the fees of requests that leave them unset. Defaults to
+// NodeSuggested.
+func WithGasOracle(oracle GasOracle) Option {
+	return optionFunc(func(t *TxService) {
+		t.gasOracle = oracle
+	})
+}
+
+// WithIdempotencyStore sets the store remembering the transactions sent by SendIdempotent. Defaults to
+// an in-memory store.
+func WithIdempotencyStore(store IdempotencyStore) Option {
+	return optionFunc(func(t *TxService) {
+		t.idempotency = store
+	})
+}
+
+// WithPollInterval sets how often WaitForReceipt polls for a receipt that is not available yet.
+func WithPollInterval(d time.Duration) Option {
+	return optionFunc(func(t *TxService) {
+		t.pollInterval = d
+	})
+}
+
+// WithJournal appends every transaction the service broadcasts to the journal at path, as pending
+// before it is sent and with its outcome once it is mined, so that Recover can reconcile the
+// transactions in flight after a crash. The file is created if needed and never truncated.
+func WithJournal(path string) Option {
+	return optionFunc(func(t *TxService) {
+		t.journal = newJournal(path)
+	})
+}
+
+// WithGasBufferPercent sets the percentage added on top of estimated gas limits, for requests that
+// leave TxRequest.GasBufferPercent nil. Defaults to 25; 0 adds no buffer.
+func WithGasBufferPercent(percent int) Option {
+	return optionFunc(func(t *TxService) {
+		t.gasBufferPercent = percent
+	})
+}
+
+// WithGasLimitCeiling caps the buffered gas limit of estimated transactions. A ceiling of 0, the
+// default, means no cap.
+func WithGasLimitCeiling(limit uint64) Option {
+	return optionFunc(func(t *TxService) {
+		t.gasLimitCeiling = limit
+	})
+}
+
+// WithCancelBump sets how CancelTransaction prices its replacement: the suggested tip is bumped by
+// percent, and every attempt the node rejects as underpriced bumps both fees by percent again, up to
+// maxAttempts attempts in total. Defaults to 10 percent and 5 attempts.
+func WithCancelBump(percent, maxAttempts int) Option {
+	return optionFunc(func(t *TxService) {
+		t.cancelBumpPercent = percent
+		t.cancelAttempts = maxAttempts
+	})
+}
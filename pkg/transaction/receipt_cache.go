@@ -0,0 +1,169 @@
+package transaction
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	defaultReceiptCacheSize = 1024
+	defaultReceiptCacheTTL  = 10 * time.Minute
+	// defaultReceiptRecheck is how long a cached receipt is trusted to still be canonical: one slot,
+	// as a block is not replaced before the next one is produced.
+	defaultReceiptRecheck = 12 * time.Second
+)
+
+// CacheStats reports the activity of the receipt cache.
+type CacheStats struct {
+	Size          int    // number of receipts currently cached
+	Hits          uint64 // lookups answered from the cache
+	Misses        uint64 // lookups that had to fetch the receipt
+	Evictions     uint64 // receipts dropped because the cache was full or the entry expired
+	Invalidations uint64 // receipts dropped because their block was reorged out
+}
+
+type cachedReceipt struct {
+	txHash    common.Hash
+	receipt   *types.Receipt
+	storedAt  time.Time
+	checkedAt time.Time // last time the block of the receipt was known to be canonical
+}
+
+// receiptCache is an LRU cache of receipts keyed by transaction hash, with a time to live.
+type receiptCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	items    map[common.Hash]*list.Element
+	order    *list.List // front is the most recently used
+	stats    CacheStats
+}
+
+func newReceiptCache(capacity int, ttl time.Duration) *receiptCache {
+	return &receiptCache{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		items:    make(map[common.Hash]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the cached receipt of txHash if present and not expired, and whether its block was
+// last checked to be canonical more than recheck ago, see checked.
+func (c *receiptCache) get(txHash common.Hash, recheck time.Duration) (receipt *types.Receipt, stale, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[txHash]
+	if !ok {
+		c.stats.Misses++
+		return nil, false, false
+	}
+
+	entry := elem.Value.(*cachedReceipt)
+	now := c.now()
+	if c.ttl > 0 && now.Sub(entry.storedAt) > c.ttl {
+		c.remove(elem)
+		c.stats.Evictions++
+		c.stats.Misses++
+		return nil, false, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+	return entry.receipt, now.Sub(entry.checkedAt) >= recheck, true
+}
+
+// checked records that the block of the cached receipt of txHash was found to be canonical.
+func (c *receiptCache) checked(txHash common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[txHash]; ok {
+		elem.Value.(*cachedReceipt).checkedAt = c.now()
+	}
+}
+
+// add caches the receipt. Any cached receipt at the same height but from a different block
+// belongs to a block that has been reorged out and is invalidated.
+func (c *receiptCache) add(txHash common.Hash, receipt *types.Receipt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if receipt.BlockNumber != nil {
+		c.invalidateBlockLocked(receipt.BlockNumber.Uint64(), receipt.BlockHash)
+	}
+
+	if elem, ok := c.items[txHash]; ok {
+		entry := elem.Value.(*cachedReceipt)
+		entry.receipt = receipt
+		entry.storedAt = c.now()
+		entry.checkedAt = entry.storedAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	now := c.now()
+	c.items[txHash] = c.order.PushFront(&cachedReceipt{
+		txHash:    txHash,
+		receipt:   receipt,
+		storedAt:  now,
+		checkedAt: now, // a receipt just fetched is in the canonical chain
+	})
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// invalidate drops the cached receipt of txHash, if any.
+func (c *receiptCache) invalidate(txHash common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[txHash]; ok {
+		c.remove(elem)
+		c.stats.Invalidations++
+	}
+}
+
+// invalidateBlock drops every cached receipt at the given height that was not included in blockHash.
+func (c *receiptCache) invalidateBlock(number uint64, blockHash common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidateBlockLocked(number, blockHash)
+}
+
+func (c *receiptCache) invalidateBlockLocked(number uint64, blockHash common.Hash) {
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		receipt := elem.Value.(*cachedReceipt).receipt
+		if receipt.BlockNumber != nil && receipt.BlockNumber.Uint64() == number && receipt.BlockHash != blockHash {
+			c.remove(elem)
+			c.stats.Invalidations++
+		}
+		elem = next
+	}
+}
+
+func (c *receiptCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*cachedReceipt).txHash)
+}
+
+func (c *receiptCache) statistics() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
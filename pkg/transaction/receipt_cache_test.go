@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReceiptCacheEviction(t *testing.T) {
+	cache := newReceiptCache(2, 0)
+
+	a, b, c := common.HexToHash("0xa"), common.HexToHash("0xb"), common.HexToHash("0xc")
+	cache.add(a, &types.Receipt{TxHash: a})
+	cache.add(b, &types.Receipt{TxHash: b})
+
+	// touch a so b becomes the least recently used entry
+	if _, _, ok := cache.get(a, 0); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.add(c, &types.Receipt{TxHash: c})
+
+	if _, _, ok := cache.get(b, 0); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, _, ok := cache.get(a, 0); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	stats := cache.statistics()
+	if stats.Size != 2 || stats.Evictions != 1 || stats.Hits != 2 || stats.Misses != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestReceiptCacheTTL(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cache := newReceiptCache(0, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	hash := common.HexToHash("0x1")
+	cache.add(hash, &types.Receipt{TxHash: hash})
+
+	now = now.Add(30 * time.Second)
+	if _, _, ok := cache.get(hash, 0); !ok {
+		t.Fatal("expected receipt to be cached before the ttl")
+	}
+
+	now = now.Add(time.Minute)
+	if _, _, ok := cache.get(hash, 0); ok {
+		t.Fatal("expected receipt to expire after the ttl")
+	}
+}
+
+func TestReceiptCacheReorgInvalidation(t *testing.T) {
+	cache := newReceiptCache(0, 0)
+
+	old := common.HexToHash("0x1")
+	cache.add(old, &types.Receipt{TxHash: old, BlockNumber: big.NewInt(10), BlockHash: common.HexToHash("0xaa")})
+
+	// a receipt from a different block at the same height means 0xaa was reorged out
+	fresh := common.HexToHash("0x2")
+	cache.add(fresh, &types.Receipt{TxHash: fresh, BlockNumber: big.NewInt(10), BlockHash: common.HexToHash("0xbb")})
+
+	if _, _, ok := cache.get(old, 0); ok {
+		t.Fatal("expected receipt of the reorged block to be invalidated")
+	}
+	if _, _, ok := cache.get(fresh, 0); !ok {
+		t.Fatal("expected receipt of the canonical block to be cached")
+	}
+	if stats := cache.statistics(); stats.Invalidations != 1 {
+		t.Fatalf("invalidations %d, want 1", stats.Invalidations)
+	}
+}
+
+func TestReceiptCacheRecheck(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cache := newReceiptCache(0, 0)
+	cache.now = func() time.Time { return now }
+
+	hash := common.HexToHash("0x1")
+	cache.add(hash, &types.Receipt{TxHash: hash})
+
+	if _, stale, _ := cache.get(hash, time.Minute); stale {
+		t.Fatal("receipt just fetched needs to be checked")
+	}
+	now = now.Add(time.Minute)
+	if _, stale, _ := cache.get(hash, time.Minute); !stale {
+		t.Fatal("receipt not checked for the recheck interval is not stale")
+	}
+
+	cache.checked(hash)
+	now = now.Add(30 * time.Second)
+	if _, stale, _ := cache.get(hash, time.Minute); stale {
+		t.Fatal("receipt checked within the recheck interval is stale")
+	}
+	if _, stale, _ := cache.get(hash, 0); !stale {
+		t.Fatal("receipt is not stale without a recheck interval")
+	}
+}
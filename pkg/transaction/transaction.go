@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hblocks/keyless/pkg/signer"
 	"github.com/pkg/errors"
@@ -22,22 +26,44 @@ var (
 	ErrUnknownTransaction   = errors.New("unknown transaction")
 	ErrAlreadyImported      = errors.New("already imported")
 	ErrTransactionCancelled = errors.New("transaction cancelled")
+	// ErrReorged denotes that the block including the transaction is no longer part of the canonical chain.
+	ErrReorged = errors.New("transaction block reorged")
+	// ErrClosed denotes that the service has been closed.
+	ErrClosed = errors.New("transaction service closed")
+	// ErrTransactionMined denotes a transaction that can no longer be cancelled as it has been mined.
+	ErrTransactionMined = errors.New("transaction already mined")
+	// ErrGasLimitCeiling denotes an estimated gas limit above the ceiling set with WithGasLimitCeiling.
+	ErrGasLimitCeiling = errors.New("estimated gas above ceiling")
+	// ErrInvalidGasBuffer denotes a negative gas buffer percentage.
+	ErrInvalidGasBuffer = errors.New("invalid gas buffer")
+)
+
+const (
+	defaultPollInterval = time.Second
+
+	// defaultGasBufferPercent is added on top of estimated gas limits, see WithGasBufferPercent.
+	defaultGasBufferPercent = 25
+
+	// defaultCancelBumpPercent and defaultCancelAttempts configure CancelTransaction, see WithCancelBump.
+	defaultCancelBumpPercent = 10
+	defaultCancelAttempts    = 5
 )
 
 // TxRequest describes a request for a transaction that can be executed.
 type TxRequest struct {
-	To                   *common.Address // recipient of the transaction
-	Data                 []byte          // transaction data
-	GasPrice             *big.Int        // gas price or nil if suggested gas price should be used
-	GasLimit             uint64          // gas limit or 0 if it should be estimated
-	MinEstimatedGasLimit uint64          // minimum gas limit to use if the gas limit was estimated; it will not apply when this value is 0 or when GasLimit is not 0
-	GasFeeCap            *big.Int        // adds a cap to maximum fee user is willing to pay
-	Value                *big.Int        // amount of wei to send
-	Description          string          // optional description
-	GasTipBoost          int             // adds a tip for the miner for prioritizing transaction
-	GasTipCap            *big.Int        // adds a cap to the tip
-	Created              int64           // creation timestamp
-	isCapped             bool
+	To                   *common.Address  // recipient of the transaction
+	Data                 []byte           // transaction data
+	GasPrice             *big.Int         // gas price or nil if suggested gas price should be used
+	GasLimit             uint64           // gas limit or 0 if it should be estimated
+	MinEstimatedGasLimit uint64           // minimum gas limit to use if the gas limit was estimated; it will not apply when this value is 0 or when GasLimit is not 0
+	GasFeeCap            *big.Int         // adds a cap to maximum fee user is willing to pay
+	Value                *big.Int         // amount of wei to send
+	Description          string           // optional description
+	GasTipBoost          int              // adds a tip for the miner for prioritizing transaction
+	GasTipCap            *big.Int         // adds a cap to the tip
+	GasBufferPercent     *int             // percentage added to the estimated gas limit, 0 for none, or nil for the service default, see WithGasBufferPercent
+	AccessList           types.AccessList // EIP-2930 addresses and storage keys the transaction accesses, see CreateAccessList
+	Created              int64            // creation timestamp
 }
 
 // Service is the service to send transactions. It takes care of gas price, gas
@@ -48,15 +74,41 @@ type Service interface {
 	Send(ctx context.Context, request *TxRequest) (txHash common.Hash, err error)
 	// Call simulate a transaction based on the request.
 	Call(ctx context.Context, request *TxRequest) (result []byte, err error)
-	// WaitForReceipt waits until either the transaction with the given hash has been mined or the context is cancelled.
+	// WaitForReceipt waits until either the transaction with the given hash has been mined, the context is cancelled or the service is closed.
+	// It blocks for as long as the transaction is pending, polling for its receipt; callers that only want to
+	// look the receipt up should pass a context with a deadline.
 	// This is only valid for transaction sent by this service.
 	WaitForReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error)
-	// CancelTransaction cancels a previously sent transaction by double-spending its nonce with zero-transfer one
+	// CancelTransaction cancels a previously sent transaction by double-spending its nonce with zero-transfer one.
+	// The transaction must still be pending, otherwise ErrTransactionMined is returned.
 	CancelTransaction(ctx context.Context, originalTxHash common.Hash) (common.Hash, error)
-	// TransactionFee retrieves the transaction fee
+	// TransactionFee retrieves the fee paid by a mined transaction, i.e. the gas it used times its gas
+	// price. Unlike the Cost of the transaction, it includes neither the value nor unused gas.
 	TransactionFee(ctx context.Context, txHash common.Hash) (*big.Int, error)
 	// FilterLogs filters the events from contract
 	FilterLogs(ctx context.Context, query ethereum.FilterQuery) (*[]types.Log, error)
+	// SendBundle submits signed transactions to the configured bundle relay instead of the public mempool.
+	SendBundle(ctx context.Context, txs []*types.Transaction, targetBlock uint64) (bundleHash common.Hash, err error)
+	// ReceiptCacheStats reports hits, misses and evictions of the receipt cache.
+	ReceiptCacheStats() CacheStats
+	// DetectNonceGap reports whether account has pending transactions stuck behind a lower nonce.
+	DetectNonceGap(ctx context.Context, account common.Address) (hasGap bool, stuckNonce uint64, err error)
+	// FillNonceGap replaces the transaction at nonce with a high fee zero-value self-transfer.
+	FillNonceGap(ctx context.Context, account common.Address, nonce uint64) (common.Hash, error)
+	// MulticallAggregate executes read-only calls in a single round trip through Multicall3.
+	MulticallAggregate(ctx context.Context, calls []Call3) ([]Result, error)
+	// SignAndSend creates a transaction based on the request for the account of the given signer, signs it and sends it.
+	SignAndSend(ctx context.Context, s signer.Signer, request *TxRequest) (common.Hash, error)
+	// SendIdempotent sends the request at most once per idempotency key, returning the hash of the earlier
+	// transaction on repeated calls.
+	SendIdempotent(ctx context.Context, request *TxRequest, idempotencyKey string) (common.Hash, error)
+	// DeployContract deploys bytecode with the ABI encoded constructor arguments from the account of the
+	// given signer and waits until it is mined.
+	DeployContract(ctx context.Context, s signer.Signer, contractABI abi.ABI, bytecode []byte, constructorArgs ...interface{}) (common.Address, common.Hash, error)
+	// CreateAccessList asks the node for the access list of a request, along with the gas it uses with it.
+	CreateAccessList(ctx context.Context, request *TxRequest) (types.AccessList, uint64, error)
+	// Recover reconciles the pending transactions of a journal written by WithJournal against the chain.
+	Recover(ctx context.Context, journalPath string) ([]JournalEntry, error)
 }
 
 type TxService struct {
@@ -68,19 +120,55 @@ type TxService struct {
 	backend   WrappedBackend
 	signer    signer.Signer
 	sender    common.Address
-	chainID   *big.Int
 	rpcClient *rpc.Client
+	relay     *bundleRelay
+	receipts  *receiptCache
+	gasOracle GasOracle
+	multicall common.Address
+
+	pollInterval   time.Duration
+	receiptRecheck time.Duration
+
+	gasBufferPercent int
+	gasLimitCeiling  uint64
+
+	cancelBumpPercent int
+	cancelAttempts    int
+
+	idempotencyLock sync.Mutex
+	idempotency     IdempotencyStore
+
+	journal *journal
+
+	chainIDLock sync.Mutex
+	chainID     *big.Int
 }
 
-func NewTxService(logger *logrus.Logger, backend WrappedBackend, signer signer.Signer) (Service, error) {
+func NewTxService(logger *logrus.Logger, backend WrappedBackend, signer signer.Signer, opts ...Option) (Service, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	tx := &TxService{
-		wg:      sync.WaitGroup{},
-		lock:    sync.Mutex{},
-		ctx:     ctx,
-		cancel:  cancel,
-		backend: backend,
-		signer:  signer,
+		wg:           sync.WaitGroup{},
+		lock:         sync.Mutex{},
+		ctx:          ctx,
+		cancel:       cancel,
+		backend:      backend,
+		signer:       signer,
+		sender:       crypto.PubkeyToAddress(*signer.GetPublicKey()),
+		multicall:    Multicall3Address,
+		receipts:     newReceiptCache(defaultReceiptCacheSize, defaultReceiptCacheTTL),
+		idempotency:  NewMemoryIdempotencyStore(),
+		pollInterval: defaultPollInterval,
+
+		receiptRecheck: defaultReceiptRecheck,
+
+		gasBufferPercent: defaultGasBufferPercent,
+
+		cancelBumpPercent: defaultCancelBumpPercent,
+		cancelAttempts:    defaultCancelAttempts,
+	}
+	tx.gasOracle = NodeSuggested(&tx.backend)
+	for _, o := range opts {
+		o.apply(tx)
 	}
 	return tx, nil
 }
@@ -89,7 +177,7 @@ func (t *TxService) waitForPendingTx(txHash common.Hash) {
 	t.wg.Add(1)
 	go func() {
 		defer t.wg.Done()
-		_, err := t.WaitForReceipt(t.ctx, txHash)
+		receipt, err := t.WaitForReceipt(t.ctx, txHash)
 		if err != nil {
 			if !errors.Is(err, ErrTransactionCancelled) {
 				// TODO: add logger
@@ -100,12 +188,14 @@ func (t *TxService) waitForPendingTx(txHash common.Hash) {
 			}
 		} else {
 			// TODO: t.logger.Debug("pending transaction confirmed", "tx", txHash)
+			// a journal write failure leaves the entry pending, for Recover to reconcile
+			_ = t.journal.recordReceipt(receipt)
 		}
 	}()
 }
 
-func (t *TxService) nextNonce(ctx context.Context) (uint64, error) {
-	nonce, err := t.backend.PendingNonceAt(ctx, t.sender)
+func (t *TxService) nextNonce(ctx context.Context, account common.Address) (uint64, error) {
+	nonce, err := t.backend.PendingNonceAt(ctx, account)
 	if err != nil {
 		return 0, err
 	}
@@ -113,23 +203,43 @@ func (t *TxService) nextNonce(ctx context.Context) (uint64, error) {
 	return nonce, nil
 }
 
-func (t *TxService) nonceByBlock(ctx context.Context, sender common.Address, blockNum *big.Int) (uint64, error) {
-	return t.backend.NonceAt(ctx, sender, blockNum)
+// getChainID returns the chain id of the backend, querying it once and caching it afterwards. Send,
+// FillNonceGap and CancelTransaction may run concurrently, so the cache is guarded by chainIDLock;
+// a failed query is not cached and is retried by the next call.
+func (t *TxService) getChainID(ctx context.Context) (*big.Int, error) {
+	t.chainIDLock.Lock()
+	defer t.chainIDLock.Unlock()
+
+	if t.chainID != nil {
+		return t.chainID, nil
+	}
+
+	chainID, err := t.backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get chain id: %w", err)
+	}
+	t.chainID = chainID
+
+	return chainID, nil
 }
 
-// prepareTransaction creates a signable transaction based on a request.
-func (t *TxService) prepareTransaction(ctx context.Context, request *TxRequest, nonce uint64) (tx *types.Transaction, err error) {
+// prepareTransaction creates a signable transaction from sender based on a request.
+func (t *TxService) prepareTransaction(ctx context.Context, sender common.Address, request *TxRequest, nonce uint64, chainID *big.Int) (tx *types.Transaction, err error) {
 
 	gasLimit, err := t.backend.EstimateGas(ctx, ethereum.CallMsg{
-		From: t.sender,
-		To:   request.To,
-		Data: request.Data,
+		From:       sender,
+		To:         request.To,
+		Data:       request.Data,
+		AccessList: request.AccessList,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	gasLimit += gasLimit / 4 // add 25% on top
+	gasLimit, err = t.bufferGasLimit(gasLimit, request.GasBufferPercent)
+	if err != nil {
+		return nil, err
+	}
 	if gasLimit < request.MinEstimatedGasLimit {
 		gasLimit = request.MinEstimatedGasLimit
 	}
@@ -146,7 +256,7 @@ func (t *TxService) prepareTransaction(ctx context.Context, request *TxRequest,
 		notice that gas price does not exceed 20 as defined by max fee.
 	*/
 
-	if request.isCapped {
+	if request.GasFeeCap == nil || request.GasTipCap == nil {
 		gasFeeCap, gasTipCap, err := t.SuggestedFeeAndTip(ctx)
 		if err != nil {
 			return nil, err
@@ -160,40 +270,69 @@ func (t *TxService) prepareTransaction(ctx context.Context, request *TxRequest,
 			request.GasTipCap = gasTipCap
 		}
 	}
+	// dynamic fee transactions carry an access list as well, so there is no need for AccessListTx
 	return types.NewTx(&types.DynamicFeeTx{
-		Nonce:     nonce,
-		ChainID:   t.chainID,
-		To:        request.To,
-		Value:     request.Value,
-		Gas:       gasLimit,
-		GasFeeCap: request.GasFeeCap,
-		GasTipCap: request.GasTipCap,
-		Data:      request.Data,
+		Nonce:      nonce,
+		ChainID:    chainID,
+		To:         request.To,
+		Value:      request.Value,
+		Gas:        gasLimit,
+		GasFeeCap:  request.GasFeeCap,
+		GasTipCap:  request.GasTipCap,
+		Data:       request.Data,
+		AccessList: request.AccessList,
 	}), nil
 }
 
-func (t *TxService) SuggestedFeeAndTip(ctx context.Context) (*big.Int, *big.Int, error) {
-	var err error
+// bufferGasLimit adds requestPercent, or the service default if it is nil, to the estimated gas limit,
+// so that the transaction still fits if the state changes between estimation and inclusion. The
+// result is clamped to the ceiling of WithGasLimitCeiling; an estimate above the ceiling itself is
+// rejected with ErrGasLimitCeiling, as the transaction would run out of gas.
+func (t *TxService) bufferGasLimit(estimated uint64, requestPercent *int) (uint64, error) {
+	bufferPercent := t.gasBufferPercent
+	if requestPercent != nil {
+		bufferPercent = *requestPercent
+	}
+	if bufferPercent < 0 {
+		return 0, fmt.Errorf("%w: negative buffer of %d%%", ErrInvalidGasBuffer, bufferPercent)
+	}
 
-	gasPrice, err := t.backend.SuggestGasPrice(ctx)
-	if err != nil {
-		return nil, nil, err
+	if t.gasLimitCeiling != 0 && estimated > t.gasLimitCeiling {
+		return 0, fmt.Errorf("%w: estimated %d, ceiling %d", ErrGasLimitCeiling, estimated, t.gasLimitCeiling)
+	}
+
+	buffered := new(big.Int).SetUint64(estimated)
+	buffered.Mul(buffered, big.NewInt(int64(100+bufferPercent)))
+	buffered.Div(buffered, big.NewInt(100))
+
+	gasLimit := uint64(math.MaxUint64)
+	if buffered.IsUint64() {
+		gasLimit = buffered.Uint64()
+	}
+	if t.gasLimitCeiling != 0 {
+		gasLimit = min(gasLimit, t.gasLimitCeiling)
 	}
 
-	gasTipCap, err := t.backend.SuggestGasTipCap(ctx)
+	return gasLimit, nil
+}
+
+// SuggestedFeeAndTip returns the fee cap and tip decided by the configured gas oracle.
+func (t *TxService) SuggestedFeeAndTip(ctx context.Context) (*big.Int, *big.Int, error) {
+	gasFeeCap, gasTipCap, err := t.gasOracle.FeeAndTip(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	gasFeeCap := new(big.Int).Add(gasTipCap, gasPrice)
-
-	// TODO: t.logger.Debug("prepare transaction", "gas_price", gasPrice, "gas_max_fee", gasFeeCap, "gas_max_tip", gasTipCap)
+	// TODO: t.logger.Debug("prepare transaction", "gas_max_fee", gasFeeCap, "gas_max_tip", gasTipCap)
 
 	return gasFeeCap, gasTipCap, nil
-
 }
 
 func (t *TxService) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (*[]types.Log, error) {
+	if err := t.checkClosed(); err != nil {
+		return nil, err
+	}
+
 	filteredLogs, err := t.backend.FilterLogs(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("unable to filter logs: %w", err)
@@ -202,44 +341,107 @@ func (t *TxService) FilterLogs(ctx context.Context, query ethereum.FilterQuery)
 	return &filteredLogs, nil
 }
 
+// Close cancels the operations in flight, waits for the background goroutines to return and makes
+// every later call fail with ErrClosed.
 func (t *TxService) Close() error {
 	t.cancel()
 	t.wg.Wait()
+	return t.journal.close()
+}
+
+// checkClosed returns ErrClosed once the service has been closed.
+func (t *TxService) checkClosed() error {
+	if t.ctx.Err() != nil {
+		return ErrClosed
+	}
 	return nil
 }
 
+// serviceContext derives a context from ctx that is also cancelled when the service is closed.
+func (t *TxService) serviceContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(t.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
 func (t *TxService) Send(ctx context.Context, request *TxRequest) (txHash common.Hash, err error) {
+	if err := t.checkClosed(); err != nil {
+		return common.Hash{}, err
+	}
+
+	return t.signAndSend(ctx, t.signer, request)
+}
+
+// SignAndSend fills the nonce and fees of the request for the account of s, signs the transaction
+// with s and broadcasts it. The service's own signer is not involved.
+func (t *TxService) SignAndSend(ctx context.Context, s signer.Signer, request *TxRequest) (common.Hash, error) {
+	if err := t.checkClosed(); err != nil {
+		return common.Hash{}, err
+	}
+
+	return t.signAndSend(ctx, s, request)
+}
+
+// signAndSend prepares the request for the account of s, signs it with s and broadcasts it.
+func (t *TxService) signAndSend(ctx context.Context, s signer.Signer, request *TxRequest) (txHash common.Hash, err error) {
+	signedTx, err := t.signAndSendTx(ctx, s, request)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return signedTx.Hash(), nil
+}
+
+// signAndSendTx is signAndSend returning the broadcast transaction.
+func (t *TxService) signAndSendTx(ctx context.Context, s signer.Signer, request *TxRequest) (*types.Transaction, error) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
-	nonce, err := t.nextNonce(ctx)
+	sender := crypto.PubkeyToAddress(*s.GetPublicKey())
+
+	nonce, err := t.nextNonce(ctx, sender)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
-	tx, err := t.prepareTransaction(ctx, request, nonce)
+	chainID, err := t.getChainID(ctx)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
-	signedTx, err := t.signer.SignTx(tx, t.chainID)
+	tx, err := t.prepareTransaction(ctx, sender, request, nonce, chainID)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
-	err = t.backend.SendTransaction(ctx, signedTx)
+	signedTx, err := s.SignTx(tx, chainID)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
-	txHash = signedTx.Hash()
+	if err := t.journal.recordPending(sender, signedTx); err != nil {
+		return nil, err
+	}
 
-	t.waitForPendingTx(txHash)
+	err = t.backend.SendTransaction(ctx, signedTx)
+	if err != nil {
+		t.journal.forget(signedTx.Hash())
+		return nil, err
+	}
 
-	return signedTx.Hash(), nil
+	t.waitForPendingTx(signedTx.Hash())
+
+	return signedTx, nil
 }
 
 func (t *TxService) Call(ctx context.Context, request *TxRequest) (result []byte, err error) {
+	if err := t.checkClosed(); err != nil {
+		return nil, err
+	}
+
 	msg := ethereum.CallMsg{
 		From:     t.sender,
 		To:       request.To,
@@ -257,13 +459,105 @@ func (t *TxService) Call(ctx context.Context, request *TxRequest) (result []byte
 }
 
 func (t *TxService) WaitForReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
-	return t.backend.TransactionReceipt(ctx, txHash)
+	if err := t.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := t.serviceContext(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := t.receipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if t.ctx.Err() != nil {
+			return nil, ErrClosed
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if t.ctx.Err() != nil {
+				return nil, ErrClosed
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// receipt returns the receipt of txHash, from the cache if possible. A cached receipt is only
+// returned if its block is still canonical; otherwise it is dropped and ErrReorged is returned. The
+// block is looked up again at most once per receiptRecheck, in between the cached receipt is
+// returned as is.
+func (t *TxService) receipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if receipt, stale, ok := t.receipts.get(txHash, t.receiptRecheck); ok {
+		if !stale {
+			return receipt, nil
+		}
+		if err := t.checkCanonical(ctx, receipt); err != nil {
+			if errors.Is(err, ErrReorged) {
+				t.receipts.invalidate(txHash)
+			}
+			return nil, err
+		}
+		t.receipts.checked(txHash)
+		return receipt, nil
+	}
+
+	receipt, err := t.backend.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	t.receipts.add(txHash, receipt)
+
+	return receipt, nil
+}
+
+// checkCanonical returns ErrReorged if the block hash at the receipt's height changed since the receipt was fetched.
+func (t *TxService) checkCanonical(ctx context.Context, receipt *types.Receipt) error {
+	if receipt.BlockNumber == nil {
+		return nil
+	}
+
+	header, err := t.backend.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("unable to get header at block %s: %w", receipt.BlockNumber, err)
+	}
+
+	if blockHash := header.Hash(); blockHash != receipt.BlockHash {
+		t.receipts.invalidateBlock(receipt.BlockNumber.Uint64(), blockHash)
+		return fmt.Errorf("%w: block %s is now %s, receipt of %s was in %s",
+			ErrReorged, receipt.BlockNumber, blockHash, receipt.TxHash, receipt.BlockHash)
+	}
+
+	return nil
+}
+
+func (t *TxService) ReceiptCacheStats() CacheStats {
+	return t.receipts.statistics()
 }
 
 func (t *TxService) CancelTransaction(ctx context.Context, originalTxHash common.Hash) (common.Hash, error) {
-	receipt, err := t.WaitForReceipt(ctx, originalTxHash)
+	if err := t.checkClosed(); err != nil {
+		return common.Hash{}, err
+	}
+
+	// the nonce is taken from the pending transaction itself, waiting for its receipt would block until
+	// it is mined, when there is nothing left to cancel
+	originalTx, isPending, err := t.backend.TransactionByHash(ctx, originalTxHash)
 	if err != nil {
-		return common.Hash{}, fmt.Errorf("error getting the receipt from tx hash: %s with error: %w", originalTxHash, err)
+		return common.Hash{}, fmt.Errorf("unable to get transaction %s: %w", originalTxHash, err)
+	}
+	if !isPending {
+		return common.Hash{}, fmt.Errorf("%w: %s", ErrTransactionMined, originalTxHash)
 	}
 
 	gasFeeCap, gasTipCap, err := t.SuggestedFeeAndTip(ctx)
@@ -271,31 +565,59 @@ func (t *TxService) CancelTransaction(ctx context.Context, originalTxHash common
 		return common.Hash{}, err
 	}
 
-	gasTipCap = new(big.Int).Div(new(big.Int).Mul(big.NewInt(int64(10)+100), gasTipCap), big.NewInt(100))
+	gasTipCap = bumpFee(gasTipCap, t.cancelBumpPercent)
 
 	gasFeeCap.Add(gasFeeCap, gasTipCap)
 
-	nonce, err := t.nonceByBlock(ctx, t.sender, receipt.BlockNumber)
+	nonce := originalTx.Nonce()
+
+	chainID, err := t.getChainID(ctx)
 	if err != nil {
-		return [32]byte{}, fmt.Errorf("unable to get nonce by block: %w", err)
+		return common.Hash{}, err
 	}
 
+	// the node rejects a replacement that does not bump the fees of the pending transaction enough,
+	// so the fees are bumped again until it is accepted
+	for attempt := 1; ; attempt++ {
+		txHash, err := t.sendCancellation(nonce, chainID, gasFeeCap, gasTipCap)
+		if err == nil {
+			return txHash, nil
+		}
+		if !errors.Is(err, ErrReplacementUnderpriced) {
+			return common.Hash{}, err
+		}
+		if attempt >= t.cancelAttempts {
+			return common.Hash{}, fmt.Errorf("unable to cancel %s after %d attempts: %w", originalTxHash, attempt, err)
+		}
+
+		gasTipCap = bumpFee(gasTipCap, t.cancelBumpPercent)
+		gasFeeCap = bumpFee(gasFeeCap, t.cancelBumpPercent)
+	}
+}
+
+// sendCancellation signs and broadcasts a zero-value self-transfer at nonce with the given fees.
+func (t *TxService) sendCancellation(nonce uint64, chainID, gasFeeCap, gasTipCap *big.Int) (common.Hash, error) {
 	signedTx, err := t.signer.SignTx(types.NewTx(&types.DynamicFeeTx{
 		Nonce:     nonce,
-		ChainID:   t.chainID,
+		ChainID:   chainID,
 		To:        &t.sender,
 		Value:     big.NewInt(0),
 		Gas:       21000,
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
 		Data:      []byte{},
-	}), t.chainID)
+	}), chainID)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
+	if err := t.journal.recordPending(t.sender, signedTx); err != nil {
+		return common.Hash{}, err
+	}
+
 	err = t.backend.SendTransaction(t.ctx, signedTx)
 	if err != nil {
+		t.journal.forget(signedTx.Hash())
 		return common.Hash{}, err
 	}
 
@@ -303,13 +625,40 @@ func (t *TxService) CancelTransaction(ctx context.Context, originalTxHash common
 
 	t.waitForPendingTx(txHash)
 
-	return txHash, err
+	return txHash, nil
+}
+
+// bumpFee returns fee increased by percent, and by at least 1 wei so that repeated bumps of a small
+// fee still increase it.
+func bumpFee(fee *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+percent)))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
 }
 
+// TransactionFee returns the fee paid by a mined transaction, i.e. gas used times the effective gas
+// price. Nodes predating London return receipts without an effective gas price, for which the gas
+// price of the transaction is used instead.
 func (t *TxService) TransactionFee(ctx context.Context, txHash common.Hash) (*big.Int, error) {
-	trx, _, err := t.backend.TransactionByHash(ctx, txHash)
+	if err := t.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	receipt, err := t.receipt(ctx, txHash)
 	if err != nil {
 		return nil, err
 	}
-	return trx.Cost(), nil
+
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		trx, _, err := t.backend.TransactionByHash(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		gasPrice = trx.GasPrice()
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice), nil
 }
@@ -0,0 +1,381 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/signer"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/backendMock"
+)
+
+func newTestService(t *testing.T, backend transaction.Backend, opts ...transaction.Option) (transaction.Service, signer.Signer) {
+	t.Helper()
+
+	s, err := signer.New(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+
+	service, err := transaction.NewTxService(nil, *transaction.NewBackend(backend), s, opts...)
+	if err != nil {
+		t.Fatalf("unable to create service: %v", err)
+	}
+	t.Cleanup(func() { _ = service.Close() })
+
+	return service, s
+}
+
+func TestTransactionFeeCachesReceipt(t *testing.T) {
+	txHash := common.HexToHash("0x1234")
+	header := &types.Header{Number: big.NewInt(7)}
+	fetches, headers := 0, 0
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+			fetches++
+			return &types.Receipt{
+				TxHash:            hash,
+				Status:            types.ReceiptStatusSuccessful,
+				GasUsed:           21000,
+				EffectiveGasPrice: big.NewInt(3e9),
+				BlockNumber:       header.Number,
+				BlockHash:         header.Hash(),
+			}, nil
+		}),
+		backendMock.WithHeaderByNumberFunc(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			headers++
+			return header, nil
+		}),
+	))
+
+	want := big.NewInt(21000 * 3e9)
+	for i := 0; i < 2; i++ {
+		fee, err := service.TransactionFee(t.Context(), txHash)
+		if err != nil {
+			t.Fatalf("unable to get transaction fee: %v", err)
+		}
+		if fee.Cmp(want) != 0 {
+			t.Fatalf("fee %s, want %s", fee, want)
+		}
+	}
+
+	if fetches != 1 || headers != 0 {
+		t.Fatalf("receipt fetched %d times and checked %d times, want 1 and 0", fetches, headers)
+	}
+	if stats := service.ReceiptCacheStats(); stats.Hits != 1 || stats.Misses != 1 || stats.Size != 1 {
+		t.Fatalf("unexpected cache stats %+v", stats)
+	}
+}
+
+func TestTransactionFeeWithoutEffectiveGasPrice(t *testing.T) {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	tx := types.NewTx(&types.LegacyTx{To: &to, Gas: 50000, GasPrice: big.NewInt(2e9), Value: big.NewInt(1e18)})
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+			// receipts of nodes predating London carry no effective gas price
+			return &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful, GasUsed: 21000}, nil
+		}),
+		backendMock.WithTransactionByHashFunc(func(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+			return tx, false, nil
+		}),
+	))
+
+	fee, err := service.TransactionFee(t.Context(), tx.Hash())
+	if err != nil {
+		t.Fatalf("unable to get transaction fee: %v", err)
+	}
+	if want := big.NewInt(21000 * 2e9); fee.Cmp(want) != 0 {
+		t.Fatalf("fee %s, want %s", fee, want)
+	}
+}
+
+func TestWaitForReceiptReorged(t *testing.T) {
+	txHash := common.HexToHash("0x1234")
+	canonical := &types.Header{Number: big.NewInt(42), Extra: []byte("canonical")}
+	fetches, headers := 0, 0
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+			fetches++
+			return &types.Receipt{
+				TxHash:      hash,
+				Status:      types.ReceiptStatusSuccessful,
+				BlockNumber: canonical.Number,
+				BlockHash:   canonical.Hash(),
+			}, nil
+		}),
+		backendMock.WithHeaderByNumberFunc(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			if number.Cmp(canonical.Number) != 0 {
+				t.Fatalf("header requested at %s, want %s", number, canonical.Number)
+			}
+			headers++
+			return canonical, nil
+		}),
+	), transaction.WithReceiptRecheck(0))
+
+	receipt, err := service.WaitForReceipt(t.Context(), txHash)
+	if err != nil {
+		t.Fatalf("unable to get receipt: %v", err)
+	}
+	if _, err := service.WaitForReceipt(t.Context(), txHash); err != nil {
+		t.Fatalf("unable to get cached receipt: %v", err)
+	}
+	if headers != 1 {
+		t.Fatalf("cached receipt checked %d times, want 1", headers)
+	}
+
+	// the block at the receipt height is replaced
+	canonical = &types.Header{Number: big.NewInt(42), Extra: []byte("reorg")}
+
+	if _, err := service.WaitForReceipt(t.Context(), txHash); !errors.Is(err, transaction.ErrReorged) {
+		t.Fatalf("expected %v, got %v", transaction.ErrReorged, err)
+	}
+
+	// the stale receipt is gone, the next lookup fetches the new one
+	fresh, err := service.WaitForReceipt(t.Context(), txHash)
+	if err != nil {
+		t.Fatalf("unable to get receipt after reorg: %v", err)
+	}
+	if fresh.BlockHash == receipt.BlockHash || fetches != 2 {
+		t.Fatalf("expected a refetched receipt, got block %s after %d fetches", fresh.BlockHash, fetches)
+	}
+}
+
+func TestWaitForReceiptRecheck(t *testing.T) {
+	txHash := common.HexToHash("0x1234")
+	header := &types.Header{Number: big.NewInt(42)}
+	fetches, headers := 0, 0
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+			fetches++
+			return &types.Receipt{
+				TxHash:      hash,
+				Status:      types.ReceiptStatusSuccessful,
+				BlockNumber: header.Number,
+				BlockHash:   header.Hash(),
+			}, nil
+		}),
+		backendMock.WithHeaderByNumberFunc(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			headers++
+			return header, nil
+		}),
+	), transaction.WithReceiptRecheck(50*time.Millisecond))
+
+	lookup := func(wantFetches, wantHeaders int) {
+		t.Helper()
+
+		if _, err := service.WaitForReceipt(t.Context(), txHash); err != nil {
+			t.Fatalf("unable to get receipt: %v", err)
+		}
+		if fetches != wantFetches || headers != wantHeaders {
+			t.Fatalf("receipt fetched %d times and checked %d times, want %d and %d", fetches, headers, wantFetches, wantHeaders)
+		}
+	}
+
+	// hits within the recheck interval cost no RPC at all
+	lookup(1, 0)
+	lookup(1, 0)
+	lookup(1, 0)
+
+	// once the interval passed the block is checked once, then trusted again for an interval
+	time.Sleep(60 * time.Millisecond)
+	lookup(1, 1)
+	lookup(1, 1)
+}
+
+func TestCloseCancelsWaitForReceipt(t *testing.T) {
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionReceiptFunc(func(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+			return nil, ethereum.NotFound
+		}),
+	), transaction.WithPollInterval(10*time.Millisecond))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := service.WaitForReceipt(context.Background(), common.HexToHash("0x1234"))
+		done <- err
+	}()
+
+	// let the wait poll a few times before closing
+	time.Sleep(50 * time.Millisecond)
+	if err := service.Close(); err != nil {
+		t.Fatalf("unable to close: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, transaction.ErrClosed) {
+			t.Fatalf("expected %v, got %v", transaction.ErrClosed, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wait did not return after close")
+	}
+
+	if _, err := service.Send(t.Context(), &transaction.TxRequest{}); !errors.Is(err, transaction.ErrClosed) {
+		t.Fatalf("expected %v, got %v", transaction.ErrClosed, err)
+	}
+	if _, err := service.WaitForReceipt(t.Context(), common.HexToHash("0x1234")); !errors.Is(err, transaction.ErrClosed) {
+		t.Fatalf("expected %v, got %v", transaction.ErrClosed, err)
+	}
+}
+
+func TestSignAndSend(t *testing.T) {
+	other, err := signer.NewFromHex("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	account := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	chainID := big.NewInt(5)
+	var sent *types.Transaction
+
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithPendingNonceAtFunc(func(ctx context.Context, a common.Address) (uint64, error) {
+			if a != account {
+				t.Fatalf("nonce requested for %s, want %s", a, account)
+			}
+			return 3, nil
+		}),
+		backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+			return chainID, nil
+		}),
+		backendMock.WithEstimateGasFunc(func(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+			if call.From != account {
+				t.Fatalf("gas estimated from %s, want %s", call.From, account)
+			}
+			return 21000, nil
+		}),
+		backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(10e9), nil
+		}),
+		backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1e9), nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			sent = tx
+			return nil
+		}),
+	))
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	txHash, err := service.SignAndSend(t.Context(), other, &transaction.TxRequest{To: &to, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("unable to sign and send: %v", err)
+	}
+	if sent == nil || sent.Hash() != txHash {
+		t.Fatal("transaction was not broadcast")
+	}
+	if sent.Nonce() != 3 || *sent.To() != to || sent.ChainId().Cmp(chainID) != 0 {
+		t.Fatalf("broadcast transaction does not match the request")
+	}
+
+	sender, err := types.Sender(types.NewLondonSigner(chainID), sent)
+	if err != nil {
+		t.Fatalf("unable to recover sender: %v", err)
+	}
+	if sender != account {
+		t.Fatalf("transaction signed by %s, want %s", sender, account)
+	}
+}
+
+func TestCancelTransactionBumpsUnderpriced(t *testing.T) {
+	original := common.HexToHash("0x1234")
+
+	newBackend := func(rejections int, broadcasts *[]*types.Transaction) transaction.Backend {
+		return backendMock.New(
+			// the original transaction is pending: it has no receipt, waiting for one would block
+			backendMock.WithTransactionReceiptFunc(func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+				return nil, ethereum.NotFound
+			}),
+			backendMock.WithTransactionByHashFunc(func(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+				return types.NewTx(&types.DynamicFeeTx{Nonce: 3}), true, nil
+			}),
+			backendMock.WithChainIDFunc(func(ctx context.Context) (*big.Int, error) {
+				return big.NewInt(1), nil
+			}),
+			backendMock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+				return big.NewInt(10e9), nil
+			}),
+			backendMock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+				return big.NewInt(1e9), nil
+			}),
+			backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+				*broadcasts = append(*broadcasts, tx)
+				if len(*broadcasts) <= rejections {
+					return errors.New("replacement transaction underpriced")
+				}
+				return nil
+			}),
+		)
+	}
+
+	var broadcasts []*types.Transaction
+	service, _ := newTestService(t, newBackend(2, &broadcasts))
+
+	txHash, err := service.CancelTransaction(t.Context(), original)
+	if err != nil {
+		t.Fatalf("unable to cancel: %v", err)
+	}
+	if len(broadcasts) != 3 {
+		t.Fatalf("broadcast %d transactions, want 3", len(broadcasts))
+	}
+	if txHash != broadcasts[2].Hash() {
+		t.Fatalf("got hash %s, want the accepted %s", txHash, broadcasts[2].Hash())
+	}
+	for i, tx := range broadcasts {
+		if tx.Nonce() != 3 {
+			t.Fatalf("attempt %d has nonce %d, want 3", i, tx.Nonce())
+		}
+		if i == 0 {
+			continue
+		}
+		// every retry must bump both fees by at least 10% to replace the previous attempt
+		for _, fee := range []struct{ previous, current *big.Int }{
+			{broadcasts[i-1].GasTipCap(), tx.GasTipCap()},
+			{broadcasts[i-1].GasFeeCap(), tx.GasFeeCap()},
+		} {
+			minimum := new(big.Int).Div(new(big.Int).Mul(fee.previous, big.NewInt(110)), big.NewInt(100))
+			if fee.current.Cmp(minimum) < 0 {
+				t.Fatalf("attempt %d fee %s, want at least %s", i, fee.current, minimum)
+			}
+		}
+	}
+
+	broadcasts = nil
+	limited, _ := newTestService(t, newBackend(5, &broadcasts), transaction.WithCancelBump(10, 2))
+	if _, err := limited.CancelTransaction(t.Context(), original); !errors.Is(err, transaction.ErrReplacementUnderpriced) {
+		t.Fatalf("expected %v, got %v", transaction.ErrReplacementUnderpriced, err)
+	}
+	if len(broadcasts) != 2 {
+		t.Fatalf("broadcast %d transactions, want 2", len(broadcasts))
+	}
+}
+
+func TestCancelTransactionMined(t *testing.T) {
+	var broadcasts int
+	service, _ := newTestService(t, backendMock.New(
+		backendMock.WithTransactionByHashFunc(func(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+			return types.NewTx(&types.DynamicFeeTx{Nonce: 3}), false, nil
+		}),
+		backendMock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+			broadcasts++
+			return nil
+		}),
+	))
+
+	if _, err := service.CancelTransaction(t.Context(), common.HexToHash("0x1234")); !errors.Is(err, transaction.ErrTransactionMined) {
+		t.Fatalf("expected %v, got %v", transaction.ErrTransactionMined, err)
+	}
+	if broadcasts != 0 {
+		t.Fatalf("broadcast %d cancellations of a mined transaction", broadcasts)
+	}
+}
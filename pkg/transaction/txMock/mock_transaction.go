@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"math/rand/v2"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/signer"
 	"github.com/hblocks/keyless/pkg/transaction"
 	"github.com/pkg/errors"
 )
@@ -21,9 +24,43 @@ type transactionServiceMock struct {
 	cancelTransaction func(ctx context.Context, originalTxHash common.Hash) (common.Hash, error)
 	transactionFee    func(ctx context.Context, txHash common.Hash) (*big.Int, error)
 	filterLogs        func(ctx context.Context, query ethereum.FilterQuery) (*[]types.Log, error)
+	sendBundle        func(ctx context.Context, txs []*types.Transaction, targetBlock uint64) (common.Hash, error)
+	detectNonceGap    func(ctx context.Context, account common.Address) (bool, uint64, error)
+	fillNonceGap      func(ctx context.Context, account common.Address, nonce uint64) (common.Hash, error)
+	multicall         func(ctx context.Context, calls []transaction.Call3) ([]transaction.Result, error)
+	signAndSend       func(ctx context.Context, s signer.Signer, request *transaction.TxRequest) (common.Hash, error)
+	sendIdempotent    func(ctx context.Context, request *transaction.TxRequest, idempotencyKey string) (common.Hash, error)
+	deployContract    func(ctx context.Context, s signer.Signer, contractABI abi.ABI, bytecode []byte, constructorArgs ...interface{}) (common.Address, common.Hash, error)
+	createAccessList  func(ctx context.Context, request *transaction.TxRequest) (types.AccessList, uint64, error)
+	recover           func(ctx context.Context, journalPath string) ([]transaction.JournalEntry, error)
+	latency           time.Duration
+	failureRate       float64
+}
+
+// ErrInjectedFailure is returned by mocked methods failing because of WithFailureRate.
+var ErrInjectedFailure = errors.New("injected failure")
+
+// fault delays the call by the configured latency and fails it with the configured probability.
+func (m *transactionServiceMock) fault(ctx context.Context) error {
+	if m.latency > 0 {
+		timer := time.NewTimer(m.latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if m.failureRate > 0 && rand.Float64() < m.failureRate {
+		return ErrInjectedFailure
+	}
+	return nil
 }
 
 func (m *transactionServiceMock) Send(ctx context.Context, request *transaction.TxRequest) (txHash common.Hash, err error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Hash{}, err
+	}
 	if m.send != nil {
 		return m.send(ctx, request)
 	}
@@ -31,6 +68,9 @@ func (m *transactionServiceMock) Send(ctx context.Context, request *transaction.
 }
 
 func (m *transactionServiceMock) WaitForReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, err
+	}
 	if m.waitForReceipt != nil {
 		return m.waitForReceipt(ctx, txHash)
 	}
@@ -38,6 +78,9 @@ func (m *transactionServiceMock) WaitForReceipt(ctx context.Context, txHash comm
 }
 
 func (m *transactionServiceMock) Call(ctx context.Context, request *transaction.TxRequest) (result []byte, err error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, err
+	}
 	if m.call != nil {
 		return m.call(ctx, request)
 	}
@@ -45,6 +88,9 @@ func (m *transactionServiceMock) Call(ctx context.Context, request *transaction.
 }
 
 func (m *transactionServiceMock) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (*[]types.Log, error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, err
+	}
 	if m.filterLogs != nil {
 		return m.filterLogs(ctx, query)
 	}
@@ -52,18 +98,118 @@ func (m *transactionServiceMock) FilterLogs(ctx context.Context, query ethereum.
 }
 
 func (m *transactionServiceMock) CancelTransaction(ctx context.Context, originalTxHash common.Hash) (common.Hash, error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Hash{}, err
+	}
 	if m.cancelTransaction != nil {
 		return m.cancelTransaction(ctx, originalTxHash)
 	}
 	return common.Hash{}, errors.New("not implemented")
 }
 
+func (m *transactionServiceMock) SendBundle(ctx context.Context, txs []*types.Transaction, targetBlock uint64) (common.Hash, error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if m.sendBundle != nil {
+		return m.sendBundle(ctx, txs, targetBlock)
+	}
+	return common.Hash{}, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) DetectNonceGap(ctx context.Context, account common.Address) (bool, uint64, error) {
+	if err := m.fault(ctx); err != nil {
+		return false, 0, err
+	}
+	if m.detectNonceGap != nil {
+		return m.detectNonceGap(ctx, account)
+	}
+	return false, 0, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) FillNonceGap(ctx context.Context, account common.Address, nonce uint64) (common.Hash, error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if m.fillNonceGap != nil {
+		return m.fillNonceGap(ctx, account, nonce)
+	}
+	return common.Hash{}, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) MulticallAggregate(ctx context.Context, calls []transaction.Call3) ([]transaction.Result, error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, err
+	}
+	if m.multicall != nil {
+		return m.multicall(ctx, calls)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) SignAndSend(ctx context.Context, s signer.Signer, request *transaction.TxRequest) (common.Hash, error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if m.signAndSend != nil {
+		return m.signAndSend(ctx, s, request)
+	}
+	return common.Hash{}, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) SendIdempotent(ctx context.Context, request *transaction.TxRequest, idempotencyKey string) (common.Hash, error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if m.sendIdempotent != nil {
+		return m.sendIdempotent(ctx, request, idempotencyKey)
+	}
+	return common.Hash{}, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) DeployContract(ctx context.Context, s signer.Signer, contractABI abi.ABI, bytecode []byte, constructorArgs ...interface{}) (common.Address, common.Hash, error) {
+	if err := m.fault(ctx); err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+	if m.deployContract != nil {
+		return m.deployContract(ctx, s, contractABI, bytecode, constructorArgs...)
+	}
+	return common.Address{}, common.Hash{}, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) CreateAccessList(ctx context.Context, request *transaction.TxRequest) (types.AccessList, uint64, error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, 0, err
+	}
+	if m.createAccessList != nil {
+		return m.createAccessList(ctx, request)
+	}
+	return nil, 0, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) Recover(ctx context.Context, journalPath string) ([]transaction.JournalEntry, error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, err
+	}
+	if m.recover != nil {
+		return m.recover(ctx, journalPath)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) ReceiptCacheStats() transaction.CacheStats {
+	return transaction.CacheStats{}
+}
+
 func (m *transactionServiceMock) Close() error {
 	return nil
 }
 
 // TransactionFee returns fee of transaction
 func (m *transactionServiceMock) TransactionFee(ctx context.Context, txHash common.Hash) (*big.Int, error) {
+	if err := m.fault(ctx); err != nil {
+		return nil, err
+	}
 	if m.transactionFee != nil {
 		return m.transactionFee(ctx, txHash)
 	}
@@ -109,6 +255,74 @@ func WithTransactionFeeFunc(f func(ctx context.Context, txHash common.Hash) (*bi
 	})
 }
 
+func WithSendBundleFunc(f func(ctx context.Context, txs []*types.Transaction, targetBlock uint64) (common.Hash, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.sendBundle = f
+	})
+}
+
+func WithDetectNonceGapFunc(f func(ctx context.Context, account common.Address) (bool, uint64, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.detectNonceGap = f
+	})
+}
+
+func WithFillNonceGapFunc(f func(ctx context.Context, account common.Address, nonce uint64) (common.Hash, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.fillNonceGap = f
+	})
+}
+
+func WithMulticallAggregateFunc(f func(ctx context.Context, calls []transaction.Call3) ([]transaction.Result, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.multicall = f
+	})
+}
+
+func WithSignAndSendFunc(f func(ctx context.Context, s signer.Signer, request *transaction.TxRequest) (common.Hash, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.signAndSend = f
+	})
+}
+
+func WithSendIdempotentFunc(f func(ctx context.Context, request *transaction.TxRequest, idempotencyKey string) (common.Hash, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.sendIdempotent = f
+	})
+}
+
+func WithDeployContractFunc(f func(ctx context.Context, s signer.Signer, contractABI abi.ABI, bytecode []byte, constructorArgs ...interface{}) (common.Address, common.Hash, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.deployContract = f
+	})
+}
+
+func WithCreateAccessListFunc(f func(ctx context.Context, request *transaction.TxRequest) (types.AccessList, uint64, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.createAccessList = f
+	})
+}
+
+func WithRecoverFunc(f func(ctx context.Context, journalPath string) ([]transaction.JournalEntry, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.recover = f
+	})
+}
+
+// WithLatency delays every mocked call by d, or until its context is done.
+func WithLatency(d time.Duration) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.latency = d
+	})
+}
+
+// WithFailureRate makes every mocked call fail with ErrInjectedFailure with probability p.
+func WithFailureRate(p float64) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.failureRate = p
+	})
+}
+
 func New(opts ...Option) transaction.Service {
 	mock := new(transactionServiceMock)
 	for _, o := range opts {
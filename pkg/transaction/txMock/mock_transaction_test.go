@@ -0,0 +1,112 @@
+package txMock_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hblocks/keyless/pkg/transaction"
+	"github.com/hblocks/keyless/pkg/transaction/txMock"
+)
+
+func TestWithFailureRate(t *testing.T) {
+	service := txMock.New(
+		txMock.WithFailureRate(1),
+		txMock.WithSendFunc(func(ctx context.Context, request *transaction.TxRequest) (common.Hash, error) {
+			return common.HexToHash("0x01"), nil
+		}),
+		txMock.WithTransactionFeeFunc(func(ctx context.Context, txHash common.Hash) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+	)
+
+	ctx := t.Context()
+	calls := map[string]func() error{
+		"Send": func() error {
+			_, err := service.Send(ctx, &transaction.TxRequest{})
+			return err
+		},
+		"WaitForReceipt": func() error {
+			_, err := service.WaitForReceipt(ctx, common.Hash{})
+			return err
+		},
+		"Call": func() error {
+			_, err := service.Call(ctx, &transaction.TxRequest{})
+			return err
+		},
+		"FilterLogs": func() error {
+			_, err := service.FilterLogs(ctx, ethereum.FilterQuery{})
+			return err
+		},
+		"CancelTransaction": func() error {
+			_, err := service.CancelTransaction(ctx, common.Hash{})
+			return err
+		},
+		"TransactionFee": func() error {
+			_, err := service.TransactionFee(ctx, common.Hash{})
+			return err
+		},
+		"SendBundle": func() error {
+			_, err := service.SendBundle(ctx, []*types.Transaction{}, 1)
+			return err
+		},
+		"DetectNonceGap": func() error {
+			_, _, err := service.DetectNonceGap(ctx, common.Address{})
+			return err
+		},
+		"FillNonceGap": func() error {
+			_, err := service.FillNonceGap(ctx, common.Address{}, 0)
+			return err
+		},
+		"SignAndSend": func() error {
+			_, err := service.SignAndSend(ctx, nil, &transaction.TxRequest{})
+			return err
+		},
+		"SendIdempotent": func() error {
+			_, err := service.SendIdempotent(ctx, &transaction.TxRequest{}, "key")
+			return err
+		},
+		"MulticallAggregate": func() error {
+			_, err := service.MulticallAggregate(ctx, nil)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		for i := 0; i < 10; i++ {
+			if err := call(); !errors.Is(err, txMock.ErrInjectedFailure) {
+				t.Fatalf("%s: expected %v, got %v", name, txMock.ErrInjectedFailure, err)
+			}
+		}
+	}
+}
+
+func TestWithLatency(t *testing.T) {
+	const latency = 20 * time.Millisecond
+
+	service := txMock.New(
+		txMock.WithLatency(latency),
+		txMock.WithTransactionFeeFunc(func(ctx context.Context, txHash common.Hash) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+	)
+
+	start := time.Now()
+	if _, err := service.TransactionFee(t.Context(), common.Hash{}); err != nil {
+		t.Fatalf("unable to get fee: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < latency {
+		t.Fatalf("call returned after %s, want at least %s", elapsed, latency)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := service.TransactionFee(ctx, common.Hash{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -86,7 +86,7 @@ func (b *WrappedBackend) CallContract(ctx context.Context, call ethereum.CallMsg
 
 	result, err := b.backend.CallContract(ctx, call, blockNumber)
 	if err != nil {
-		return nil, err
+		return nil, MapNodeError(err)
 	}
 	return result, nil
 }
@@ -118,7 +118,7 @@ func (b *WrappedBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 func (b *WrappedBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
 	gas, err = b.backend.EstimateGas(ctx, call)
 	if err != nil {
-		return 0, err
+		return 0, MapNodeError(err)
 	}
 	return gas, nil
 }
@@ -126,7 +126,7 @@ func (b *WrappedBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg)
 func (b *WrappedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	err := b.backend.SendTransaction(ctx, tx)
 	if err != nil {
-		return err
+		return MapNodeError(err)
 	}
 	return nil
 }
@@ -147,6 +147,14 @@ func (b *WrappedBackend) ChainID(ctx context.Context) (*big.Int, error) {
 	return chainID, nil
 }
 
+func (b *WrappedBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	feeHistory, err := b.backend.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	return feeHistory, nil
+}
+
 func (b *WrappedBackend) Close() {
 	b.backend.Close()
 }
@@ -0,0 +1,18 @@
+package circuit
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFileFunc stores the artifact called name, e.g. on the local filesystem, in memory for tests
+// or in a cloud storage bucket. Names are the bare file names such as ProofMetaFile.
+type WriteFileFunc func(name string, data []byte) error
+
+// DirWriter returns the WriteFileFunc writing artifacts into dir on the local filesystem, which
+// the functions taking an output directory use.
+func DirWriter(dir string) WriteFileFunc {
+	return func(name string, data []byte) error {
+		return os.WriteFile(filepath.Join(dir, name), data, 0o644)
+	}
+}
@@ -0,0 +1,70 @@
+package circuit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestWriteFileFunc(t *testing.T) {
+	artifacts := make(map[string][]byte)
+	memory := func(name string, data []byte) error {
+		artifacts[name] = bytes.Clone(data)
+		return nil
+	}
+
+	assignment := &pairCircuit{X: 10, A: 3, B: 7}
+	full, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	cs, err := Compile(&pairCircuit{}, ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to compile: %v", err)
+	}
+	meta := NewProofMeta("pair/v1", ecc.BN254, backend.GROTH16)
+
+	dir := t.TempDir()
+	for _, write := range []WriteFileFunc{memory, DirWriter(dir)} {
+		if err := ExportPublicWitnessTo(full, write); err != nil {
+			t.Fatalf("unable to export public witness: %v", err)
+		}
+		if err := ExportAnnotatedWitnessTo(assignment, write); err != nil {
+			t.Fatalf("unable to export annotated witness: %v", err)
+		}
+		if err := WriteProofMetaTo(write, meta); err != nil {
+			t.Fatalf("unable to write metadata: %v", err)
+		}
+		if err := DumpR1CSTo(cs, "circuit.r1cs", write); err != nil {
+			t.Fatalf("unable to dump r1cs: %v", err)
+		}
+	}
+
+	names := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{AnnotatedWitnessFile, "circuit.r1cs", ProofMetaFile, PublicWitnessBinFile, PublicWitnessJSONFile}
+	slices.Sort(want)
+	if !slices.Equal(names, want) {
+		t.Fatalf("captured artifacts %v, want %v", names, want)
+	}
+
+	// the in-memory artifacts are the files the local filesystem writer produced
+	for name, data := range artifacts {
+		onDisk, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", name, err)
+		}
+		if !bytes.Equal(data, onDisk) {
+			t.Fatalf("%s differs between memory and disk", name)
+		}
+	}
+}
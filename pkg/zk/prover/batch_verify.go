@@ -0,0 +1,130 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+var (
+	// ErrBatchSize denotes a batch with a different number of proofs and public witnesses.
+	ErrBatchSize = errors.New("number of proofs and public witnesses differ")
+	// ErrBatchRejected denotes a batch in which at least one proof does not verify.
+	ErrBatchRejected = errors.New("batch contains an invalid proof")
+)
+
+// BatchVerifyGroth16 verifies proofs against the public witnesses of the same index, all for vk.
+//
+// gnark has no Groth16 batch verifier, so BN254 proofs without commitments are checked at once
+// with a random linear combination of their pairing equations: with random rᵢ,
+//
+//	∏ e(rᵢ·Aᵢ, Bᵢ) · e(-Σrᵢ·α, β) · e(-Σrᵢ·Lᵢ, γ) · e(-Σrᵢ·Cᵢ, δ) = 1
+//
+// where Lᵢ is the public input term of proof i. This costs n+3 pairings sharing a single final
+// exponentiation instead of n verifications, and holds for an invalid proof only with negligible
+// probability. Which proof is invalid is not reported. Other curves, and circuits with
+// commitments, are verified one proof at a time.
+func BatchVerifyGroth16(vk groth16.VerifyingKey, proofs []groth16.Proof, publicWitnesses []witness.Witness) error {
+	if len(proofs) != len(publicWitnesses) {
+		return fmt.Errorf("%w: %d proofs, %d public witnesses", ErrBatchSize, len(proofs), len(publicWitnesses))
+	}
+
+	if bn254VK, ok := vk.(*groth16_bn254.VerifyingKey); ok && len(bn254VK.CommitmentKeys) == 0 {
+		return batchVerifyBN254(bn254VK, proofs, publicWitnesses)
+	}
+
+	for i := range proofs {
+		if err := groth16.Verify(proofs[i], vk, publicWitnesses[i]); err != nil {
+			return fmt.Errorf("%w: proof %d: %w", ErrBatchRejected, i, err)
+		}
+	}
+	return nil
+}
+
+// batchVerifyBN254 checks the random linear combination of the pairing equations of the proofs,
+// see BatchVerifyGroth16.
+func batchVerifyBN254(vk *groth16_bn254.VerifyingKey, proofs []groth16.Proof, publicWitnesses []witness.Witness) error {
+	if len(proofs) == 0 {
+		return nil
+	}
+
+	n := len(proofs)
+	g1 := make([]bn254.G1Affine, 0, n+3)
+	g2 := make([]bn254.G2Affine, 0, n+3)
+
+	// inputScalars[j] = Σ rᵢ·xᵢⱼ, with xᵢ₀ = 1 for the constant wire, so Σrᵢ·Lᵢ is a single MultiExp over K
+	inputScalars := make([]fr.Element, len(vk.G1.K))
+	krs := make([]bn254.G1Affine, n)
+	rs := make([]fr.Element, n)
+
+	for i := range proofs {
+		proof, ok := proofs[i].(*groth16_bn254.Proof)
+		if !ok {
+			return fmt.Errorf("%w: proof %d is a %T, not a BN254 proof", ErrBatchRejected, i, proofs[i])
+		}
+		if len(proof.Commitments) != 0 {
+			return fmt.Errorf("%w: proof %d has commitments the verifying key does not expect", ErrBatchRejected, i)
+		}
+		if !proof.Ar.IsInSubGroup() || !proof.Krs.IsInSubGroup() || !proof.Bs.IsInSubGroup() {
+			return fmt.Errorf("%w: proof %d has a point outside the prime order subgroup", ErrBatchRejected, i)
+		}
+
+		public, ok := publicWitnesses[i].Vector().(fr.Vector)
+		if !ok || len(public) != len(vk.G1.K)-1 {
+			return fmt.Errorf("%w: public witness %d does not match the verifying key", ErrBatchRejected, i)
+		}
+
+		if _, err := rs[i].SetRandom(); err != nil {
+			return fmt.Errorf("unable to sample batch coefficient: %w", err)
+		}
+
+		var ar bn254.G1Affine
+		ar.ScalarMultiplication(&proof.Ar, rs[i].BigInt(new(big.Int)))
+		g1 = append(g1, ar)
+		g2 = append(g2, proof.Bs)
+
+		krs[i] = proof.Krs
+		inputScalars[0].Add(&inputScalars[0], &rs[i])
+		for j := range public {
+			var term fr.Element
+			term.Mul(&rs[i], &public[j])
+			inputScalars[j+1].Add(&inputScalars[j+1], &term)
+		}
+	}
+
+	// Σrᵢ·α, paired with -β, stands for e(α, β)^Σrᵢ on the right hand side
+	var alpha bn254.G1Affine
+	alpha.ScalarMultiplication(&vk.G1.Alpha, inputScalars[0].BigInt(new(big.Int)))
+
+	var inputs, c bn254.G1Affine
+	if _, err := inputs.MultiExp(vk.G1.K, inputScalars, ecc.MultiExpConfig{}); err != nil {
+		return fmt.Errorf("unable to combine public inputs: %w", err)
+	}
+	if _, err := c.MultiExp(krs, rs, ecc.MultiExpConfig{}); err != nil {
+		return fmt.Errorf("unable to combine proofs: %w", err)
+	}
+
+	var betaNeg, gammaNeg, deltaNeg bn254.G2Affine
+	betaNeg.Neg(&vk.G2.Beta)
+	gammaNeg.Neg(&vk.G2.Gamma)
+	deltaNeg.Neg(&vk.G2.Delta)
+	g1 = append(g1, alpha, inputs, c)
+	g2 = append(g2, betaNeg, gammaNeg, deltaNeg)
+
+	ok, err := bn254.PairingCheck(g1, g2)
+	if err != nil {
+		return fmt.Errorf("unable to compute pairing check: %w", err)
+	}
+	if !ok {
+		return ErrBatchRejected
+	}
+
+	return nil
+}
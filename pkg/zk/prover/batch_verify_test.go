@@ -0,0 +1,100 @@
+package circuit
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+// newCubicBatch proves CubicCircuit over curve for x = 1..n and returns the proofs, their public
+// witnesses and the verifying key.
+func newCubicBatch(tb testing.TB, curve ecc.ID, n int) ([]groth16.Proof, []witness.Witness, groth16.VerifyingKey) {
+	tb.Helper()
+
+	cs, err := Compile(&CubicCircuit{}, curve)
+	if err != nil {
+		tb.Fatalf("unable to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		tb.Fatalf("unable to run setup: %v", err)
+	}
+
+	proofs := make([]groth16.Proof, n)
+	publics := make([]witness.Witness, n)
+	for i := range proofs {
+		full, err := frontend.NewWitness(NewCubicAssignment(big.NewInt(int64(i+1))), curve.ScalarField())
+		if err != nil {
+			tb.Fatalf("unable to create witness: %v", err)
+		}
+		if publics[i], err = full.Public(); err != nil {
+			tb.Fatalf("unable to extract public witness: %v", err)
+		}
+		if proofs[i], err = groth16.Prove(cs, pk, full); err != nil {
+			tb.Fatalf("unable to prove: %v", err)
+		}
+	}
+	return proofs, publics, vk
+}
+
+func TestBatchVerifyGroth16(t *testing.T) {
+	proofs, publics, vk := newCubicBatch(t, ecc.BN254, 21)
+
+	if err := BatchVerifyGroth16(vk, proofs[:20], publics[:20]); err != nil {
+		t.Fatalf("valid batch rejected: %v", err)
+	}
+	if err := BatchVerifyGroth16(vk, nil, nil); err != nil {
+		t.Fatalf("empty batch rejected: %v", err)
+	}
+
+	// the last proof is checked against the public input of another one
+	mismatched := append(publics[:20:20], publics[0])
+	if err := BatchVerifyGroth16(vk, proofs, mismatched); !errors.Is(err, ErrBatchRejected) {
+		t.Fatalf("expected %v, got %v", ErrBatchRejected, err)
+	}
+	if err := BatchVerifyGroth16(vk, proofs, publics); err != nil {
+		t.Fatalf("valid batch of 21 rejected: %v", err)
+	}
+
+	if err := BatchVerifyGroth16(vk, proofs, publics[1:]); !errors.Is(err, ErrBatchSize) {
+		t.Fatalf("expected %v, got %v", ErrBatchSize, err)
+	}
+}
+
+func TestBatchVerifyGroth16OtherCurve(t *testing.T) {
+	proofs, publics, vk := newCubicBatch(t, ecc.BLS12_381, 3)
+
+	if err := BatchVerifyGroth16(vk, proofs, publics); err != nil {
+		t.Fatalf("valid batch rejected: %v", err)
+	}
+	publics[2] = publics[1]
+	if err := BatchVerifyGroth16(vk, proofs, publics); !errors.Is(err, ErrBatchRejected) {
+		t.Fatalf("expected %v, got %v", ErrBatchRejected, err)
+	}
+}
+
+func BenchmarkBatchVerifyGroth16(b *testing.B) {
+	proofs, publics, vk := newCubicBatch(b, ecc.BN254, 20)
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range proofs {
+				if err := groth16.Verify(proofs[j], vk, publics[j]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := BatchVerifyGroth16(vk, proofs, publics); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package circuit
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/logger"
+)
+
+func BenchmarkGroth16Prove(b *testing.B) {
+	logger.Disable()
+
+	// the pre-image length sets the number of Poseidon2 permutations, hence the circuit size
+	for _, preImageLen := range []int{0, 4, 16} {
+		b.Run(fmt.Sprintf("preimage=%d", preImageLen), func(b *testing.B) {
+			cs, err := Compile(NewPedersenCircuit(preImageLen), ecc.BN254)
+			if err != nil {
+				b.Fatal(err)
+			}
+			pk, _, err := groth16.Setup(cs)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			preImage := make([]fr.Element, preImageLen)
+			for i := range preImage {
+				preImage[i].SetUint64(uint64(i + 1))
+			}
+			assignment := NewPedersenAssignment(big.NewInt(1234), big.NewInt(5678))
+			if preImageLen > 0 {
+				assignment = NewPedersenPreImageAssignment(preImage, big.NewInt(5678))
+			}
+			w, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportMetric(float64(cs.GetNbConstraints()), "constraints")
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := groth16.Prove(cs, pk, w); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
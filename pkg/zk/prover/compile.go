@@ -0,0 +1,57 @@
+package circuit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// Compile compiles circuit over the scalar field of curve into the rank-1 constraint system the
+// Groth16 prover takes, the same way every prover entrypoint does.
+func Compile(circuit frontend.Circuit, curve ecc.ID) (constraint.ConstraintSystem, error) {
+	if err := checkProofSystem(curve, backend.GROTH16); err != nil {
+		return nil, err
+	}
+
+	cs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile circuit: %w", err)
+	}
+
+	return cs, nil
+}
+
+// DumpR1CS writes cs to path in gnark's binary encoding, which groth16.NewCS(curve).ReadFrom reads back.
+func DumpR1CS(cs constraint.ConstraintSystem, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create r1cs file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := cs.WriteTo(f); err != nil {
+		return fmt.Errorf("unable to write r1cs: %w", err)
+	}
+
+	return f.Close()
+}
+
+// DumpR1CSTo is DumpR1CS writing the constraint system as the artifact called name with write.
+func DumpR1CSTo(cs constraint.ConstraintSystem, name string, write WriteFileFunc) error {
+	var buf bytes.Buffer
+	if _, err := cs.WriteTo(&buf); err != nil {
+		return fmt.Errorf("unable to write r1cs: %w", err)
+	}
+
+	if err := write(name, buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write r1cs file: %w", err)
+	}
+
+	return nil
+}
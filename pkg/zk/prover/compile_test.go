@@ -0,0 +1,62 @@
+package circuit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+func TestCompilePedersenCircuit(t *testing.T) {
+	// changing the constraint count of the circuit changes its keys, so it is pinned here
+	cases := []struct {
+		preImageLen int
+		constraints int
+	}{
+		{0, 4310},
+		{3, 4791},
+	}
+
+	for _, tc := range cases {
+		cs, err := Compile(NewPedersenCircuit(tc.preImageLen), ecc.BN254)
+		if err != nil {
+			t.Fatalf("unable to compile circuit: %v", err)
+		}
+		if got := cs.GetNbConstraints(); got != tc.constraints {
+			t.Fatalf("circuit with a pre-image of %d has %d constraints, want %d", tc.preImageLen, got, tc.constraints)
+		}
+	}
+
+	if _, err := Compile(NewPedersenCircuit(0), ecc.UNKNOWN); !errors.Is(err, ErrUnsupportedProofSystem) {
+		t.Fatalf("expected %v, got %v", ErrUnsupportedProofSystem, err)
+	}
+}
+
+func TestDumpR1CS(t *testing.T) {
+	cs, err := Compile(NewPedersenCircuit(0), ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pedersen.r1cs")
+	if err := DumpR1CS(cs, path); err != nil {
+		t.Fatalf("unable to dump r1cs: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unable to open r1cs: %v", err)
+	}
+	defer f.Close()
+
+	decoded := groth16.NewCS(ecc.BN254)
+	if _, err := decoded.ReadFrom(f); err != nil {
+		t.Fatalf("unable to read r1cs: %v", err)
+	}
+	if decoded.GetNbConstraints() != cs.GetNbConstraints() {
+		t.Fatalf("decoded r1cs has %d constraints, want %d", decoded.GetNbConstraints(), cs.GetNbConstraints())
+	}
+}
@@ -0,0 +1,300 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// CompressedProofSize is the size in bytes of a serialized CompressedProof.
+const CompressedProofSize = 4 * fp.Bytes
+
+var (
+	// ErrUnsupportedProof denotes a proof that is not a BN254 Groth16 proof without commitments.
+	ErrUnsupportedProof = errors.New("only bn254 groth16 proofs without commitments can be compressed")
+	// ErrInvalidPoint denotes a compressed point that is missing, out of range, not on the curve or
+	// not in its subgroup.
+	ErrInvalidPoint = errors.New("invalid compressed point")
+)
+
+// Constants of the point (de)compression in the gnark Solidity verifier. G2 is defined over
+// Fp[i]/(i² + 1) with the twist y² = x³ + 3/(9 + i) = x³ + 27/82 - 3/82 ⋅ i.
+var (
+	modulusP         = fp.Modulus()
+	expSqrtFp        = new(big.Int).Rsh(new(big.Int).Add(modulusP, big.NewInt(1)), 2) // (p + 1) / 4
+	fraction1Over2   = fpDiv(big.NewInt(1), big.NewInt(2))
+	fraction27Over82 = fpDiv(big.NewInt(27), big.NewInt(82))
+	fraction3Over82  = fpDiv(big.NewInt(3), big.NewInt(82))
+)
+
+// CompressedProof is a bn254 Groth16 proof with the points (A, B, C) in compressed form, in the
+// exact order expected by verifyCompressedProof of the gnark Solidity verifier:
+//
+//	[ A.x with sign bit, B.x1, B.x0 with sign and hint bits, C.x with sign bit ]
+type CompressedProof [4]*big.Int
+
+// CompressProof compresses a bn254 Groth16 proof, halving its size compared to the uncompressed
+// Solidity encoding. Proofs carrying Pedersen commitments are not supported.
+func CompressProof(proof groth16.Proof) (CompressedProof, error) {
+	p, ok := proof.(*groth16_bn254.Proof)
+	if !ok || len(p.Commitments) > 0 {
+		return CompressedProof{}, ErrUnsupportedProof
+	}
+
+	var compressed CompressedProof
+	var err error
+
+	if compressed[0], err = compressG1(fpBig(p.Ar.X), fpBig(p.Ar.Y)); err != nil {
+		return CompressedProof{}, fmt.Errorf("unable to compress A: %w", err)
+	}
+	if compressed[2], compressed[1], err = compressG2(fpBig(p.Bs.X.A0), fpBig(p.Bs.X.A1), fpBig(p.Bs.Y.A0), fpBig(p.Bs.Y.A1)); err != nil {
+		return CompressedProof{}, fmt.Errorf("unable to compress B: %w", err)
+	}
+	if compressed[3], err = compressG1(fpBig(p.Krs.X), fpBig(p.Krs.Y)); err != nil {
+		return CompressedProof{}, fmt.Errorf("unable to compress C: %w", err)
+	}
+
+	return compressed, nil
+}
+
+// DecompressProof reconstructs the Groth16 proof from its compressed form.
+func DecompressProof(compressed CompressedProof) (groth16.Proof, error) {
+	for i := range compressed {
+		if compressed[i] == nil {
+			return nil, fmt.Errorf("%w: element %d is missing", ErrInvalidPoint, i)
+		}
+	}
+
+	var proof groth16_bn254.Proof
+
+	ax, ay, err := decompressG1(compressed[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress A: %w", err)
+	}
+	proof.Ar.X.SetBigInt(ax)
+	proof.Ar.Y.SetBigInt(ay)
+
+	bx0, bx1, by0, by1, err := decompressG2(compressed[2], compressed[1])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress B: %w", err)
+	}
+	proof.Bs.X.A0.SetBigInt(bx0)
+	proof.Bs.X.A1.SetBigInt(bx1)
+	proof.Bs.Y.A0.SetBigInt(by0)
+	proof.Bs.Y.A1.SetBigInt(by1)
+
+	cx, cy, err := decompressG1(compressed[3])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress C: %w", err)
+	}
+	proof.Krs.X.SetBigInt(cx)
+	proof.Krs.Y.SetBigInt(cy)
+
+	if !proof.Ar.IsInSubGroup() || !proof.Bs.IsInSubGroup() || !proof.Krs.IsInSubGroup() {
+		return nil, fmt.Errorf("%w: point not in subgroup", ErrInvalidPoint)
+	}
+
+	return &proof, nil
+}
+
+// Bytes returns the 128 bytes big endian encoding of the compressed proof. It returns
+// ErrInvalidPoint if an element is missing or negative, or does not fit in its 32 bytes.
+func (c CompressedProof) Bytes() ([]byte, error) {
+	res := make([]byte, CompressedProofSize)
+	for i := range c {
+		switch {
+		case c[i] == nil:
+			return nil, fmt.Errorf("%w: element %d is missing", ErrInvalidPoint, i)
+		case c[i].Sign() < 0 || c[i].BitLen() > 8*fp.Bytes:
+			return nil, fmt.Errorf("%w: element %d does not fit in %d bytes", ErrInvalidPoint, i, fp.Bytes)
+		}
+		c[i].FillBytes(res[i*fp.Bytes : (i+1)*fp.Bytes])
+	}
+	return res, nil
+}
+
+// CompressedProofFromBytes decodes a compressed proof encoded with Bytes.
+func CompressedProofFromBytes(data []byte) (CompressedProof, error) {
+	if len(data) != CompressedProofSize {
+		return CompressedProof{}, fmt.Errorf("compressed proof must be %d bytes, got %d", CompressedProofSize, len(data))
+	}
+
+	var c CompressedProof
+	for i := range c {
+		c[i] = new(big.Int).SetBytes(data[i*fp.Bytes : (i+1)*fp.Bytes])
+	}
+	return c, nil
+}
+
+// compressG1 mirrors compress_g1: x with the lowest bit set if y is the negated square root.
+func compressG1(x, y *big.Int) (*big.Int, error) {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return new(big.Int), nil // point at infinity
+	}
+
+	yPos, ok := sqrtFp(fpAdd(fpMul(fpMul(x, x), x), big.NewInt(3)))
+	if !ok {
+		return nil, ErrInvalidPoint
+	}
+
+	c := new(big.Int).Lsh(x, 1)
+	switch {
+	case y.Cmp(yPos) == 0:
+	case y.Cmp(fpNeg(yPos)) == 0:
+		c.SetBit(c, 0, 1)
+	default:
+		return nil, ErrInvalidPoint
+	}
+	return c, nil
+}
+
+// decompressG1 mirrors decompress_g1.
+func decompressG1(c *big.Int) (x, y *big.Int, err error) {
+	if c.Sign() == 0 {
+		return new(big.Int), new(big.Int), nil // point at infinity
+	}
+
+	negate := c.Bit(0) == 1
+	x = new(big.Int).Rsh(c, 1)
+	if x.Cmp(modulusP) >= 0 {
+		return nil, nil, ErrInvalidPoint
+	}
+
+	y, ok := sqrtFp(fpAdd(fpMul(fpMul(x, x), x), big.NewInt(3)))
+	if !ok {
+		return nil, nil, ErrInvalidPoint
+	}
+	if negate {
+		y = fpNeg(y)
+	}
+	return x, y, nil
+}
+
+// compressG2 mirrors compress_g2: c0 is x0 with the sign bit and the square root hint bit, c1 is x1.
+func compressG2(x0, x1, y0, y1 *big.Int) (c0, c1 *big.Int, err error) {
+	if x0.Sign() == 0 && x1.Sign() == 0 && y0.Sign() == 0 && y1.Sign() == 0 {
+		return new(big.Int), new(big.Int), nil // point at infinity
+	}
+
+	a0, a1 := g2RightHandSide(x0, x1)
+
+	d, ok := sqrtFp(fpAdd(fpMul(a0, a0), fpMul(a1, a1)))
+	if !ok {
+		return nil, nil, ErrInvalidPoint
+	}
+	_, isSquare := sqrtFp(fpMul(fpAdd(a0, d), fraction1Over2))
+	hint := !isSquare
+
+	y0Pos, y1Pos, ok := sqrtFp2(a0, a1, hint)
+	if !ok {
+		return nil, nil, ErrInvalidPoint
+	}
+
+	c0 = new(big.Int).Lsh(x0, 2)
+	if hint {
+		c0.SetBit(c0, 1, 1)
+	}
+	switch {
+	case y0.Cmp(y0Pos) == 0 && y1.Cmp(y1Pos) == 0:
+	case y0.Cmp(fpNeg(y0Pos)) == 0 && y1.Cmp(fpNeg(y1Pos)) == 0:
+		c0.SetBit(c0, 0, 1)
+	default:
+		return nil, nil, ErrInvalidPoint
+	}
+	return c0, new(big.Int).Set(x1), nil
+}
+
+// decompressG2 mirrors decompress_g2.
+func decompressG2(c0, c1 *big.Int) (x0, x1, y0, y1 *big.Int, err error) {
+	if c0.Sign() == 0 && c1.Sign() == 0 {
+		return new(big.Int), new(big.Int), new(big.Int), new(big.Int), nil // point at infinity
+	}
+
+	negate := c0.Bit(0) == 1
+	hint := c0.Bit(1) == 1
+	x0 = new(big.Int).Rsh(c0, 2)
+	x1 = new(big.Int).Set(c1)
+	if x0.Cmp(modulusP) >= 0 || x1.Cmp(modulusP) >= 0 {
+		return nil, nil, nil, nil, ErrInvalidPoint
+	}
+
+	a0, a1 := g2RightHandSide(x0, x1)
+	y0, y1, ok := sqrtFp2(a0, a1, hint)
+	if !ok {
+		return nil, nil, nil, nil, ErrInvalidPoint
+	}
+	if negate {
+		y0, y1 = fpNeg(y0), fpNeg(y1)
+	}
+	return x0, x1, y0, y1, nil
+}
+
+// g2RightHandSide returns x³ + 3/(9 + i) for x = x0 + x1 ⋅ i.
+func g2RightHandSide(x0, x1 *big.Int) (a0, a1 *big.Int) {
+	n3ab := fpMul(fpMul(x0, x1), fpNeg(big.NewInt(3)))
+	a3 := fpMul(fpMul(x0, x0), x0)
+	b3 := fpMul(fpMul(x1, x1), x1)
+
+	a0 = fpAdd(fraction27Over82, fpAdd(a3, fpMul(n3ab, x1)))
+	a1 = fpNeg(fpAdd(fraction3Over82, fpAdd(b3, fpMul(n3ab, x0))))
+	return a0, a1
+}
+
+// sqrtFp2 mirrors sqrt_Fp2, the hint selecting which of the two candidate roots of the norm to use.
+func sqrtFp2(a0, a1 *big.Int, hint bool) (x0, x1 *big.Int, ok bool) {
+	d, ok := sqrtFp(fpAdd(fpMul(a0, a0), fpMul(a1, a1)))
+	if !ok {
+		return nil, nil, false
+	}
+	if hint {
+		d = fpNeg(d)
+	}
+
+	x0, ok = sqrtFp(fpMul(fpAdd(a0, d), fraction1Over2))
+	if !ok {
+		return nil, nil, false
+	}
+	twoX0 := fpMul(x0, big.NewInt(2))
+	if twoX0.Sign() == 0 {
+		return nil, nil, false
+	}
+	x1 = fpMul(a1, new(big.Int).ModInverse(twoX0, modulusP))
+
+	if a0.Cmp(fpAdd(fpMul(x0, x0), fpNeg(fpMul(x1, x1)))) != 0 || a1.Cmp(fpMul(big.NewInt(2), fpMul(x0, x1))) != 0 {
+		return nil, nil, false
+	}
+	return x0, x1, true
+}
+
+// sqrtFp returns a^((p+1)/4), the square root picked by the Solidity verifier, and whether a is a square.
+func sqrtFp(a *big.Int) (*big.Int, bool) {
+	x := new(big.Int).Exp(a, expSqrtFp, modulusP)
+	return x, fpMul(x, x).Cmp(new(big.Int).Mod(a, modulusP)) == 0
+}
+
+func fpAdd(a, b *big.Int) *big.Int {
+	res := new(big.Int).Add(a, b)
+	return res.Mod(res, modulusP)
+}
+
+func fpMul(a, b *big.Int) *big.Int {
+	res := new(big.Int).Mul(a, b)
+	return res.Mod(res, modulusP)
+}
+
+func fpNeg(a *big.Int) *big.Int {
+	res := new(big.Int).Mod(a, modulusP)
+	return res.Sub(modulusP, res).Mod(res, modulusP)
+}
+
+func fpDiv(a, b *big.Int) *big.Int {
+	return fpMul(a, new(big.Int).ModInverse(b, fp.Modulus()))
+}
+
+func fpBig(e fp.Element) *big.Int {
+	return e.BigInt(new(big.Int))
+}
@@ -0,0 +1,98 @@
+package circuit
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestCompressProof(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{n: 4})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+
+	witness, err := frontend.NewWitness(&powerCircuit{X: 3, Y: 81}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		t.Fatalf("unable to extract public witness: %v", err)
+	}
+
+	proof, err := groth16.Prove(cs, pk, witness)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+
+	compressed, err := CompressProof(proof)
+	if err != nil {
+		t.Fatalf("unable to compress proof: %v", err)
+	}
+
+	encoded, err := compressed.Bytes()
+	if err != nil {
+		t.Fatalf("unable to encode compressed proof: %v", err)
+	}
+	if uncompressed := proof.(*groth16_bn254.Proof).MarshalSolidity(); len(encoded)*2 != len(uncompressed) {
+		t.Fatalf("compressed proof is %d bytes, want half of %d", len(encoded), len(uncompressed))
+	}
+
+	decoded, err := CompressedProofFromBytes(encoded)
+	if err != nil {
+		t.Fatalf("unable to decode compressed proof: %v", err)
+	}
+	decompressed, err := DecompressProof(decoded)
+	if err != nil {
+		t.Fatalf("unable to decompress proof: %v", err)
+	}
+	if err := groth16.Verify(decompressed, vk, publicWitness); err != nil {
+		t.Fatalf("decompressed proof rejected: %v", err)
+	}
+
+	var want, got bytes.Buffer
+	proof.WriteRawTo(&want)
+	decompressed.WriteRawTo(&got)
+	if !bytes.Equal(want.Bytes(), got.Bytes()) {
+		t.Fatal("decompressed proof differs from the original")
+	}
+
+	// flipping the sign bit of A yields -A, which must not verify
+	decoded[0].SetBit(decoded[0], 0, decoded[0].Bit(0)^1)
+	tampered, err := DecompressProof(decoded)
+	if err != nil {
+		t.Fatalf("unable to decompress tampered proof: %v", err)
+	}
+	if err := groth16.Verify(tampered, vk, publicWitness); err == nil {
+		t.Fatal("tampered proof verified")
+	}
+}
+
+func TestCompressedProofBytesInvalid(t *testing.T) {
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 8*CompressedProofSize/4)
+	for name, c := range map[string]CompressedProof{
+		"zero value": {},
+		"missing":    {big.NewInt(1), big.NewInt(2), nil, big.NewInt(4)},
+		"negative":   {big.NewInt(1), big.NewInt(-2), big.NewInt(3), big.NewInt(4)},
+		"too large":  {big.NewInt(1), big.NewInt(2), big.NewInt(3), tooLarge},
+	} {
+		if _, err := c.Bytes(); !errors.Is(err, ErrInvalidPoint) {
+			t.Fatalf("%s: expected %v, got %v", name, ErrInvalidPoint, err)
+		}
+		if _, err := DecompressProof(c); err == nil {
+			t.Fatalf("%s: decompressed an invalid proof", name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package circuit
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultOutDir is the directory proof artifacts are written to by default.
+const DefaultOutDir = "artifacts"
+
+var (
+	// ErrInvalidConfig denotes a ProverConfig that cannot be used.
+	ErrInvalidConfig = errors.New("invalid prover config")
+	// ErrWitnessTooLarge denotes a witness with more elements than ProverConfig.MaxWitnessElements.
+	ErrWitnessTooLarge = errors.New("witness too large")
+)
+
+// ProverConfig gathers the parameters shared by the prover entrypoints.
+type ProverConfig struct {
+	Curve   ecc.ID         // curve the circuits are compiled over
+	Backend backend.ID     // proving backend
+	OutDir  string         // directory the artifacts are written to
+	Logger  *logrus.Logger // logger for progress and timings
+
+	// Rand is the source the toxic value of the insecure PLONK SRS is drawn from. gnark draws the
+	// Groth16 setup and the blinding of every proof from crypto/rand whatever Rand is.
+	Rand io.Reader
+
+	// MaxWitnessElements caps the number of elements of a witness to prove, see CheckWitnessSize.
+	// 0 means no limit.
+	MaxWitnessElements int
+
+	// InsecureSRS lets the PLONK backend run its setup with an SRS generated on the fly from a
+	// toxic value known to this process, with which anyone holding it can forge proofs. There is
+	// no way to load a trusted SRS yet, so PLONK is refused unless this is set: it is for demos
+	// and tests only.
+	InsecureSRS bool
+}
+
+// DefaultConfig returns a Groth16 over BN254 configuration, the combination the Solidity verifier
+// supports, writing to DefaultOutDir.
+func DefaultConfig() ProverConfig {
+	return ProverConfig{
+		Curve:   ecc.BN254,
+		Backend: backend.GROTH16,
+		OutDir:  DefaultOutDir,
+		Logger:  logrus.StandardLogger(),
+		Rand:    rand.Reader,
+	}
+}
+
+// Validate checks that every field is set and that the backend supports the curve.
+func (c ProverConfig) Validate() error {
+	if err := checkProofSystem(c.Curve, c.Backend); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	switch {
+	case c.OutDir == "":
+		return fmt.Errorf("%w: output directory is empty", ErrInvalidConfig)
+	case c.Logger == nil:
+		return fmt.Errorf("%w: logger is nil", ErrInvalidConfig)
+	case c.Rand == nil:
+		return fmt.Errorf("%w: randomness source is nil", ErrInvalidConfig)
+	case c.MaxWitnessElements < 0:
+		return fmt.Errorf("%w: negative witness size limit", ErrInvalidConfig)
+	case c.Backend == backend.PLONK && !c.InsecureSRS:
+		return fmt.Errorf("%w: plonk has no trusted srs to load yet, set InsecureSRS (--insecure-srs) to run with an insecure one", ErrInvalidConfig)
+	}
+
+	return nil
+}
+
+// CheckWitnessSize returns ErrWitnessTooLarge if w has more elements than MaxWitnessElements.
+// Servers proving witnesses received from clients call it before proving, so an oversized witness
+// is rejected before the prover allocates memory proportional to it.
+func (c ProverConfig) CheckWitnessSize(w witness.Witness) error {
+	if c.MaxWitnessElements == 0 {
+		return nil
+	}
+	if size := WitnessSize(w); size > c.MaxWitnessElements {
+		return fmt.Errorf("%w: %d elements, at most %d allowed", ErrWitnessTooLarge, size, c.MaxWitnessElements)
+	}
+	return nil
+}
+
+// checkProofSystem returns ErrUnsupportedProofSystem unless the backend is implemented over the curve.
+func checkProofSystem(curve ecc.ID, backendID backend.ID) error {
+	if backendID != backend.GROTH16 && backendID != backend.PLONK {
+		return fmt.Errorf("%w: backend %s", ErrUnsupportedProofSystem, backendID)
+	}
+	if !slices.Contains(proofCurves, curve) {
+		return fmt.Errorf("%w: %s does not support curve %s", ErrUnsupportedProofSystem, backendID, curve)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package circuit
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if cfg.Curve != ecc.BN254 || cfg.Backend != backend.GROTH16 {
+		t.Fatalf("default proof system is %s/%s, want %s/%s", cfg.Curve, cfg.Backend, ecc.BN254, backend.GROTH16)
+	}
+	if cfg.OutDir != DefaultOutDir || cfg.Logger == nil || cfg.Rand == nil {
+		t.Fatalf("default config is not fully populated: %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("default config rejected: %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*ProverConfig)
+	}{
+		{"curve without backend", func(c *ProverConfig) { c.Curve = ecc.SECP256K1 }},
+		{"unknown curve", func(c *ProverConfig) { c.Curve = ecc.UNKNOWN }},
+		{"unknown backend", func(c *ProverConfig) { c.Backend = backend.UNKNOWN }},
+		{"empty output directory", func(c *ProverConfig) { c.OutDir = "" }},
+		{"nil logger", func(c *ProverConfig) { c.Logger = nil }},
+		{"nil randomness", func(c *ProverConfig) { c.Rand = nil }},
+		{"negative witness limit", func(c *ProverConfig) { c.MaxWitnessElements = -1 }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tc.modify(&cfg)
+
+			if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("expected %v, got %v", ErrInvalidConfig, err)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.Curve, cfg.Backend = ecc.BLS12_381, backend.PLONK
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("plonk without an srs: expected %v, got %v", ErrInvalidConfig, err)
+	}
+	cfg.InsecureSRS = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("plonk over bls12-381 rejected: %v", err)
+	}
+}
+
+func TestCheckWitnessSize(t *testing.T) {
+	cubic, err := frontend.NewWitness(NewCubicAssignment(big.NewInt(3)), ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	pedersen, err := frontend.NewWitness(NewPedersenAssignment(big.NewInt(1), big.NewInt(2)), ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	if WitnessSize(cubic) != 2 || WitnessSize(pedersen) != 5 || WitnessSize(nil) != 0 {
+		t.Fatalf("witness sizes %d and %d, want 2 and 5", WitnessSize(cubic), WitnessSize(pedersen))
+	}
+
+	cfg := DefaultConfig()
+	if err := cfg.CheckWitnessSize(pedersen); err != nil {
+		t.Fatalf("witness rejected without a limit: %v", err)
+	}
+
+	cfg.MaxWitnessElements = 2
+	if err := cfg.CheckWitnessSize(cubic); err != nil {
+		t.Fatalf("witness within the limit rejected: %v", err)
+	}
+	if err := cfg.CheckWitnessSize(pedersen); !errors.Is(err, ErrWitnessTooLarge) {
+		t.Fatalf("expected %v, got %v", ErrWitnessTooLarge, err)
+	}
+
+	cfg.OutDir = t.TempDir()
+	if err := ProveAndExport(cfg, "pedersen", NewPedersenCircuit(0), NewPedersenAssignment(big.NewInt(1), big.NewInt(2))); !errors.Is(err, ErrWitnessTooLarge) {
+		t.Fatalf("expected %v, got %v", ErrWitnessTooLarge, err)
+	}
+}
@@ -0,0 +1,39 @@
+package circuit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	gnarkio "github.com/consensys/gnark/io"
+)
+
+var (
+	// ErrVKMismatch denotes that a proof was generated against a different verifying key.
+	ErrVKMismatch = errors.New("verifying key fingerprint mismatch")
+)
+
+// VKFingerprint returns the hex encoded sha256 hash of the serialized verifying key, of any backend.
+// Two verifying keys have the same fingerprint only if they come from the same setup.
+func VKFingerprint(vk gnarkio.WriterRawTo) (string, error) {
+	h := sha256.New()
+	if _, err := vk.WriteRawTo(h); err != nil {
+		return "", fmt.Errorf("unable to serialize verifying key: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyVKFingerprint checks that meta was produced for the given verifying key.
+func VerifyVKFingerprint(meta ProofMeta, vk gnarkio.WriterRawTo) error {
+	fingerprint, err := VKFingerprint(vk)
+	if err != nil {
+		return err
+	}
+
+	if meta.VKFingerprint != fingerprint {
+		return fmt.Errorf("%w: proof was generated for %q, verifying key is %q",
+			ErrVKMismatch, meta.VKFingerprint, fingerprint)
+	}
+	return nil
+}
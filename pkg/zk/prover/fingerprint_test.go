@@ -0,0 +1,63 @@
+package circuit
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestVKFingerprint(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{n: 4})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+
+	_, vkA, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	_, vkB, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+
+	fingerprintA, err := VKFingerprint(vkA)
+	if err != nil {
+		t.Fatalf("unable to fingerprint vk: %v", err)
+	}
+	fingerprintB, err := VKFingerprint(vkB)
+	if err != nil {
+		t.Fatalf("unable to fingerprint vk: %v", err)
+	}
+	if fingerprintA == fingerprintB {
+		t.Fatal("different setups produce the same fingerprint")
+	}
+
+	// the fingerprint survives a serialization round trip of the key
+	var buf bytes.Buffer
+	if _, err := vkA.WriteTo(&buf); err != nil {
+		t.Fatalf("unable to serialize vk: %v", err)
+	}
+	reloaded := groth16.NewVerifyingKey(ecc.BN254)
+	if _, err := reloaded.ReadFrom(&buf); err != nil {
+		t.Fatalf("unable to deserialize vk: %v", err)
+	}
+	if again, _ := VKFingerprint(reloaded); again != fingerprintA {
+		t.Fatalf("fingerprint %s after reload, want %s", again, fingerprintA)
+	}
+
+	meta := NewProofMeta("power/v1", ecc.BN254, backend.GROTH16)
+	meta.VKFingerprint = fingerprintA
+	if err := VerifyVKFingerprint(meta, reloaded); err != nil {
+		t.Fatalf("matching vk rejected: %v", err)
+	}
+	if err := VerifyVKFingerprint(meta, vkB); !errors.Is(err, ErrVKMismatch) {
+		t.Fatalf("expected %v, got %v", ErrVKMismatch, err)
+	}
+}
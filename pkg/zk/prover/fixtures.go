@@ -0,0 +1,153 @@
+package circuit
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+	mathrand "math/rand/v2"
+	"os"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	twistededwards_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/hblocks/keyless/pkg/zk/verifier"
+)
+
+// VerifyingKeyFile holds the verifying key written by WriteFixtures, in gnark's binary encoding.
+const VerifyingKeyFile = "verifying_key.bin"
+
+// Fixture is a PedersenCircuit proof along with what is needed to check it, see WriteFixtures.
+type Fixture struct {
+	Proof        groth16.Proof
+	VerifyingKey groth16.VerifyingKey
+	Witness      witness.Witness // full witness, the public part of which the proof is checked against
+}
+
+// NewFixture sets up PedersenCircuit without pre-image over BN254 and proves the opening of a
+// commitment whose message and blinding factor are drawn from opening. The toxic waste of the
+// setup and the blinding of the proof are drawn from crypto/rand by gnark, which offers no way to
+// pass another source; GenerateFixtures derives them from a seed too.
+func NewFixture(opening io.Reader) (*Fixture, error) {
+	cs, err := Compile(NewPedersenCircuit(0), ecc.BN254)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run setup: %w", err)
+	}
+
+	m, err := randomScalar(opening, fr.Modulus())
+	if err != nil {
+		return nil, err
+	}
+	order := twistededwards_bn254.GetEdwardsCurve().Order
+	r, err := randomScalar(opening, &order)
+	if err != nil {
+		return nil, err
+	}
+
+	full, err := frontend.NewWitness(NewPedersenAssignment(m, r), ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+	proof, err := groth16.Prove(cs, pk, full)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove: %w", err)
+	}
+
+	return &Fixture{Proof: proof, VerifyingKey: vk, Witness: full}, nil
+}
+
+// WriteFixtures checks the proof of f and writes it (ProofFile), the verifying key
+// (VerifyingKeyFile), the public witness (PublicWitnessBinFile and PublicWitnessJSONFile) and the
+// verifyProof calldata (verifier.ProofCalldataFile) with write. The artifacts only depend on f, so
+// the same fixture is always written byte for byte the same.
+func WriteFixtures(f *Fixture, write WriteFileFunc) error {
+	public, err := f.Witness.Public()
+	if err != nil {
+		return fmt.Errorf("unable to extract public witness: %w", err)
+	}
+	if err := groth16.Verify(f.Proof, f.VerifyingKey, public); err != nil {
+		return fmt.Errorf("unable to verify fixture proof: %w", err)
+	}
+
+	// write may keep the data it is given, so every artifact gets a buffer of its own
+	var proof bytes.Buffer
+	if err := WriteProofVersioned(&proof, f.Proof, ecc.BN254, backend.GROTH16); err != nil {
+		return err
+	}
+	if err := write(ProofFile, proof.Bytes()); err != nil {
+		return fmt.Errorf("unable to write proof: %w", err)
+	}
+
+	var vk bytes.Buffer
+	if _, err := f.VerifyingKey.WriteTo(&vk); err != nil {
+		return fmt.Errorf("unable to marshal verifying key: %w", err)
+	}
+	if err := write(VerifyingKeyFile, vk.Bytes()); err != nil {
+		return fmt.Errorf("unable to write verifying key: %w", err)
+	}
+
+	if err := ExportPublicWitnessTo(f.Witness, write); err != nil {
+		return err
+	}
+
+	inputs, ok := public.Vector().(fr.Vector)
+	if !ok {
+		return fmt.Errorf("unexpected public witness vector %T", public.Vector())
+	}
+	return verifier.ExportProofCalldataTo(f.Proof, inputs, write)
+}
+
+// randMu serializes GenerateFixtures, which replaces crypto/rand.Reader while it runs.
+var randMu sync.Mutex
+
+// GenerateFixtures writes the WriteFixtures artifacts of a fixture derived from seed to outDir,
+// creating it if needed. The opening, the toxic waste of the setup and the proof blinding are all
+// drawn from a ChaCha8 stream seeded with the hash of seed, so the same seed yields byte-identical
+// artifacts that test suites can check in. The keys are known to anyone with the seed: fixtures
+// are for tests only.
+//
+// gnark draws the setup and proof randomness from crypto/rand.Reader without a way to pass another
+// source, so GenerateFixtures replaces it while it runs and restores it before returning. Whatever
+// else reads crypto/rand.Reader in the meantime, in any goroutine, reads the seeded stream too:
+// call it from tests and tools, never from a process handling secrets.
+func GenerateFixtures(outDir, seed string) error {
+	rng := mathrand.NewChaCha8(sha256.Sum256([]byte(seed)))
+
+	randMu.Lock()
+	defer randMu.Unlock()
+	previous := rand.Reader
+	rand.Reader = rng
+	defer func() { rand.Reader = previous }()
+
+	fixture, err := NewFixture(rng)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+	return WriteFixtures(fixture, DirWriter(outDir))
+}
+
+// randomScalar draws a scalar below order from r, reducing 64 bytes so that the bias is negligible.
+func randomScalar(r io.Reader, order *big.Int) (*big.Int, error) {
+	var buf [64]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, fmt.Errorf("unable to sample scalar: %w", err)
+	}
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(buf[:]), order), nil
+}
@@ -0,0 +1,122 @@
+package circuit
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/hblocks/keyless/pkg/zk/verifier"
+)
+
+// writeFixturesToMemory writes f with WriteFixtures and returns the artifacts by name.
+func writeFixturesToMemory(t *testing.T, f *Fixture) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	if err := WriteFixtures(f, func(name string, data []byte) error {
+		files[name] = data
+		return nil
+	}); err != nil {
+		t.Fatalf("unable to write fixtures: %v", err)
+	}
+	return files
+}
+
+func TestWriteFixtures(t *testing.T) {
+	var opening [128]byte
+	if _, err := rand.Read(opening[:]); err != nil {
+		t.Fatalf("unable to sample opening: %v", err)
+	}
+
+	fixture, err := NewFixture(bytes.NewReader(opening[:]))
+	if err != nil {
+		t.Fatalf("unable to create fixture: %v", err)
+	}
+	files := writeFixturesToMemory(t, fixture)
+
+	for _, name := range []string{ProofFile, VerifyingKeyFile, PublicWitnessBinFile, PublicWitnessJSONFile, verifier.ProofCalldataFile} {
+		if len(files[name]) == 0 {
+			t.Fatalf("%s not written", name)
+		}
+	}
+	for name, data := range writeFixturesToMemory(t, fixture) {
+		if !bytes.Equal(data, files[name]) {
+			t.Fatalf("%s differs between writes of the same fixture", name)
+		}
+	}
+
+	// the fixtures verify once read back
+	proof, curve, _, err := ReadProofVersioned(bytes.NewReader(files[ProofFile]))
+	if err != nil {
+		t.Fatalf("unable to read proof: %v", err)
+	}
+	vk := groth16.NewVerifyingKey(curve)
+	if _, err := vk.ReadFrom(bytes.NewReader(files[VerifyingKeyFile])); err != nil {
+		t.Fatalf("unable to read verifying key: %v", err)
+	}
+	public, err := witness.New(ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	if err := public.UnmarshalBinary(files[PublicWitnessBinFile]); err != nil {
+		t.Fatalf("unable to read public witness: %v", err)
+	}
+	if err := groth16.Verify(proof.(groth16.Proof), vk, public); err != nil {
+		t.Fatalf("fixture proof rejected: %v", err)
+	}
+
+	// the opening alone decides the commitment; the setup and proof randomness come from crypto/rand
+	other, err := NewFixture(bytes.NewReader(opening[:]))
+	if err != nil {
+		t.Fatalf("unable to create fixture: %v", err)
+	}
+	otherFiles := writeFixturesToMemory(t, other)
+	if !bytes.Equal(otherFiles[PublicWitnessBinFile], files[PublicWitnessBinFile]) {
+		t.Fatal("the same opening committed to another value")
+	}
+	if bytes.Equal(otherFiles[VerifyingKeyFile], files[VerifyingKeyFile]) {
+		t.Fatal("two setups produced the same verifying key")
+	}
+
+	if _, err := NewFixture(bytes.NewReader(opening[:10])); err == nil {
+		t.Fatal("fixture created from a truncated opening")
+	}
+
+	// a proof of one setup does not pass with the verifying key of another
+	fixture.VerifyingKey = other.VerifyingKey
+	if err := WriteFixtures(fixture, func(string, []byte) error { return nil }); err == nil {
+		t.Fatal("fixture written with a proof of another setup")
+	}
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	reader := rand.Reader
+
+	first, second, other := t.TempDir(), t.TempDir(), t.TempDir()
+	for dir, seed := range map[string]string{first: "seed", second: "seed", other: "other seed"} {
+		if err := GenerateFixtures(dir, seed); err != nil {
+			t.Fatalf("unable to generate fixtures: %v", err)
+		}
+	}
+	if rand.Reader != reader {
+		t.Fatal("crypto/rand.Reader not restored")
+	}
+
+	for _, name := range []string{ProofFile, VerifyingKeyFile, PublicWitnessBinFile, PublicWitnessJSONFile, verifier.ProofCalldataFile} {
+		want, err := os.ReadFile(filepath.Join(first, name))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", name, err)
+		}
+		if got, _ := os.ReadFile(filepath.Join(second, name)); !bytes.Equal(got, want) {
+			t.Fatalf("%s differs between generations with the same seed", name)
+		}
+		if got, _ := os.ReadFile(filepath.Join(other, name)); bytes.Equal(got, want) {
+			t.Fatalf("%s is the same for another seed", name)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package kProof
 
 import (
-	"embed"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"os"
+	"path/filepath"
+	"slices"
 	"text/template"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -14,10 +16,11 @@ import (
 	eddsa_bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
 	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
 	"github.com/consensys/gnark/frontend"
+	"github.com/hblocks/keyless/pkg/resources"
 )
 
-//go:embed template/*.tmpl
-var eddsaTemplateFiles embed.FS
+// ErrCurveUnsupported denotes a curve that has no signature scheme implementation in this build.
+var ErrCurveUnsupported = errors.New("curve unsupported")
 
 // ---------------------------------------------------------------------------
 // Circuit for ownership of a secret key (placeholder logic)
@@ -30,9 +33,56 @@ type Circuit struct {
 			Y frontend.Variable
 		}
 	}
-	// We'll store chunk1 and chunk2 (each a 128-bit portion, for example)
-	SkChunk1 frontend.Variable
-	SkChunk2 frontend.Variable
+	// SkChunks are the chunks of the secret scalar, most significant first, as split by SplitScalar
+	SkChunks []frontend.Variable
+}
+
+// NewCircuit returns the ownership circuit for curve, with as many scalar chunks as ChunkConfig
+// prescribes.
+func NewCircuit(curve ecc.ID) (*Circuit, error) {
+	if err := checkCurve(curve); err != nil {
+		return nil, err
+	}
+
+	numChunks, _ := ChunkConfig(curve)
+	return &Circuit{SkChunks: make([]frontend.Variable, numChunks)}, nil
+}
+
+// scalarChunkBits is the size of a scalar chunk; 128 bits always fit in the scalar field.
+const scalarChunkBits = 128
+
+// ChunkConfig tells how a secret scalar of curve is split into chunks, both by the circuit and by
+// the witness builder: numChunks chunks of chunkBits bits each cover the whole scalar field, e.g.
+// two chunks for BN254 and three for BW6-761. Unsupported curves yield (0, 0).
+func ChunkConfig(curve ecc.ID) (numChunks int, chunkBits int) {
+	if checkCurve(curve) != nil {
+		return 0, 0
+	}
+
+	bits := curve.ScalarField().BitLen()
+	return (bits + scalarChunkBits - 1) / scalarChunkBits, scalarChunkBits
+}
+
+// SplitScalar splits a big-endian scalar of curve into the chunks of ChunkConfig, most significant
+// first, so that Σ chunks[i]⋅2^(chunkBits⋅(numChunks-1-i)) is the scalar.
+func SplitScalar(curve ecc.ID, scalar []byte) ([]*big.Int, error) {
+	if err := checkCurve(curve); err != nil {
+		return nil, err
+	}
+
+	numChunks, chunkBits := ChunkConfig(curve)
+	s := new(big.Int).SetBytes(scalar)
+	if s.BitLen() > numChunks*chunkBits {
+		return nil, fmt.Errorf("scalar of %d bits does not fit in %d chunks of %d bits", s.BitLen(), numChunks, chunkBits)
+	}
+
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(chunkBits)), big.NewInt(1))
+	chunks := make([]*big.Int, numChunks)
+	for i := numChunks - 1; i >= 0; i-- {
+		chunks[i] = new(big.Int).And(s, mask)
+		s.Rsh(s, uint(chunkBits))
+	}
+	return chunks, nil
 }
 
 type TemplateData struct {
@@ -42,11 +92,16 @@ type TemplateData struct {
 }
 
 func (c *Circuit) Define(api frontend.API) error {
-	// Compute 2^128 as a *big.Int constant.
-	exp128 := new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil)
+	if len(c.SkChunks) == 0 {
+		return errors.New("circuit has no scalar chunks")
+	}
 
-	// Reconstruct private key = chunk1 * 2^128 + chunk2
-	fullSk := api.Add(api.Mul(c.SkChunk1, exp128), c.SkChunk2)
+	// Reconstruct private key = Σ chunk[i] * 2^(128 * (n-1-i)), Horner style
+	shift := new(big.Int).Lsh(big.NewInt(1), scalarChunkBits)
+	fullSk := c.SkChunks[0]
+	for _, chunk := range c.SkChunks[1:] {
+		fullSk = api.Add(api.Mul(fullSk, shift), chunk)
+	}
 
 	// Dummy check: sum of x+y must equal fullSk
 	sum := api.Add(c.Pk.A.X, c.Pk.A.Y)
@@ -56,92 +111,123 @@ func (c *Circuit) Define(api frontend.API) error {
 }
 
 // parseKeys is just an example; you can adapt to real usage
-func parseKeys(id ecc.ID, pubKeyBuf []byte, privKeyBuf []byte) ([]byte, []byte, []byte) {
+func parseKeys(id ecc.ID, pubKeyBuf []byte, privKeyBuf []byte) ([]byte, []byte, []byte, error) {
+	if err := checkCurve(id); err != nil {
+		return nil, nil, nil, err
+	}
+	scalarLen := (id.ScalarField().BitLen() + 7) / 8
+	if len(privKeyBuf) < scalarLen {
+		return nil, nil, nil, fmt.Errorf("private key of %d bytes is too short", len(privKeyBuf))
+	}
+
 	switch id {
 	case ecc.BN254:
 		var priv eddsa_bn254.G1Affine
 		priv.SetBytes(privKeyBuf)
 		aX := priv.X.Bytes()
 		aY := priv.Y.Bytes()
-		scalar := privKeyBuf[:32]
-		return aX[:], aY[:], scalar[:]
+		scalar := privKeyBuf[:scalarLen]
+		return aX[:], aY[:], scalar[:], nil
 
 	case ecc.BLS12_381:
 		var priv eddsa_bls12381.G1Affine
 		priv.SetBytes(privKeyBuf)
 		aX := priv.X.Bytes()
 		aY := priv.Y.Bytes()
-		scalar := privKeyBuf[:32]
-		return aX[:], aY[:], scalar[:]
+		scalar := privKeyBuf[:scalarLen]
+		return aX[:], aY[:], scalar[:], nil
 
 	case ecc.BLS12_377:
 		var priv eddsa_bls12377.G1Affine
 		priv.SetBytes(privKeyBuf)
 		aX := priv.X.Bytes()
 		aY := priv.Y.Bytes()
-		scalar := privKeyBuf[:32]
-		return aX[:], aY[:], scalar[:]
+		scalar := privKeyBuf[:scalarLen]
+		return aX[:], aY[:], scalar[:], nil
 	case ecc.BW6_761:
 		var priv bw6761.G1Affine
 		priv.SetBytes(privKeyBuf)
 		aX := priv.X.Bytes()
 		aY := priv.Y.Bytes()
-		scalar := privKeyBuf[:32]
-		return aX[:], aY[:], scalar[:]
+		scalar := privKeyBuf[:scalarLen]
+		return aX[:], aY[:], scalar[:], nil
 	default:
-		// fallback
-		return pubKeyBuf, pubKeyBuf, privKeyBuf
+		return nil, nil, nil, fmt.Errorf("%w: %s", ErrCurveUnsupported, id)
 	}
 }
 
-func generateTemplate(prod string, fs embed.FS) error {
-	tmpl := template.New(prod)
+// implementedCurves lists the curves compiled into gnark-crypto; kept as a variable so tests can
+// simulate a build without some of them.
+var implementedCurves = ecc.Implemented
+
+// checkCurve returns ErrCurveUnsupported unless id has a signature scheme implementation and is
+// compiled into gnark-crypto.
+func checkCurve(id ecc.ID) error {
+	if !slices.Contains(SignatureSchemeImplemented(), id) || !slices.Contains(implementedCurves(), id) {
+		return fmt.Errorf("%w: %s", ErrCurveUnsupported, id)
+	}
+	return nil
+}
+
+// generatedTemplates are the templates generateTemplate executes, in order, into each generated file.
+var generatedTemplates = []string{
+	"eddsa.go.tmpl",
+	"doc.go.tmpl",
+	"eddsa.test.go.tmpl",
+	"marshal.go.tmpl",
+}
+
+// generateTemplate executes the embedded templates for every supported curve into
+// generated_<curve>_<prod>.go files in outDir.
+func generateTemplate(prod string, outDir string) error {
 	// IMPORTANT: use .EnumID in the .tmpl to compare strings like "bw6_761"
-	tmpl.ParseFS(
-		fs,
-		"template/eddsa.go.tmpl",
-		"template/eddsa.test.go.tmpl",
-		"template/marshal.go.tmpl",
-		"template/doc.go.tmpl",
-	)
+	tmpl, err := resources.ParseTemplates(prod)
+	if err != nil {
+		return err
+	}
 
 	for _, curve := range SignatureSchemeImplemented() {
+		if err := checkCurve(curve); err != nil {
+			log.Printf("warning: skipping template generation: %v", err)
+			continue
+		}
+
 		data := TemplateData{
 			Name:    curve,
 			Package: "verifyKey",
 			EnumID:  curve.String(), // e.g. "bn254", "bls12_377", "bw6_761", etc.
 		}
 
-		dir, _ := os.Getwd()
-
-		f, err := os.Create(dir + "/generated_" + curve.String() + "_" + prod + ".go")
-		if err != nil {
-			log.Fatalf("error creating file %s: %v", "generated_"+curve.String()+"_"+prod+".go", err)
+		name := "generated_" + curve.String() + "_" + prod + ".go"
+		if err := executeTemplates(tmpl, filepath.Join(outDir, name), data); err != nil {
+			return err
 		}
-		defer f.Close()
+	}
+	return nil
+}
 
-		if err := tmpl.ExecuteTemplate(f, "eddsa.go.tmpl", data); err != nil {
-			fmt.Println("error executing template eddsa.go.tmpl: ", err)
-		}
-		if err := tmpl.ExecuteTemplate(f, "doc.go.tmpl", data); err != nil {
-			fmt.Println("error executing template doc.go.tmpl: ", err)
-		}
-		if err := tmpl.ExecuteTemplate(f, "eddsa.test.go.tmpl", data); err != nil {
-			fmt.Println("error executing template eddsa.test.go.tmpl: ", err)
-		}
-		if err := tmpl.ExecuteTemplate(f, "marshal.go.tmpl", data); err != nil {
-			fmt.Println("error executing template marshal.go.tmpl: ", err)
+// executeTemplates writes generatedTemplates, executed with data, to path.
+func executeTemplates(tmpl *template.Template, path string, data TemplateData) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	for _, name := range generatedTemplates {
+		if err := tmpl.ExecuteTemplate(f, name, data); err != nil {
+			return fmt.Errorf("error executing template %s: %w", name, err)
 		}
 	}
-	return nil
+	return f.Close()
 }
 
 func BlsVerify() {
 	eccs := ecc.Implemented()
 	fmt.Println("Available curves in gnark-crypto:", eccs)
 
-	// Example of parsing embedded templates
-	err := generateTemplate("prod", eddsaTemplateFiles)
+	// Example of executing the embedded templates, into the working directory
+	err := generateTemplate("prod", ".")
 	if err != nil {
 		log.Fatalf("error generating template: %v", err)
 	}
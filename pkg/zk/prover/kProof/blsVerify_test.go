@@ -1,11 +1,13 @@
 package kProof
 
 import (
-	"embed"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -14,9 +16,6 @@ import (
 	"github.com/consensys/gnark/test"
 )
 
-//go:embed template/*.tmpl
-var eddsaTemplateFiles_test embed.FS
-
 func BlsVerifyTest(t *testing.T) {
 	eccs := ecc.Implemented()
 	fmt.Println("Available curves in gnark-crypto:", eccs)
@@ -24,7 +23,7 @@ func BlsVerifyTest(t *testing.T) {
 	t.Run("TestOwnershipSk", func(t *testing.T) {
 		assert := test.NewAssert(t)
 		// Example of parsing embedded templates
-		err := generateTemplate("test", eddsaTemplateFiles_test)
+		err := generateTemplate("test", t.TempDir())
 		if err != nil {
 			log.Fatalf("error generating template: %v", err)
 		}
@@ -35,17 +34,9 @@ func BlsVerifyTest(t *testing.T) {
 			r := rand.New(src)
 
 			// ~~~~~ 1) Compile the circuit
-			ownershipSkCircuit := &Circuit{
-				Pk: struct {
-					A struct {
-						X frontend.Variable
-						Y frontend.Variable
-					}
-				}{},
-				SkChunk1: frontend.Variable(0),
-				SkChunk2: frontend.Variable(0),
-			}
-			_, err := frontend.Compile(id.ScalarField(), r1cs.NewBuilder, ownershipSkCircuit)
+			ownershipSkCircuit, err := NewCircuit(id)
+			assert.NoError(err)
+			_, err = frontend.Compile(id.ScalarField(), r1cs.NewBuilder, ownershipSkCircuit)
 			assert.NoError(err)
 
 			// ~~~~~ 2) Get pubkey + privkey (stub).
@@ -60,18 +51,19 @@ func BlsVerifyTest(t *testing.T) {
 			fakePub := make([]byte, 64) // or whatever
 			r.Read(fakePub)
 
-			pubkeyAx, pubkeyAy, privScalar := parseKeys(id, fakePub, fakePriv)
+			pubkeyAx, pubkeyAy, privScalar, err := parseKeys(id, fakePub, fakePriv)
+			assert.NoError(err)
 
 			// ~~~~~ 3) Construct the witness
 			witness := &Circuit{}
 			witness.Pk.A.X = pubkeyAx
 			witness.Pk.A.Y = pubkeyAy
 
-			// chunk1 = first 16 bytes => big.Int
-			chunk1 := new(big.Int).SetBytes(privScalar[:16])
-			chunk2 := new(big.Int).SetBytes(privScalar[16:32]) // or [16:48] for BW6_761
-			witness.SkChunk1 = chunk1
-			witness.SkChunk2 = chunk2
+			chunks, err := SplitScalar(id, privScalar)
+			assert.NoError(err)
+			for _, chunk := range chunks {
+				witness.SkChunks = append(witness.SkChunks, chunk)
+			}
 
 			// ~~~~~ 4) Solve & Prove
 			assert.SolvingSucceeded(ownershipSkCircuit, witness)
@@ -79,3 +71,94 @@ func BlsVerifyTest(t *testing.T) {
 		}
 	})
 }
+
+func TestParseKeysUnsupportedCurve(t *testing.T) {
+	buf := make([]byte, 48)
+
+	// a curve gnark-crypto implements, but without a signature scheme here
+	if _, _, _, err := parseKeys(ecc.BLS24_315, buf, buf); !errors.Is(err, ErrCurveUnsupported) {
+		t.Fatalf("expected %v, got %v", ErrCurveUnsupported, err)
+	}
+
+	// a build of gnark-crypto without BN254
+	defer func(f func() []ecc.ID) { implementedCurves = f }(implementedCurves)
+	implementedCurves = func() []ecc.ID { return []ecc.ID{ecc.BLS12_381} }
+
+	if _, _, _, err := parseKeys(ecc.BN254, buf, buf); !errors.Is(err, ErrCurveUnsupported) {
+		t.Fatalf("expected %v, got %v", ErrCurveUnsupported, err)
+	}
+	if _, _, _, err := parseKeys(ecc.BLS12_381, buf, buf); err != nil {
+		t.Fatalf("unable to parse keys of a supported curve: %v", err)
+	}
+}
+
+func TestChunkConfig(t *testing.T) {
+	want := map[ecc.ID]int{
+		ecc.BN254:     2,
+		ecc.BLS12_381: 2,
+		ecc.BLS12_377: 2,
+		ecc.BW6_761:   3,
+	}
+
+	for _, id := range SignatureSchemeImplemented() {
+		numChunks, chunkBits := ChunkConfig(id)
+		if numChunks != want[id] || chunkBits != 128 {
+			t.Fatalf("%s: %d chunks of %d bits, want %d chunks of 128 bits", id, numChunks, chunkBits, want[id])
+		}
+
+		for _, scalar := range []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			new(big.Int).Lsh(big.NewInt(1), 128),
+			new(big.Int).Sub(id.ScalarField(), big.NewInt(1)),
+		} {
+			chunks, err := SplitScalar(id, scalar.Bytes())
+			if err != nil {
+				t.Fatalf("%s: unable to split scalar: %v", id, err)
+			}
+			if len(chunks) != numChunks {
+				t.Fatalf("%s: split into %d chunks, want %d", id, len(chunks), numChunks)
+			}
+
+			reconstructed := new(big.Int)
+			for _, chunk := range chunks {
+				if chunk.BitLen() > chunkBits {
+					t.Fatalf("%s: chunk of %d bits", id, chunk.BitLen())
+				}
+				reconstructed.Lsh(reconstructed, uint(chunkBits)).Add(reconstructed, chunk)
+			}
+			if reconstructed.Cmp(scalar) != 0 {
+				t.Fatalf("%s: reconstructed %s, want %s", id, reconstructed, scalar)
+			}
+		}
+	}
+
+	if numChunks, chunkBits := ChunkConfig(ecc.BLS24_315); numChunks != 0 || chunkBits != 0 {
+		t.Fatalf("unsupported curve configured with %d chunks of %d bits", numChunks, chunkBits)
+	}
+	if _, err := SplitScalar(ecc.BN254, make([]byte, 40)); err != nil {
+		t.Fatalf("unable to split a zero padded scalar: %v", err)
+	}
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 256)
+	if _, err := SplitScalar(ecc.BN254, tooLarge.Bytes()); err == nil {
+		t.Fatal("expected an error for a scalar larger than the chunks")
+	}
+}
+
+func TestGenerateTemplate(t *testing.T) {
+	out := t.TempDir()
+	t.Chdir(t.TempDir())
+
+	if err := generateTemplate("test", out); err != nil {
+		t.Fatalf("unable to generate templates: %v", err)
+	}
+	for _, curve := range SignatureSchemeImplemented() {
+		info, err := os.Stat(filepath.Join(out, "generated_"+curve.String()+"_test.go"))
+		if err != nil {
+			t.Fatalf("no file generated for %s: %v", curve, err)
+		}
+		if info.Size() == 0 {
+			t.Fatalf("file generated for %s is empty", curve)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package kProof
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	eddsa_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards/eddsa"
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	eddsa_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards/eddsa"
+	_ "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	eddsa_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	_ "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	eddsa_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/twistededwards/eddsa"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+)
+
+// EdwardsCurve returns the twisted Edwards curve whose base field is the scalar field of curve,
+// i.e. the curve EdDSA keys of the ownership circuit live on.
+func EdwardsCurve(curve ecc.ID) (tedwards.ID, error) {
+	if err := checkCurve(curve); err != nil {
+		return 0, err
+	}
+
+	switch curve {
+	case ecc.BN254:
+		return tedwards.BN254, nil
+	case ecc.BLS12_381:
+		return tedwards.BLS12_381, nil
+	case ecc.BLS12_377:
+		return tedwards.BLS12_377, nil
+	case ecc.BW6_761:
+		return tedwards.BW6_761, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrCurveUnsupported, curve)
+	}
+}
+
+// MiMC returns the MiMC hash over the scalar field of curve, which EdDSA signatures of the
+// ownership circuit are computed with since it is cheap to verify in a circuit.
+func MiMC(curve ecc.ID) (hash.Hash, error) {
+	if err := checkCurve(curve); err != nil {
+		return 0, err
+	}
+
+	switch curve {
+	case ecc.BN254:
+		return hash.MIMC_BN254, nil
+	case ecc.BLS12_381:
+		return hash.MIMC_BLS12_381, nil
+	case ecc.BLS12_377:
+		return hash.MIMC_BLS12_377, nil
+	case ecc.BW6_761:
+		return hash.MIMC_BW6_761, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrCurveUnsupported, curve)
+	}
+}
+
+// GenerateEdDSAKey generates an EdDSA key pair on the twisted Edwards curve of curve, see
+// EdwardsCurve, reading randomness from rng. Both keys are returned in gnark-crypto's encoding.
+func GenerateEdDSAKey(curve ecc.ID, rng io.Reader) (priv, pub []byte, err error) {
+	id, err := EdwardsCurve(curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := eddsa.New(id, rng)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate eddsa key: %w", err)
+	}
+
+	return key.Bytes(), key.Public().Bytes(), nil
+}
+
+// SignEdDSA signs msg with the private key returned by GenerateEdDSAKey, hashing with MiMC. Since
+// MiMC absorbs field elements, msg must be a sequence of canonically encoded field elements of the
+// scalar field of curve, e.g. fr.Element.Marshal().
+func SignEdDSA(curve ecc.ID, priv, msg []byte) ([]byte, error) {
+	key, err := newPrivateKey(curve)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := key.SetBytes(priv); err != nil {
+		return nil, fmt.Errorf("unable to decode eddsa private key: %w", err)
+	}
+
+	h, err := MiMC(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := key.Sign(msg, h.New())
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign: %w", err)
+	}
+
+	return sig, nil
+}
+
+// VerifyEdDSA verifies off-circuit a signature created by SignEdDSA.
+func VerifyEdDSA(curve ecc.ID, pub, sig, msg []byte) (bool, error) {
+	key, err := newPrivateKey(curve)
+	if err != nil {
+		return false, err
+	}
+	publicKey := key.Public()
+	if _, err := publicKey.SetBytes(pub); err != nil {
+		return false, fmt.Errorf("unable to decode eddsa public key: %w", err)
+	}
+
+	h, err := MiMC(curve)
+	if err != nil {
+		return false, err
+	}
+
+	return publicKey.Verify(sig, msg, h.New())
+}
+
+// newPrivateKey returns an empty EdDSA private key of the twisted Edwards curve of curve.
+func newPrivateKey(curve ecc.ID) (signature.Signer, error) {
+	if err := checkCurve(curve); err != nil {
+		return nil, err
+	}
+
+	switch curve {
+	case ecc.BN254:
+		return new(eddsa_bn254.PrivateKey), nil
+	case ecc.BLS12_381:
+		return new(eddsa_bls12381.PrivateKey), nil
+	case ecc.BLS12_377:
+		return new(eddsa_bls12377.PrivateKey), nil
+	case ecc.BW6_761:
+		return new(eddsa_bw6761.PrivateKey), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrCurveUnsupported, curve)
+	}
+}
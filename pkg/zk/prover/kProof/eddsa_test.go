@@ -0,0 +1,107 @@
+package kProof
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/consensys/gnark/test"
+)
+
+// newMessage returns a random scalar field element of curve, encoded as MiMC expects it.
+func newMessage(t *testing.T, curve ecc.ID) (*big.Int, []byte) {
+	t.Helper()
+
+	field := curve.ScalarField()
+	m, err := rand.Int(rand.Reader, field)
+	if err != nil {
+		t.Fatalf("unable to sample message: %v", err)
+	}
+	return m, m.FillBytes(make([]byte, (field.BitLen()+7)/8))
+}
+
+func TestEdDSA(t *testing.T) {
+	for _, curve := range SignatureSchemeImplemented() {
+		t.Run(curve.String(), func(t *testing.T) {
+			priv, pub, err := GenerateEdDSAKey(curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("unable to generate key: %v", err)
+			}
+
+			_, msg := newMessage(t, curve)
+			sig, err := SignEdDSA(curve, priv, msg)
+			if err != nil {
+				t.Fatalf("unable to sign: %v", err)
+			}
+
+			ok, err := VerifyEdDSA(curve, pub, sig, msg)
+			if err != nil || !ok {
+				t.Fatalf("valid signature rejected: %v", err)
+			}
+
+			_, other := newMessage(t, curve)
+			if ok, _ := VerifyEdDSA(curve, pub, sig, other); ok {
+				t.Fatal("signature accepted for another message")
+			}
+		})
+	}
+
+	if _, _, err := GenerateEdDSAKey(ecc.BLS24_315, rand.Reader); !errors.Is(err, ErrCurveUnsupported) {
+		t.Fatalf("expected %v, got %v", ErrCurveUnsupported, err)
+	}
+}
+
+// eddsaCircuit verifies an EdDSA signature of Message with MiMC over BN254.
+type eddsaCircuit struct {
+	PublicKey eddsa.PublicKey `gnark:",public"`
+	Signature eddsa.Signature `gnark:",public"`
+	Message   frontend.Variable
+}
+
+func (c *eddsaCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return eddsa.Verify(curve, c.Signature, c.Message, c.PublicKey, &h)
+}
+
+func TestEdDSAInCircuit(t *testing.T) {
+	priv, pub, err := GenerateEdDSAKey(ecc.BN254, rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	m, msg := newMessage(t, ecc.BN254)
+	sig, err := SignEdDSA(ecc.BN254, priv, msg)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	if ok, err := VerifyEdDSA(ecc.BN254, pub, sig, msg); err != nil || !ok {
+		t.Fatalf("signature rejected off-circuit: %v", err)
+	}
+
+	var assignment eddsaCircuit
+	assignment.PublicKey.Assign(tedwards.BN254, pub)
+	assignment.Signature.Assign(tedwards.BN254, sig)
+	assignment.Message = m
+
+	if err := test.IsSolved(&eddsaCircuit{}, &assignment, ecc.BN254.ScalarField()); err != nil {
+		t.Fatalf("signature rejected in circuit: %v", err)
+	}
+
+	assignment.Message = new(big.Int).Add(m, big.NewInt(1))
+	if err := test.IsSolved(&eddsaCircuit{}, &assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Fatal("signature of another message accepted in circuit")
+	}
+}
@@ -0,0 +1,40 @@
+package circuit
+
+import (
+	"github.com/consensys/gnark/constraint"
+)
+
+const (
+	// provingVectors is the number of field element vectors of domain size the Groth16 prover keeps
+	// alive at once: the wire assignment, the A, B and C evaluations and the coset buffers of the quotient.
+	provingVectors = 6
+	// g1PointsPerWire is the number of G1 points of the proving key per wire (A, B and K).
+	g1PointsPerWire = 3
+)
+
+// EstimateProvingMemory returns a rough upper bound, in bytes, of the memory needed to prove the
+// given constraint system with Groth16. It accounts for the proving key points and the vectors used
+// while computing the quotient, so it scales with the number of constraints and wires; the exact
+// figure depends on the backend and the number of parallel tasks.
+func EstimateProvingMemory(r1cs constraint.ConstraintSystem) uint64 {
+	fieldBytes := uint64((r1cs.FieldBitLen() + 7) / 8)
+
+	wires := uint64(r1cs.GetNbInternalVariables() + r1cs.GetNbSecretVariables() + r1cs.GetNbPublicVariables())
+	domain := nextPowerOfTwo(uint64(r1cs.GetNbConstraints() + r1cs.GetNbPublicVariables()))
+
+	g1Size := 2 * fieldBytes // affine coordinates over the base field
+	g2Size := 2 * g1Size     // coordinates over the quadratic extension
+
+	provingKey := (g1PointsPerWire*wires+domain)*g1Size + wires*g2Size
+	vectors := provingVectors * domain * fieldBytes
+
+	return provingKey + vectors
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	p := uint64(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
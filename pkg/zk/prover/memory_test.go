@@ -0,0 +1,41 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// powerCircuit asserts that X^n == Y, using n-1 multiplication constraints.
+type powerCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+	n int
+}
+
+func (c *powerCircuit) Define(api frontend.API) error {
+	res := c.X
+	for i := 1; i < c.n; i++ {
+		res = api.Mul(res, c.X)
+	}
+	api.AssertIsEqual(res, c.Y)
+	return nil
+}
+
+func TestEstimateProvingMemory(t *testing.T) {
+	var previous uint64
+	for _, n := range []int{10, 1000, 10000} {
+		cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{n: n})
+		if err != nil {
+			t.Fatalf("unable to compile circuit: %v", err)
+		}
+
+		estimate := EstimateProvingMemory(cs)
+		if estimate <= previous {
+			t.Fatalf("estimate for %d constraints is %d, not larger than %d", cs.GetNbConstraints(), estimate, previous)
+		}
+		previous = estimate
+	}
+}
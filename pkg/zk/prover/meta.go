@@ -0,0 +1,96 @@
+package circuit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/consensys/gnark"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	gnarkio "github.com/consensys/gnark/io"
+)
+
+// ProofMetaFile is the name of the metadata file written next to the proof artifacts.
+const ProofMetaFile = "proof_meta.json"
+
+var (
+	// ErrCircuitMismatch denotes that a proof was generated for a different circuit than the verifier expects.
+	ErrCircuitMismatch = errors.New("circuit id mismatch")
+)
+
+// ProofMeta records how a proof was generated so verifiers can detect mismatched artifacts.
+type ProofMeta struct {
+	CircuitID     string    `json:"circuit_id"`               // identifier (name and version) of the proven circuit
+	Curve         string    `json:"curve"`                    // curve the circuit was compiled over
+	GnarkVersion  string    `json:"gnark_version"`            // gnark version used to generate the proof
+	Backend       string    `json:"backend"`                  // proving backend, e.g. groth16
+	VKFingerprint string    `json:"vk_fingerprint,omitempty"` // fingerprint of the verifying key, see VKFingerprint
+	CreatedAt     time.Time `json:"created_at"`               // generation timestamp
+}
+
+// NewProofMeta returns the metadata of a proof generated now with the linked gnark version.
+func NewProofMeta(circuitID string, curve ecc.ID, backendID backend.ID) ProofMeta {
+	return ProofMeta{
+		CircuitID:    circuitID,
+		Curve:        curve.String(),
+		GnarkVersion: gnark.Version.String(),
+		Backend:      backendID.String(),
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+// WriteProofMeta writes meta as proof_meta.json into outDir.
+func WriteProofMeta(outDir string, meta ProofMeta) error {
+	return WriteProofMetaTo(DirWriter(outDir), meta)
+}
+
+// WriteProofMetaTo is WriteProofMeta writing the artifact with write.
+func WriteProofMetaTo(write WriteFileFunc, meta ProofMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal proof metadata: %w", err)
+	}
+
+	if err := write(ProofMetaFile, data); err != nil {
+		return fmt.Errorf("unable to write proof metadata: %w", err)
+	}
+
+	return nil
+}
+
+// ReadProofMeta reads proof_meta.json from dir.
+func ReadProofMeta(dir string) (ProofMeta, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ProofMetaFile))
+	if err != nil {
+		return ProofMeta{}, fmt.Errorf("unable to read proof metadata: %w", err)
+	}
+
+	var meta ProofMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return ProofMeta{}, fmt.Errorf("unable to unmarshal proof metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// VerifyMeta checks that meta was produced for the circuit the verifying key belongs to: the
+// circuit id must be the expected one and, when meta records a verifying key fingerprint, as
+// ProveAndExport does, it must be the one of vk, see VerifyVKFingerprint.
+func VerifyMeta(meta ProofMeta, expectedCircuitID string, vk gnarkio.WriterRawTo) error {
+	if meta.CircuitID != expectedCircuitID {
+		return fmt.Errorf("%w: proof was generated for %q, verifying key expects %q",
+			ErrCircuitMismatch, meta.CircuitID, expectedCircuitID)
+	}
+
+	if meta.VKFingerprint == "" {
+		return nil
+	}
+	if vk == nil {
+		return fmt.Errorf("%w: metadata records fingerprint %q, no verifying key given", ErrVKMismatch, meta.VKFingerprint)
+	}
+	return VerifyVKFingerprint(meta, vk)
+}
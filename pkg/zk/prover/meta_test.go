@@ -0,0 +1,76 @@
+package circuit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestProofMetaRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	meta := NewProofMeta("pedersen/v1", ecc.BN254, backend.GROTH16)
+	if err := WriteProofMeta(dir, meta); err != nil {
+		t.Fatalf("unable to write metadata: %v", err)
+	}
+
+	got, err := ReadProofMeta(dir)
+	if err != nil {
+		t.Fatalf("unable to read metadata: %v", err)
+	}
+	if got.CircuitID != "pedersen/v1" || got.Curve != "bn254" || got.Backend != "groth16" || got.GnarkVersion == "" {
+		t.Fatalf("unexpected metadata %+v", got)
+	}
+	if !got.CreatedAt.Equal(meta.CreatedAt) {
+		t.Fatalf("timestamp %s, want %s", got.CreatedAt, meta.CreatedAt)
+	}
+}
+
+func TestVerifyMetaCircuitMismatch(t *testing.T) {
+	meta := NewProofMeta("pedersen/v1", ecc.BN254, backend.GROTH16)
+	if err := VerifyMeta(meta, "pedersen/v1", nil); err != nil {
+		t.Fatalf("matching circuit id rejected: %v", err)
+	}
+	if err := VerifyMeta(meta, "pedersen/v2", nil); !errors.Is(err, ErrCircuitMismatch) {
+		t.Fatalf("expected %v, got %v", ErrCircuitMismatch, err)
+	}
+}
+
+func TestVerifyMetaFingerprint(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{n: 4})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	_, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	_, other, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+
+	meta := NewProofMeta("power/v1", ecc.BN254, backend.GROTH16)
+	if meta.VKFingerprint, err = VKFingerprint(vk); err != nil {
+		t.Fatalf("unable to fingerprint vk: %v", err)
+	}
+	if err := VerifyMeta(meta, "power/v1", vk); err != nil {
+		t.Fatalf("matching verifying key rejected: %v", err)
+	}
+
+	// another setup of the same circuit, under the same circuit id, is a mismatch
+	if err := VerifyMeta(meta, "power/v1", other); !errors.Is(err, ErrVKMismatch) {
+		t.Fatalf("expected %v, got %v", ErrVKMismatch, err)
+	}
+	if err := VerifyMeta(meta, "power/v1", nil); !errors.Is(err, ErrVKMismatch) {
+		t.Fatalf("recorded fingerprint without verifying key: expected %v, got %v", ErrVKMismatch, err)
+	}
+	if err := VerifyMeta(meta, "power/v2", vk); !errors.Is(err, ErrCircuitMismatch) {
+		t.Fatalf("expected %v, got %v", ErrCircuitMismatch, err)
+	}
+}
@@ -0,0 +1,69 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+// ErrNonceReplayed denotes a proof whose nonce has already been accepted.
+var ErrNonceReplayed = errors.New("nonce already used")
+
+// ProveWithNonce proves knowledge of the opening (m, r) of a PedersenCircuit commitment with the
+// keys of GetOrSetup over curve, binding nonce into the proof. It returns the proof along with its
+// public witness, which holds the commitment and the nonce.
+func ProveWithNonce(curve ecc.ID, m, r, nonce *big.Int) (groth16.Proof, witness.Witness, error) {
+	keys, err := GetOrSetup(curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	full, err := frontend.NewWitness(NewPedersenNonceAssignment(m, r, nonce), curve.ScalarField())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+	public, err := full.Public()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to extract public witness: %w", err)
+	}
+
+	proof, err := groth16.Prove(keys.CS, keys.ProvingKey, full)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to prove: %w", err)
+	}
+
+	return proof, public, nil
+}
+
+// ReplayGuard remembers the nonces of accepted proofs. It is safe for concurrent use.
+type ReplayGuard struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewReplayGuard returns a guard that has seen no nonce yet.
+func NewReplayGuard() *ReplayGuard {
+	return &ReplayGuard{seen: make(map[string]struct{})}
+}
+
+// Accept records nonce, or returns ErrNonceReplayed if it was recorded before. Verifiers call it
+// once the proof verified against a public witness holding nonce, so a rejected proof does not
+// burn its nonce.
+func (g *ReplayGuard) Accept(nonce *big.Int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := nonce.String()
+	if _, ok := g.seen[key]; ok {
+		return fmt.Errorf("%w: %s", ErrNonceReplayed, key)
+	}
+	g.seen[key] = struct{}{}
+
+	return nil
+}
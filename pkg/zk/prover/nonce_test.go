@@ -0,0 +1,54 @@
+package circuit
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+func TestProveWithNonce(t *testing.T) {
+	keys, err := GetOrSetup(ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to set up: %v", err)
+	}
+
+	m, r := big.NewInt(1234), big.NewInt(5678)
+	proofA, publicA, err := ProveWithNonce(ecc.BN254, m, r, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("unable to prove with nonce 1: %v", err)
+	}
+	proofB, publicB, err := ProveWithNonce(ecc.BN254, m, r, big.NewInt(2))
+	if err != nil {
+		t.Fatalf("unable to prove with nonce 2: %v", err)
+	}
+
+	if reflect.DeepEqual(publicA.Vector(), publicB.Vector()) {
+		t.Fatal("public witnesses of different nonces are equal")
+	}
+	if err := groth16.Verify(proofA, keys.VerifyingKey, publicA); err != nil {
+		t.Fatalf("proof with nonce 1 rejected: %v", err)
+	}
+	if err := groth16.Verify(proofB, keys.VerifyingKey, publicB); err != nil {
+		t.Fatalf("proof with nonce 2 rejected: %v", err)
+	}
+
+	// the nonce is bound to the proof: it cannot be swapped for a fresh one
+	if err := groth16.Verify(proofA, keys.VerifyingKey, publicB); err == nil {
+		t.Fatal("proof with nonce 1 accepted for nonce 2")
+	}
+
+	guard := NewReplayGuard()
+	if err := guard.Accept(big.NewInt(1)); err != nil {
+		t.Fatalf("fresh nonce rejected: %v", err)
+	}
+	if err := guard.Accept(big.NewInt(2)); err != nil {
+		t.Fatalf("fresh nonce rejected: %v", err)
+	}
+	if err := guard.Accept(big.NewInt(1)); !errors.Is(err, ErrNonceReplayed) {
+		t.Fatalf("expected %v, got %v", ErrNonceReplayed, err)
+	}
+}
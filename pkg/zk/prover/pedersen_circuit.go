@@ -0,0 +1,249 @@
+package circuit
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	poseidon2_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
+	twistededwards_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	poseidon2 "github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+const (
+	// pedersenDomain seeds the derivation of the second Pedersen generator H.
+	pedersenDomain = "keyless/pedersen/H"
+
+	// Poseidon2 parameters used to hash pre-images: a width 3 state absorbing two elements per
+	// permutation, with 8 full and 56 partial rounds of the degree 5 s-box.
+	poseidonWidth         = 3
+	poseidonRate          = 2
+	poseidonDegree        = 5
+	poseidonFullRounds    = 8
+	poseidonPartialRounds = 56
+	poseidonSeed          = "keyless/poseidon2"
+)
+
+// PedersenCircuit proves knowledge of the opening of the Pedersen commitment C = M·G + R·H on the
+// twisted Edwards curve embedded in BN254 (Baby Jubjub). G is the curve base point and H is derived
+// by hashing to the curve, see PedersenGenerators.
+//
+// In pre-image mode, i.e. when PreImage is allocated, M is additionally constrained to be the
+// Poseidon2 hash of PreImage, see PoseidonHash, so the proof attests to knowledge of the pre-image.
+//
+// Nonce is a public value chosen by the prover and bound into the proof, so that a verifier can
+// reject a proof replayed with a nonce it has already seen, see ReplayGuard.
+//
+// In commitment mode, i.e. when Committed is set, the secret inputs are additionally committed to
+// with gnark's in-circuit commitment API. The Groth16 backend then adds a Pedersen commitment to
+// them and its proof of knowledge to the proof, checked by groth16.Verify against the commitment
+// key of the verifying key, instead of the secret inputs only being bound through the public
+// ones. Such proofs have no Solidity calldata encoding, see verifier.NewProofCalldata.
+type PedersenCircuit struct {
+	C        twistededwards.Point `gnark:",public"`
+	Nonce    frontend.Variable    `gnark:",public"`
+	M        frontend.Variable
+	R        frontend.Variable
+	PreImage []frontend.Variable
+
+	Committed bool `gnark:"-"`
+}
+
+// NewPedersenCircuit returns the circuit definition, hashing a pre-image of preImageLen elements
+// into M, or taking M directly if preImageLen is 0.
+func NewPedersenCircuit(preImageLen int) *PedersenCircuit {
+	return &PedersenCircuit{PreImage: make([]frontend.Variable, preImageLen)}
+}
+
+// NewPedersenCommittedCircuit is NewPedersenCircuit in commitment mode. Assignments are the same as
+// for the circuit of NewPedersenCircuit.
+func NewPedersenCommittedCircuit(preImageLen int) *PedersenCircuit {
+	c := NewPedersenCircuit(preImageLen)
+	c.Committed = true
+	return c
+}
+
+func (c *PedersenCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+
+	if len(c.PreImage) > 0 {
+		digest, err := poseidonHashCircuit(api, c.PreImage)
+		if err != nil {
+			return err
+		}
+		api.AssertIsEqual(c.M, digest)
+	}
+
+	assertPedersenOpening(api, curve, c.C, c.M, c.R)
+
+	// a public input that appears in no constraint has no term in the verifying key, so the proof
+	// would verify for any nonce; squaring it ties it to the proof
+	api.Mul(c.Nonce, c.Nonce)
+
+	if c.Committed {
+		return commitSecrets(api, append([]frontend.Variable{c.M, c.R}, c.PreImage...))
+	}
+
+	return nil
+}
+
+// commitSecrets commits to secrets with the in-circuit commitment API of the builder. The
+// challenge derived from the commitment must be used in a constraint for the commitment to be kept,
+// and is non-zero except with negligible probability.
+func commitSecrets(api frontend.API, secrets []frontend.Variable) error {
+	committer, ok := api.(frontend.Committer)
+	if !ok {
+		return fmt.Errorf("%w: builder has no commitment support", ErrUnsupportedProofSystem)
+	}
+
+	challenge, err := committer.Commit(secrets...)
+	if err != nil {
+		return fmt.Errorf("unable to commit to secret inputs: %w", err)
+	}
+	api.AssertIsDifferent(challenge, 0)
+
+	return nil
+}
+
+// assertPedersenOpening constrains c to be the commitment m·G + r·H, see PedersenCommit.
+func assertPedersenOpening(api frontend.API, curve twistededwards.Curve, c twistededwards.Point, m, r frontend.Variable) {
+	g, h := PedersenGenerators()
+	commitment := curve.DoubleBaseScalarMul(
+		twistededwards.Point{X: g.X, Y: g.Y},
+		twistededwards.Point{X: h.X, Y: h.Y},
+		m, r,
+	)
+	api.AssertIsEqual(commitment.X, c.X)
+	api.AssertIsEqual(commitment.Y, c.Y)
+}
+
+// NewPedersenAssignment returns the assignment of a PedersenCircuit without pre-image committing to m
+// with randomness r, with a zero nonce.
+func NewPedersenAssignment(m, r *big.Int) *PedersenCircuit {
+	return NewPedersenNonceAssignment(m, r, new(big.Int))
+}
+
+// NewPedersenNonceAssignment is NewPedersenAssignment binding nonce into the proof.
+func NewPedersenNonceAssignment(m, r, nonce *big.Int) *PedersenCircuit {
+	c := PedersenCommit(m, r)
+	return &PedersenCircuit{
+		C:     twistededwards.Point{X: c.X.BigInt(new(big.Int)), Y: c.Y.BigInt(new(big.Int))},
+		Nonce: nonce,
+		M:     m,
+		R:     r,
+	}
+}
+
+// NewPedersenPreImageAssignment returns the assignment of a PedersenCircuit in pre-image mode
+// committing to the Poseidon2 hash of preImage with randomness r.
+func NewPedersenPreImageAssignment(preImage []fr.Element, r *big.Int) *PedersenCircuit {
+	digest := PoseidonHash(preImage...)
+	assignment := NewPedersenAssignment(digest.BigInt(new(big.Int)), r)
+
+	assignment.PreImage = make([]frontend.Variable, len(preImage))
+	for i := range preImage {
+		assignment.PreImage[i] = preImage[i].BigInt(new(big.Int))
+	}
+
+	return assignment
+}
+
+// PedersenCommit computes the commitment M·G + R·H verified by PedersenCircuit.
+func PedersenCommit(m, r *big.Int) twistededwards_bn254.PointAffine {
+	g, h := PedersenGenerators()
+
+	var mg, rh, c twistededwards_bn254.PointAffine
+	mg.ScalarMultiplication(&g, m)
+	rh.ScalarMultiplication(&h, r)
+	c.Add(&mg, &rh)
+
+	return c
+}
+
+// PedersenGenerators returns the generators of PedersenCircuit: G is the base point of Baby Jubjub
+// and H a point of the prime order subgroup whose discrete logarithm to G is unknown. H is found by
+// try-and-increment: y is derived from a counter and the domain until it lies on the curve, and the
+// point is then multiplied by the cofactor.
+func PedersenGenerators() (g, h twistededwards_bn254.PointAffine) {
+	params := twistededwards_bn254.GetEdwardsCurve()
+	cofactor := params.Cofactor.BigInt(new(big.Int))
+
+	var one fr.Element
+	one.SetOne()
+
+	for ctr := uint64(0); ; ctr++ {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], ctr)
+		digest := sha256.Sum256(append([]byte(pedersenDomain), buf[:]...))
+
+		// x² = (1 - y²) / (a - d·y²)
+		var y, y2, num, den, x fr.Element
+		y.SetBytes(digest[:])
+		y2.Square(&y)
+		num.Sub(&one, &y2)
+		den.Mul(&params.D, &y2)
+		den.Sub(&params.A, &den)
+		if den.IsZero() {
+			continue
+		}
+		num.Div(&num, &den)
+		if x.Sqrt(&num) == nil {
+			continue
+		}
+
+		p := twistededwards_bn254.NewPointAffine(x, y)
+		h.ScalarMultiplication(&p, cofactor)
+		if !h.IsZero() {
+			return params.Base, h
+		}
+	}
+}
+
+// PoseidonHash hashes the elements with a Poseidon2 sponge. The capacity element is initialised
+// with the number of inputs so that inputs of different lengths never collide by zero padding.
+func PoseidonHash(inputs ...fr.Element) fr.Element {
+	h := poseidon2_bn254.NewHash(poseidonWidth, poseidonFullRounds, poseidonPartialRounds, poseidonSeed)
+
+	state := make([]fr.Element, poseidonWidth)
+	state[poseidonWidth-1].SetUint64(uint64(len(inputs)))
+	for i := 0; i < len(inputs); i += poseidonRate {
+		for j := 0; j < poseidonRate && i+j < len(inputs); j++ {
+			state[j].Add(&state[j], &inputs[i+j])
+		}
+		if err := h.Permutation(state); err != nil {
+			panic(err) // the state always has the width of the permutation
+		}
+	}
+
+	return state[0]
+}
+
+// poseidonHashCircuit is the in-circuit counterpart of PoseidonHash.
+func poseidonHashCircuit(api frontend.API, inputs []frontend.Variable) (frontend.Variable, error) {
+	h := poseidon2.NewHash(poseidonWidth, poseidonDegree, poseidonFullRounds, poseidonPartialRounds, poseidonSeed, ecc.BN254)
+
+	state := make([]frontend.Variable, poseidonWidth)
+	for i := range state {
+		state[i] = 0
+	}
+	state[poseidonWidth-1] = len(inputs)
+	for i := 0; i < len(inputs); i += poseidonRate {
+		for j := 0; j < poseidonRate && i+j < len(inputs); j++ {
+			state[j] = api.Add(state[j], inputs[i+j])
+		}
+		if err := h.Permutation(api, state); err != nil {
+			return nil, err
+		}
+	}
+
+	return state[0], nil
+}
@@ -0,0 +1,142 @@
+package circuit
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	twistededwards_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+func TestPedersenCircuit(t *testing.T) {
+	m, r := big.NewInt(1234), big.NewInt(5678)
+
+	if err := test.IsSolved(NewPedersenCircuit(0), NewPedersenAssignment(m, r), ecc.BN254.ScalarField()); err != nil {
+		t.Fatalf("valid opening not accepted: %v", err)
+	}
+
+	wrong := NewPedersenAssignment(m, r)
+	wrong.R = big.NewInt(5679)
+	if err := test.IsSolved(NewPedersenCircuit(0), wrong, ecc.BN254.ScalarField()); err == nil {
+		t.Fatal("wrong randomness accepted")
+	}
+}
+
+func TestPedersenCircuitPreImage(t *testing.T) {
+	preImage := make([]fr.Element, 3)
+	for i := range preImage {
+		preImage[i].SetUint64(uint64(i + 1))
+	}
+	r := big.NewInt(42)
+	circuit := NewPedersenCircuit(len(preImage))
+
+	if err := test.IsSolved(circuit, NewPedersenPreImageAssignment(preImage, r), ecc.BN254.ScalarField()); err != nil {
+		t.Fatalf("correct pre-image not accepted: %v", err)
+	}
+
+	// keep the commitment and its opening, but claim a different pre-image of M
+	wrong := NewPedersenPreImageAssignment(preImage, r)
+	wrong.PreImage[2] = 4
+	if err := test.IsSolved(circuit, wrong, ecc.BN254.ScalarField()); err == nil {
+		t.Fatal("wrong pre-image accepted")
+	}
+}
+
+func TestPoseidonHashLength(t *testing.T) {
+	var one fr.Element
+	one.SetOne()
+
+	// zero padding must not make inputs of different lengths collide
+	a := PoseidonHash(one)
+	b := PoseidonHash(one, fr.Element{})
+	if a.Equal(&b) {
+		t.Fatal("inputs of different lengths hash to the same value")
+	}
+}
+
+func TestPedersenGenerators(t *testing.T) {
+	g, h := PedersenGenerators()
+	if !h.IsOnCurve() || h.Equal(&g) {
+		t.Fatal("H is not a curve point distinct from G")
+	}
+
+	order := twistededwards_bn254.GetEdwardsCurve().Order
+	var p twistededwards_bn254.PointAffine
+	p.ScalarMultiplication(&h, &order)
+	if !p.IsZero() {
+		t.Fatal("H is not in the prime order subgroup")
+	}
+}
+
+func TestPedersenCommittedCircuit(t *testing.T) {
+	cs, err := Compile(NewPedersenCommittedCircuit(0), ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to compile: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	if keys := vk.(*groth16_bn254.VerifyingKey).CommitmentKeys; len(keys) != 1 {
+		t.Fatalf("verifying key has %d commitment keys, want 1", len(keys))
+	}
+
+	m, r := big.NewInt(1234), big.NewInt(5678)
+	full, err := frontend.NewWitness(NewPedersenNonceAssignment(m, r, big.NewInt(9)), ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	public, err := full.Public()
+	if err != nil {
+		t.Fatalf("unable to extract public witness: %v", err)
+	}
+
+	proof, err := groth16.Prove(cs, pk, full)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+	if err := groth16.Verify(proof, vk, public); err != nil {
+		t.Fatalf("valid proof rejected: %v", err)
+	}
+
+	// the commitment to the secret inputs is checked against its proof of knowledge
+	tampered := *proof.(*groth16_bn254.Proof)
+	tampered.Commitments = slices.Clone(tampered.Commitments)
+	_, _, g1, _ := bn254.Generators()
+	tampered.Commitments[0].Add(&tampered.Commitments[0], &g1)
+	if err := groth16.Verify(&tampered, vk, public); err == nil {
+		t.Fatal("proof with a tampered commitment accepted")
+	}
+
+	other, err := frontend.NewWitness(NewPedersenNonceAssignment(m, big.NewInt(5679), big.NewInt(9)), ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("unable to create public witness: %v", err)
+	}
+	if err := groth16.Verify(proof, vk, other); err == nil {
+		t.Fatal("proof accepted for another commitment")
+	}
+
+	wrong := NewPedersenNonceAssignment(m, r, big.NewInt(9))
+	wrong.R = big.NewInt(5679)
+	wrongWitness, err := frontend.NewWitness(wrong, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	if _, err := groth16.Prove(cs, pk, wrongWitness); err == nil {
+		t.Fatal("proved a wrong opening")
+	}
+
+	// BatchVerifyGroth16 checks proofs with commitments one at a time
+	if err := BatchVerifyGroth16(vk, []groth16.Proof{proof, &tampered}, []witness.Witness{public, public}); !errors.Is(err, ErrBatchRejected) {
+		t.Fatalf("expected %v, got %v", ErrBatchRejected, err)
+	}
+}
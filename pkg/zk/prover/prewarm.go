@@ -0,0 +1,111 @@
+package circuit
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// SetupKeys is a compiled circuit, e.g. PedersenCircuit, along with its Groth16 keys.
+type SetupKeys struct {
+	CS           constraint.ConstraintSystem
+	ProvingKey   groth16.ProvingKey
+	VerifyingKey groth16.VerifyingKey
+}
+
+// setupCache holds the keys of each curve once they have been set up.
+var setupCache = struct {
+	sync.Mutex
+	keys map[ecc.ID]*SetupKeys
+}{keys: make(map[ecc.ID]*SetupKeys)}
+
+// compile is the compilation routine; kept as a variable so tests can count compilations.
+var compile = Compile
+
+// GetOrSetup returns the keys of PedersenCircuit, without pre-image, over curve. The circuit is
+// compiled and set up on the first call only; later calls return the cached keys. Concurrent first
+// calls wait for a single setup.
+func GetOrSetup(curve ecc.ID) (*SetupKeys, error) {
+	setupCache.Lock()
+	defer setupCache.Unlock()
+
+	if keys, ok := setupCache.keys[curve]; ok {
+		return keys, nil
+	}
+
+	cs, err := compile(NewPedersenCircuit(0), curve)
+	if err != nil {
+		return nil, err
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run setup: %w", err)
+	}
+
+	keys := &SetupKeys{CS: cs, ProvingKey: pk, VerifyingKey: vk}
+	setupCache.keys[curve] = keys
+
+	return keys, nil
+}
+
+// Ready reports whether the keys of curve are cached, i.e. whether GetOrSetup returns immediately.
+func Ready(curve ecc.ID) bool {
+	setupCache.Lock()
+	defer setupCache.Unlock()
+
+	_, ok := setupCache.keys[curve]
+	return ok
+}
+
+type prewarmConfig struct {
+	throwawayProof bool
+}
+
+// PrewarmOption configures Prewarm.
+type PrewarmOption interface {
+	apply(*prewarmConfig)
+}
+
+type prewarmOptionFunc func(*prewarmConfig)
+
+func (f prewarmOptionFunc) apply(c *prewarmConfig) { f(c) }
+
+// WithThrowawayProof makes Prewarm prove a dummy assignment once the keys are set up, so that the
+// allocations and lazily initialised tables of the prover are in place before the first real proof.
+func WithThrowawayProof() PrewarmOption {
+	return prewarmOptionFunc(func(c *prewarmConfig) {
+		c.throwawayProof = true
+	})
+}
+
+// Prewarm compiles and sets up PedersenCircuit over curve ahead of time, see GetOrSetup, so that the
+// first request served does not pay for it. Once it returns nil, Ready(curve) is true.
+func Prewarm(curve ecc.ID, opts ...PrewarmOption) error {
+	var cfg prewarmConfig
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+
+	keys, err := GetOrSetup(curve)
+	if err != nil {
+		return err
+	}
+	if !cfg.throwawayProof {
+		return nil
+	}
+
+	w, err := frontend.NewWitness(NewPedersenAssignment(big.NewInt(1), big.NewInt(1)), curve.ScalarField())
+	if err != nil {
+		return fmt.Errorf("unable to create throwaway witness: %w", err)
+	}
+	if _, err := groth16.Prove(keys.CS, keys.ProvingKey, w); err != nil {
+		return fmt.Errorf("unable to create throwaway proof: %w", err)
+	}
+
+	return nil
+}
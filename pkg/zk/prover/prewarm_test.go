@@ -0,0 +1,47 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestPrewarm(t *testing.T) {
+	defer func(f func(frontend.Circuit, ecc.ID) (constraint.ConstraintSystem, error)) {
+		compile = f
+	}(compile)
+
+	compilations := 0
+	compile = func(circuit frontend.Circuit, curve ecc.ID) (constraint.ConstraintSystem, error) {
+		compilations++
+		return Compile(circuit, curve)
+	}
+
+	setupCache.Lock()
+	delete(setupCache.keys, ecc.BN254)
+	setupCache.Unlock()
+
+	if Ready(ecc.BN254) {
+		t.Fatal("keys ready before prewarming")
+	}
+	if err := Prewarm(ecc.BN254, WithThrowawayProof()); err != nil {
+		t.Fatalf("unable to prewarm: %v", err)
+	}
+	if !Ready(ecc.BN254) {
+		t.Fatal("keys not ready after prewarming")
+	}
+
+	prewarmed, err := GetOrSetup(ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to get keys: %v", err)
+	}
+	again, err := GetOrSetup(ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to get keys: %v", err)
+	}
+	if compilations != 1 || prewarmed != again {
+		t.Fatalf("circuit compiled %d times, want the cached keys after a single compilation", compilations)
+	}
+}
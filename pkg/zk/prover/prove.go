@@ -0,0 +1,88 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// ErrWitnessMismatch denotes a witness that does not fit the constraint system it is proven against.
+var ErrWitnessMismatch = errors.New("witness does not match constraint system")
+
+// ProveWithWitness proves r1cs with a witness built by the caller, e.g. with frontend.NewWitness or
+// decoded from another tool's output, instead of assigning a circuit here.
+func ProveWithWitness(r1cs constraint.ConstraintSystem, pk groth16.ProvingKey, w witness.Witness) (groth16.Proof, error) {
+	if w == nil {
+		return nil, fmt.Errorf("%w: witness is nil", ErrWitnessMismatch)
+	}
+
+	// the full witness holds the public inputs (without the constant wire) followed by the secret ones
+	nbInputs := r1cs.GetNbPublicVariables() - 1 + r1cs.GetNbSecretVariables()
+	if size := WitnessSize(w); size != nbInputs {
+		return nil, fmt.Errorf("%w: witness has %d values, constraint system expects %d", ErrWitnessMismatch, size, nbInputs)
+	}
+
+	proof, err := groth16.Prove(r1cs, pk, w)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove: %w", err)
+	}
+
+	return proof, nil
+}
+
+// ProveFromR1CSFile proves assignment against a precompiled constraint system, as written by
+// DumpR1CS, and a proving key written with its WriteTo method, both over curve. Nothing is compiled,
+// so deployments can ship the artifacts of a trusted setup instead of the circuit code path.
+func ProveFromR1CSFile(r1csPath, pkPath string, curve ecc.ID, assignment frontend.Circuit) (groth16.Proof, error) {
+	if err := checkProofSystem(curve, backend.GROTH16); err != nil {
+		return nil, err
+	}
+
+	cs := groth16.NewCS(curve)
+	if err := readFrom(r1csPath, cs); err != nil {
+		return nil, fmt.Errorf("unable to load r1cs: %w", err)
+	}
+	pk := groth16.NewProvingKey(curve)
+	if err := readFrom(pkPath, pk); err != nil {
+		return nil, fmt.Errorf("unable to load proving key: %w", err)
+	}
+
+	w, err := frontend.NewWitness(assignment, curve.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+
+	return ProveWithWitness(cs, pk, w)
+}
+
+// WitnessSize returns the number of field elements of w, public and secret, or 0 for a nil witness.
+func WitnessSize(w witness.Witness) int {
+	if w == nil {
+		return 0
+	}
+	if vector := reflect.ValueOf(w.Vector()); vector.Kind() == reflect.Slice {
+		return vector.Len()
+	}
+	return 0
+}
+
+// readFrom decodes the file at path into dst.
+func readFrom(path string, dst io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = dst.ReadFrom(f)
+	return err
+}
@@ -0,0 +1,103 @@
+package circuit
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestProveWithWitness(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{n: 3})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+
+	// build the witness by hand, without assigning the circuit: public Y first, then secret X
+	w, err := witness.New(ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	values := make(chan any, 2)
+	values <- 125
+	values <- 5
+	close(values)
+	if err := w.Fill(1, 1, values); err != nil {
+		t.Fatalf("unable to fill witness: %v", err)
+	}
+
+	proof, err := ProveWithWitness(cs, pk, w)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		t.Fatalf("unable to extract public witness: %v", err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		t.Fatalf("proof rejected: %v", err)
+	}
+
+	if _, err := ProveWithWitness(cs, pk, publicWitness); !errors.Is(err, ErrWitnessMismatch) {
+		t.Fatalf("expected %v, got %v", ErrWitnessMismatch, err)
+	}
+}
+
+func TestProveFromR1CSFile(t *testing.T) {
+	cs, err := Compile(&CubicCircuit{}, ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+
+	dir := t.TempDir()
+	r1csPath, pkPath := filepath.Join(dir, "myCircuit.r1cs"), filepath.Join(dir, "myCircuit.pk")
+	if err := DumpR1CS(cs, r1csPath); err != nil {
+		t.Fatalf("unable to dump r1cs: %v", err)
+	}
+	f, err := os.Create(pkPath)
+	if err != nil {
+		t.Fatalf("unable to create proving key file: %v", err)
+	}
+	if _, err := pk.WriteTo(f); err != nil {
+		t.Fatalf("unable to write proving key: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("unable to close proving key file: %v", err)
+	}
+
+	assignment := NewCubicAssignment(big.NewInt(3))
+	proof, err := ProveFromR1CSFile(r1csPath, pkPath, ecc.BN254, assignment)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+	public, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("unable to create public witness: %v", err)
+	}
+	if err := groth16.Verify(proof, vk, public); err != nil {
+		t.Fatalf("proof rejected: %v", err)
+	}
+
+	if _, err := ProveFromR1CSFile(r1csPath, pkPath, ecc.BN254, NewPedersenAssignment(big.NewInt(1), big.NewInt(2))); !errors.Is(err, ErrWitnessMismatch) {
+		t.Fatalf("expected %v, got %v", ErrWitnessMismatch, err)
+	}
+	if _, err := ProveFromR1CSFile(filepath.Join(dir, "missing.r1cs"), pkPath, ecc.BN254, assignment); err == nil {
+		t.Fatal("expected an error for a missing r1cs file")
+	}
+}
@@ -0,0 +1,161 @@
+package circuit
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	gnarkio "github.com/consensys/gnark/io"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+// ProofFile holds the proof written by ProveAndExport, see WriteProofVersioned.
+const ProofFile = "proof.bin"
+
+// CubicCircuit proves knowledge of X such that X³ + X + 5 = Y. Unlike PedersenCircuit it uses no
+// curve specific gadget, so it compiles for every curve and backend of ProverConfig.
+type CubicCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *CubicCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(c.X, c.X, c.X)
+	api.AssertIsEqual(api.Add(x3, c.X, 5), c.Y)
+	return nil
+}
+
+// NewCubicAssignment returns the assignment of CubicCircuit for x.
+func NewCubicAssignment(x *big.Int) *CubicCircuit {
+	y := new(big.Int).Exp(x, big.NewInt(3), nil)
+	y.Add(y, x).Add(y, big.NewInt(5))
+	return &CubicCircuit{X: x, Y: y}
+}
+
+// ProveAndExport compiles circuit for the curve and backend of cfg, runs a setup, proves assignment
+// and checks the proof, then writes the proof, its public witness and metadata for circuitID, with
+// the fingerprint of the verifying key, to
+// cfg.OutDir. The PLONK setup uses an SRS with a known toxic value, which cfg.InsecureSRS must
+// allow: it is meant for demos only.
+func ProveAndExport(cfg ProverConfig, circuitID string, circuit, assignment frontend.Circuit) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	log := cfg.Logger.WithField("curve", cfg.Curve).WithField("backend", cfg.Backend)
+
+	full, err := frontend.NewWitness(assignment, cfg.Curve.ScalarField())
+	if err != nil {
+		return fmt.Errorf("unable to create witness: %w", err)
+	}
+	if err := cfg.CheckWitnessSize(full); err != nil {
+		return err
+	}
+	public, err := full.Public()
+	if err != nil {
+		return fmt.Errorf("unable to extract public witness: %w", err)
+	}
+
+	start := time.Now()
+	var (
+		proof Proof
+		vk    gnarkio.WriterRawTo
+	)
+	switch cfg.Backend {
+	case backend.GROTH16:
+		proof, vk, err = proveGroth16(circuit, full, public, cfg)
+	case backend.PLONK:
+		proof, vk, err = provePlonk(circuit, full, public, cfg)
+	default:
+		err = fmt.Errorf("%w: backend %s", ErrUnsupportedProofSystem, cfg.Backend)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("proved and verified in %s", time.Since(start))
+
+	if err := os.MkdirAll(cfg.OutDir, 0o755); err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+	write := DirWriter(cfg.OutDir)
+
+	var buf bytes.Buffer
+	if err := WriteProofVersioned(&buf, proof, cfg.Curve, cfg.Backend); err != nil {
+		return err
+	}
+	if err := write(ProofFile, buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write proof: %w", err)
+	}
+	if err := ExportPublicWitnessTo(full, write); err != nil {
+		return err
+	}
+	meta := NewProofMeta(circuitID, cfg.Curve, cfg.Backend)
+	if meta.VKFingerprint, err = VKFingerprint(vk); err != nil {
+		return err
+	}
+	if err := WriteProofMetaTo(write, meta); err != nil {
+		return err
+	}
+	log.Infof("artifacts written to %s", cfg.OutDir)
+
+	return nil
+}
+
+func proveGroth16(circuit frontend.Circuit, full, public witness.Witness, cfg ProverConfig) (Proof, gnarkio.WriterRawTo, error) {
+	cs, err := Compile(circuit, cfg.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to run setup: %w", err)
+	}
+
+	proof, err := groth16.Prove(cs, pk, full)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to prove: %w", err)
+	}
+	if err := groth16.Verify(proof, vk, public); err != nil {
+		return nil, nil, fmt.Errorf("unable to verify proof: %w", err)
+	}
+
+	return proof, vk, nil
+}
+
+func provePlonk(circuit frontend.Circuit, full, public witness.Witness, cfg ProverConfig) (Proof, gnarkio.WriterRawTo, error) {
+	cs, err := frontend.Compile(cfg.Curve.ScalarField(), scs.NewBuilder, circuit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to compile circuit: %w", err)
+	}
+	cfg.Logger.Warn("INSECURE: the plonk srs is generated from a toxic value known to this process, " +
+		"anyone holding it can forge proofs; never use these keys or proofs outside of tests")
+	tau, err := randomScalar(cfg.Rand, cfg.Curve.ScalarField())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to draw toxic value: %w", err)
+	}
+	srs, srsLagrange, err := unsafekzg.NewSRS(cs, unsafekzg.WithToxicValue(tau))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create srs: %w", err)
+	}
+	pk, vk, err := plonk.Setup(cs, srs, srsLagrange)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to run setup: %w", err)
+	}
+
+	proof, err := plonk.Prove(cs, pk, full)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to prove: %w", err)
+	}
+	if err := plonk.Verify(proof, vk, public); err != nil {
+		return nil, nil, fmt.Errorf("unable to verify proof: %w", err)
+	}
+
+	return proof, vk, nil
+}
@@ -0,0 +1,101 @@
+package circuit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	mathrand "math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/sirupsen/logrus"
+)
+
+func TestProveAndExport(t *testing.T) {
+	for _, tc := range []struct {
+		curve   ecc.ID
+		backend backend.ID
+	}{
+		{ecc.BN254, backend.GROTH16},
+		{ecc.BLS12_381, backend.PLONK},
+	} {
+		t.Run(tc.curve.String()+"/"+tc.backend.String(), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Curve, cfg.Backend = tc.curve, tc.backend
+			cfg.InsecureSRS = tc.backend == backend.PLONK
+			cfg.OutDir = filepath.Join(t.TempDir(), "out")
+			cfg.Logger = logrus.New()
+			cfg.Logger.SetOutput(&bytes.Buffer{})
+
+			if err := ProveAndExport(cfg, "cubic/v1", &CubicCircuit{}, NewCubicAssignment(big.NewInt(3))); err != nil {
+				t.Fatalf("unable to prove: %v", err)
+			}
+
+			data, err := os.ReadFile(filepath.Join(cfg.OutDir, ProofFile))
+			if err != nil {
+				t.Fatalf("unable to read proof: %v", err)
+			}
+			_, curve, backendID, err := ReadProofVersioned(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("unable to decode proof: %v", err)
+			}
+			if curve != tc.curve || backendID != tc.backend {
+				t.Fatalf("proof for %s/%s, want %s/%s", curve, backendID, tc.curve, tc.backend)
+			}
+
+			meta, err := ReadProofMeta(cfg.OutDir)
+			if err != nil {
+				t.Fatalf("unable to read metadata: %v", err)
+			}
+			if meta.CircuitID != "cubic/v1" {
+				t.Fatalf("circuit id %q, want cubic/v1", meta.CircuitID)
+			}
+			if len(meta.VKFingerprint) != 64 {
+				t.Fatalf("verifying key fingerprint %q, want a hex encoded sha256", meta.VKFingerprint)
+			}
+		})
+	}
+
+	wrong := NewCubicAssignment(big.NewInt(3))
+	wrong.Y = 1
+	if err := ProveAndExport(DefaultConfig(), "cubic/v1", &CubicCircuit{}, wrong); err == nil {
+		t.Fatal("proved a wrong assignment")
+	}
+}
+
+func TestProveAndExportPlonkRand(t *testing.T) {
+	prove := func(rng io.Reader) (ProofMeta, error) {
+		cfg := DefaultConfig()
+		cfg.Backend, cfg.InsecureSRS, cfg.Rand = backend.PLONK, true, rng
+		cfg.OutDir = filepath.Join(t.TempDir(), "out")
+		cfg.Logger = logrus.New()
+		cfg.Logger.SetOutput(&bytes.Buffer{})
+
+		if err := ProveAndExport(cfg, "cubic/v1", &CubicCircuit{}, NewCubicAssignment(big.NewInt(3))); err != nil {
+			return ProofMeta{}, err
+		}
+		return ReadProofMeta(cfg.OutDir)
+	}
+
+	// the toxic value, hence the verifying key, only depends on Rand
+	var seed [32]byte
+	first, err := prove(mathrand.NewChaCha8(seed))
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+	second, err := prove(mathrand.NewChaCha8(seed))
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+	if first.VKFingerprint != second.VKFingerprint {
+		t.Fatalf("same randomness yielded verifying keys %s and %s", first.VKFingerprint, second.VKFingerprint)
+	}
+
+	if _, err := prove(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+}
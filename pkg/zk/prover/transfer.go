@@ -0,0 +1,185 @@
+package circuit
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	twistededwards_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+)
+
+// balanceBits bounds every balance and fee of a confidential transfer, so that the balance
+// equation cannot be satisfied by values wrapping around the field modulus.
+const balanceBits = 64
+
+// TransferCircuit proves that a confidential transfer preserves the total balance: the Pedersen
+// commitments to the sender and recipient balances before the transfer (Inputs) and after it
+// (Outputs) open to values with Inputs[0] + Inputs[1] = Outputs[0] + Outputs[1] + Fee. The
+// commitments and the fee are public, the balances and their randomness stay secret.
+type TransferCircuit struct {
+	Inputs  [2]twistededwards.Point `gnark:",public"`
+	Outputs [2]twistededwards.Point `gnark:",public"`
+	Fee     frontend.Variable       `gnark:",public"`
+
+	InputValues      [2]frontend.Variable
+	InputRandomness  [2]frontend.Variable
+	OutputValues     [2]frontend.Variable
+	OutputRandomness [2]frontend.Variable
+}
+
+func (c *TransferCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Inputs {
+		api.ToBinary(c.InputValues[i], balanceBits)
+		assertPedersenOpening(api, curve, c.Inputs[i], c.InputValues[i], c.InputRandomness[i])
+	}
+	for i := range c.Outputs {
+		api.ToBinary(c.OutputValues[i], balanceBits)
+		assertPedersenOpening(api, curve, c.Outputs[i], c.OutputValues[i], c.OutputRandomness[i])
+	}
+	api.ToBinary(c.Fee, balanceBits)
+
+	api.AssertIsEqual(
+		api.Add(c.InputValues[0], c.InputValues[1]),
+		api.Add(c.OutputValues[0], c.OutputValues[1], c.Fee),
+	)
+
+	return nil
+}
+
+// Transfer holds the balances of a confidential transfer from a sender to a recipient. It is
+// balanced if SenderBalance + RecipientBalance = NewSenderBalance + NewRecipientBalance + Fee.
+type Transfer struct {
+	SenderBalance       uint64
+	RecipientBalance    uint64
+	NewSenderBalance    uint64
+	NewRecipientBalance uint64
+	Fee                 uint64
+}
+
+// TransferArtifacts is the outcome of ConfidentialTransfer. The commitments, fee, proof and
+// verifying key are meant to be published; the randomness opens the commitments and must be
+// handed to their owners only.
+type TransferArtifacts struct {
+	Inputs  [2]twistededwards_bn254.PointAffine // commitments to the sender and recipient balances
+	Outputs [2]twistededwards_bn254.PointAffine // commitments to the new sender and recipient balances
+	Fee     uint64
+
+	InputRandomness  [2]*big.Int
+	OutputRandomness [2]*big.Int
+
+	Proof         groth16.Proof
+	VerifyingKey  groth16.VerifyingKey
+	PublicWitness witness.Witness
+}
+
+// Verify checks the proof of the transfer against its public commitments and fee.
+func (a *TransferArtifacts) Verify() error {
+	if err := groth16.Verify(a.Proof, a.VerifyingKey, a.PublicWitness); err != nil {
+		return fmt.Errorf("unable to verify transfer proof: %w", err)
+	}
+	return nil
+}
+
+// transferSetup holds the keys of TransferCircuit once they have been set up.
+var transferSetup struct {
+	sync.Mutex
+	keys *SetupKeys
+}
+
+// transferKeys returns the Groth16 keys of TransferCircuit over BN254, running the setup on the
+// first call only.
+func transferKeys() (*SetupKeys, error) {
+	transferSetup.Lock()
+	defer transferSetup.Unlock()
+
+	if transferSetup.keys != nil {
+		return transferSetup.keys, nil
+	}
+
+	cs, err := compile(&TransferCircuit{}, ecc.BN254)
+	if err != nil {
+		return nil, err
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run setup: %w", err)
+	}
+	transferSetup.keys = &SetupKeys{CS: cs, ProvingKey: pk, VerifyingKey: vk}
+
+	return transferSetup.keys, nil
+}
+
+// ConfidentialTransfer commits to the balances of transfer with fresh randomness and proves with
+// TransferCircuit that the transfer is balanced, without revealing any balance. An unbalanced
+// transfer has no satisfying assignment, so proving it fails.
+func ConfidentialTransfer(transfer Transfer) (*TransferArtifacts, error) {
+	keys, err := transferKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := &TransferArtifacts{Fee: transfer.Fee, VerifyingKey: keys.VerifyingKey}
+	assignment := &TransferCircuit{Fee: transfer.Fee}
+
+	inputs := [2]uint64{transfer.SenderBalance, transfer.RecipientBalance}
+	outputs := [2]uint64{transfer.NewSenderBalance, transfer.NewRecipientBalance}
+	for i := range inputs {
+		artifacts.Inputs[i], artifacts.InputRandomness[i], err = commitBalance(inputs[i])
+		if err != nil {
+			return nil, err
+		}
+		assignment.Inputs[i] = pointAssignment(artifacts.Inputs[i])
+		assignment.InputValues[i] = inputs[i]
+		assignment.InputRandomness[i] = artifacts.InputRandomness[i]
+
+		artifacts.Outputs[i], artifacts.OutputRandomness[i], err = commitBalance(outputs[i])
+		if err != nil {
+			return nil, err
+		}
+		assignment.Outputs[i] = pointAssignment(artifacts.Outputs[i])
+		assignment.OutputValues[i] = outputs[i]
+		assignment.OutputRandomness[i] = artifacts.OutputRandomness[i]
+	}
+
+	full, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+	if artifacts.PublicWitness, err = full.Public(); err != nil {
+		return nil, fmt.Errorf("unable to extract public witness: %w", err)
+	}
+
+	if artifacts.Proof, err = groth16.Prove(keys.CS, keys.ProvingKey, full); err != nil {
+		return nil, fmt.Errorf("unable to prove transfer: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// commitBalance commits to balance with randomness drawn uniformly from the scalars of Baby Jubjub.
+func commitBalance(balance uint64) (twistededwards_bn254.PointAffine, *big.Int, error) {
+	order := twistededwards_bn254.GetEdwardsCurve().Order
+	r, err := rand.Int(rand.Reader, &order)
+	if err != nil {
+		return twistededwards_bn254.PointAffine{}, nil, fmt.Errorf("unable to sample randomness: %w", err)
+	}
+
+	return PedersenCommit(new(big.Int).SetUint64(balance), r), r, nil
+}
+
+// pointAssignment returns the circuit assignment of p.
+func pointAssignment(p twistededwards_bn254.PointAffine) twistededwards.Point {
+	return twistededwards.Point{X: p.X.BigInt(new(big.Int)), Y: p.Y.BigInt(new(big.Int))}
+}
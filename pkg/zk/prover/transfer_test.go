@@ -0,0 +1,51 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestConfidentialTransfer(t *testing.T) {
+	// the sender pays 250 to the recipient and a fee of 3
+	balanced := Transfer{
+		SenderBalance:       1000,
+		RecipientBalance:    40,
+		NewSenderBalance:    747,
+		NewRecipientBalance: 290,
+		Fee:                 3,
+	}
+
+	artifacts, err := ConfidentialTransfer(balanced)
+	if err != nil {
+		t.Fatalf("unable to prove balanced transfer: %v", err)
+	}
+	if err := artifacts.Verify(); err != nil {
+		t.Fatalf("balanced transfer rejected: %v", err)
+	}
+	if artifacts.Inputs[0].Equal(&artifacts.Outputs[0]) {
+		t.Fatal("commitments to different balances are equal")
+	}
+
+	// the proof is bound to the published fee
+	tampered := &TransferCircuit{Fee: balanced.Fee + 1}
+	for i := range artifacts.Inputs {
+		tampered.Inputs[i] = pointAssignment(artifacts.Inputs[i])
+		tampered.Outputs[i] = pointAssignment(artifacts.Outputs[i])
+	}
+	public, err := frontend.NewWitness(tampered, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("unable to create public witness: %v", err)
+	}
+	artifacts.PublicWitness = public
+	if err := artifacts.Verify(); err == nil {
+		t.Fatal("proof accepted with a different fee")
+	}
+
+	unbalanced := balanced
+	unbalanced.NewRecipientBalance++
+	if _, err := ConfidentialTransfer(unbalanced); err == nil {
+		t.Fatal("unbalanced transfer proved")
+	}
+}
@@ -0,0 +1,86 @@
+package circuit
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+// VerifyWithPublicInputs verifies proof when only the values of the public inputs are at hand,
+// rather than a serialized public witness. inputs maps the name of every public variable of
+// circuit, named as in ExportAnnotatedWitness (e.g. "C_X"), to its decimal or 0x-prefixed hex
+// value; the public witness is assembled in the order the circuit declares them. circuit only
+// provides the layout, its values are ignored.
+func VerifyWithPublicInputs(proof groth16.Proof, vk groth16.VerifyingKey, circuit frontend.Circuit, curve ecc.ID, inputs map[string]string) error {
+	public, err := PublicWitnessFromInputs(circuit, curve, inputs)
+	if err != nil {
+		return err
+	}
+
+	if err := groth16.Verify(proof, vk, public); err != nil {
+		return fmt.Errorf("unable to verify proof: %w", err)
+	}
+
+	return nil
+}
+
+// PublicWitnessFromInputs builds the public witness of circuit over the scalar field of curve from
+// the named values of its public inputs, see VerifyWithPublicInputs. Every public input must be
+// given, and no other name.
+func PublicWitnessFromInputs(circuit frontend.Circuit, curve ecc.ID, inputs map[string]string) (witness.Witness, error) {
+	names, _, err := variableNames(circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	field := curve.ScalarField()
+	values := make(chan any, len(names))
+	for _, name := range names {
+		s, ok := inputs[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing public input %q", witness.ErrInvalidWitness, name)
+		}
+		v, ok := parseInput(s)
+		if !ok || v.Sign() < 0 || v.Cmp(field) >= 0 {
+			return nil, fmt.Errorf("%w: public input %q is not a field element", witness.ErrInvalidWitness, name)
+		}
+		values <- v
+	}
+	close(values)
+
+	for name := range inputs {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("%w: unknown public input %q", witness.ErrInvalidWitness, name)
+		}
+	}
+
+	w, err := witness.New(field)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+	if err := w.Fill(len(names), 0, values); err != nil {
+		return nil, fmt.Errorf("unable to fill witness: %w", err)
+	}
+
+	return w, nil
+}
+
+// parseInput parses a public input value: hex after a 0x or 0X prefix, decimal otherwise. Unlike
+// big.Int.SetString with base 0, a leading zero does not make it octal, and neither 0b, 0o nor
+// underscores are accepted.
+func parseInput(s string) (*big.Int, bool) {
+	s = strings.TrimSpace(s)
+	if hex, ok := strings.CutPrefix(s, "0x"); ok {
+		return new(big.Int).SetString(hex, 16)
+	}
+	if hex, ok := strings.CutPrefix(s, "0X"); ok {
+		return new(big.Int).SetString(hex, 16)
+	}
+	return new(big.Int).SetString(s, 10)
+}
@@ -0,0 +1,88 @@
+package circuit
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestVerifyWithPublicInputs(t *testing.T) {
+	keys, err := GetOrSetup(ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to set up: %v", err)
+	}
+
+	m, r := big.NewInt(1234), big.NewInt(5678)
+	w, err := frontend.NewWitness(NewPedersenAssignment(m, r), ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	proof, err := groth16.Prove(keys.CS, keys.ProvingKey, w)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+
+	c := PedersenCommit(m, r)
+	inputs := map[string]string{
+		"C_X":   c.X.String(),
+		"C_Y":   "0x" + c.Y.Text(16),
+		"Nonce": "0",
+	}
+	if err := VerifyWithPublicInputs(proof, keys.VerifyingKey, NewPedersenCircuit(0), ecc.BN254, inputs); err != nil {
+		t.Fatalf("proof rejected: %v", err)
+	}
+
+	wrong := map[string]string{"C_X": c.X.String(), "C_Y": "1", "Nonce": "0"}
+	if err := VerifyWithPublicInputs(proof, keys.VerifyingKey, NewPedersenCircuit(0), ecc.BN254, wrong); err == nil {
+		t.Fatal("proof accepted with a wrong public input")
+	}
+
+	for name, inputs := range map[string]map[string]string{
+		"missing":   {"C_X": c.X.String()},
+		"unknown":   {"C_X": c.X.String(), "C_Y": c.Y.String(), "Nonce": "0", "M": "1234"},
+		"not field": {"C_X": c.X.String(), "C_Y": ecc.BN254.ScalarField().String(), "Nonce": "0"},
+		"not int":   {"C_X": c.X.String(), "C_Y": "y", "Nonce": "0"},
+		"binary":    {"C_X": c.X.String(), "C_Y": c.Y.String(), "Nonce": "0b1"},
+		"octal":     {"C_X": c.X.String(), "C_Y": c.Y.String(), "Nonce": "0o7"},
+		"separated": {"C_X": c.X.String(), "C_Y": c.Y.String(), "Nonce": "1_000"},
+	} {
+		err := VerifyWithPublicInputs(proof, keys.VerifyingKey, NewPedersenCircuit(0), ecc.BN254, inputs)
+		if !errors.Is(err, witness.ErrInvalidWitness) {
+			t.Fatalf("%s: expected %v, got %v", name, witness.ErrInvalidWitness, err)
+		}
+	}
+}
+
+func TestPublicWitnessFromInputsLeadingZero(t *testing.T) {
+	c := PedersenCommit(big.NewInt(1234), big.NewInt(5678))
+
+	witnessOf := func(nonce string) []byte {
+		t.Helper()
+
+		w, err := PublicWitnessFromInputs(NewPedersenCircuit(0), ecc.BN254, map[string]string{
+			"C_X": c.X.String(), "C_Y": "0X" + c.Y.Text(16), "Nonce": nonce,
+		})
+		if err != nil {
+			t.Fatalf("unable to build witness for nonce %q: %v", nonce, err)
+		}
+		data, err := w.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unable to marshal witness: %v", err)
+		}
+		return data
+	}
+
+	// a leading zero is decimal, not octal: 010 is ten, not eight
+	if !bytes.Equal(witnessOf("010"), witnessOf("10")) {
+		t.Fatal("010 is not read as 10")
+	}
+	if bytes.Equal(witnessOf("010"), witnessOf("8")) {
+		t.Fatal("010 is read as octal")
+	}
+}
@@ -0,0 +1,105 @@
+package circuit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+)
+
+// ProofFormatVersion is the version of the proof serialization written by WriteProofVersioned.
+const ProofFormatVersion uint8 = 1
+
+// proofCurves lists the curves gnark implements the proving backends for.
+var proofCurves = []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633}
+
+// proofMagic starts every versioned proof, so that raw gnark proofs are not mistaken for one.
+var proofMagic = [4]byte{'K', 'L', 'P', 'F'}
+
+var (
+	ErrNotVersionedProof      = errors.New("not a versioned proof")
+	ErrUnsupportedVersion     = errors.New("unsupported proof format version")
+	ErrUnsupportedProofSystem = errors.New("unsupported curve or backend")
+)
+
+// proofHeader precedes the proof bytes, all fields big endian.
+type proofHeader struct {
+	Magic   [4]byte
+	Version uint8
+	Curve   uint16
+	Backend uint16
+}
+
+// Proof is a proof of any of the supported backends.
+type Proof interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// WriteProofVersioned writes proof prefixed by a header holding a magic, the format version, the
+// curve and the backend, so that files written by a later, incompatible scheme are rejected instead
+// of silently misparsed.
+func WriteProofVersioned(w io.Writer, proof Proof, curve ecc.ID, backendID backend.ID) error {
+	header := proofHeader{
+		Magic:   proofMagic,
+		Version: ProofFormatVersion,
+		Curve:   uint16(curve),
+		Backend: uint16(backendID),
+	}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return fmt.Errorf("unable to write proof header: %w", err)
+	}
+
+	if _, err := proof.WriteTo(w); err != nil {
+		return fmt.Errorf("unable to write proof: %w", err)
+	}
+
+	return nil
+}
+
+// ReadProofVersioned reads a proof written by WriteProofVersioned, returning it with its curve and backend.
+func ReadProofVersioned(r io.Reader) (Proof, ecc.ID, backend.ID, error) {
+	var header proofHeader
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, ecc.UNKNOWN, backend.UNKNOWN, fmt.Errorf("unable to read proof header: %w", err)
+	}
+
+	if !bytes.Equal(header.Magic[:], proofMagic[:]) {
+		return nil, ecc.UNKNOWN, backend.UNKNOWN, ErrNotVersionedProof
+	}
+	if header.Version != ProofFormatVersion {
+		return nil, ecc.UNKNOWN, backend.UNKNOWN, fmt.Errorf("%w: got %d, this build reads %d",
+			ErrUnsupportedVersion, header.Version, ProofFormatVersion)
+	}
+
+	curve, backendID := ecc.ID(header.Curve), backend.ID(header.Backend)
+
+	proof, err := newProof(curve, backendID)
+	if err != nil {
+		return nil, ecc.UNKNOWN, backend.UNKNOWN, err
+	}
+
+	if _, err := proof.ReadFrom(r); err != nil {
+		return nil, ecc.UNKNOWN, backend.UNKNOWN, fmt.Errorf("unable to read proof: %w", err)
+	}
+
+	return proof, curve, backendID, nil
+}
+
+// newProof returns an empty proof for the curve and backend.
+func newProof(curve ecc.ID, backendID backend.ID) (Proof, error) {
+	if err := checkProofSystem(curve, backendID); err != nil {
+		return nil, err
+	}
+
+	if backendID == backend.PLONK {
+		return plonk.NewProof(curve), nil
+	}
+	return groth16.NewProof(curve), nil
+}
@@ -0,0 +1,69 @@
+package circuit
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestProofVersioned(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{n: 2})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	witness, err := frontend.NewWitness(&powerCircuit{X: 6, Y: 36}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	proof, err := groth16.Prove(cs, pk, witness)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteProofVersioned(&buf, proof, ecc.BN254, backend.GROTH16); err != nil {
+		t.Fatalf("unable to write proof: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	read, curve, backendID, err := ReadProofVersioned(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("unable to read proof: %v", err)
+	}
+	if curve != ecc.BN254 || backendID != backend.GROTH16 {
+		t.Fatalf("read %s/%s, want %s/%s", curve, backendID, ecc.BN254, backend.GROTH16)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		t.Fatalf("unable to extract public witness: %v", err)
+	}
+	if err := groth16.Verify(read.(groth16.Proof), vk, publicWitness); err != nil {
+		t.Fatalf("read proof rejected: %v", err)
+	}
+
+	// a file written by a future version of the format
+	bumped := bytes.Clone(encoded)
+	bumped[len(proofMagic)]++
+	if _, _, _, err := ReadProofVersioned(bytes.NewReader(bumped)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("expected %v, got %v", ErrUnsupportedVersion, err)
+	}
+
+	// a raw proof without header
+	var raw bytes.Buffer
+	if _, err := proof.WriteTo(&raw); err != nil {
+		t.Fatalf("unable to write raw proof: %v", err)
+	}
+	if _, _, _, err := ReadProofVersioned(&raw); !errors.Is(err, ErrNotVersionedProof) {
+		t.Fatalf("expected %v, got %v", ErrNotVersionedProof, err)
+	}
+}
@@ -0,0 +1,182 @@
+package circuit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// VerifyingKeyJSONFile holds the verifying key written by ExportVerifyingKeyJSON.
+const VerifyingKeyJSONFile = "verifying_key.json"
+
+// G1PointJSON is the JSON form of a G1 point: its affine coordinates as decimal strings.
+type G1PointJSON struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// G2PointJSON is the JSON form of a G2 point: its affine coordinates as [c0, c1] pairs of decimal
+// strings, for c0 + c1·u.
+type G2PointJSON struct {
+	X [2]string `json:"x"`
+	Y [2]string `json:"y"`
+}
+
+// VerifyingKeyJSON is the JSON form of a Groth16 BN254 verifying key without commitments. K holds
+// one point for the constant wire followed by one per public input.
+type VerifyingKeyJSON struct {
+	Curve string        `json:"curve"`
+	Alpha G1PointJSON   `json:"alpha"`
+	Beta  G2PointJSON   `json:"beta"`
+	Gamma G2PointJSON   `json:"gamma"`
+	Delta G2PointJSON   `json:"delta"`
+	K     []G1PointJSON `json:"k"`
+}
+
+// NewVerifyingKeyJSON returns the JSON form of vk, which must be a BN254 verifying key without
+// commitments.
+func NewVerifyingKeyJSON(vk groth16.VerifyingKey) (*VerifyingKeyJSON, error) {
+	bn254VK, err := jsonVerifyingKey(vk)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &VerifyingKeyJSON{
+		Curve: "bn254",
+		Alpha: g1PointJSON(bn254VK.G1.Alpha),
+		Beta:  g2PointJSON(bn254VK.G2.Beta),
+		Gamma: g2PointJSON(bn254VK.G2.Gamma),
+		Delta: g2PointJSON(bn254VK.G2.Delta),
+		K:     make([]G1PointJSON, len(bn254VK.G1.K)),
+	}
+	for i := range bn254VK.G1.K {
+		res.K[i] = g1PointJSON(bn254VK.G1.K[i])
+	}
+
+	return res, nil
+}
+
+// ExportVerifyingKeyJSON writes vk as indented JSON to VerifyingKeyJSONFile in outDir, see
+// WriteVerifyingKeyJSON.
+func ExportVerifyingKeyJSON(vk groth16.VerifyingKey, outDir string) error {
+	f, err := os.Create(filepath.Join(outDir, VerifyingKeyJSONFile))
+	if err != nil {
+		return fmt.Errorf("unable to create verifying key file: %w", err)
+	}
+	defer f.Close()
+
+	if err := WriteVerifyingKeyJSON(f, vk); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// WriteVerifyingKeyJSON writes the VerifyingKeyJSON of vk to w, indented as json.MarshalIndent with
+// two spaces would. The K points, one per public input, are encoded and written one at a time, so
+// neither the JSON form of the key nor its encoding is ever held in memory as a whole.
+func WriteVerifyingKeyJSON(w io.Writer, vk groth16.VerifyingKey) error {
+	bn254VK, err := jsonVerifyingKey(vk)
+	if err != nil {
+		return err
+	}
+
+	s := &jsonStream{w: bufio.NewWriter(w)}
+	s.write("{\n")
+	s.field("curve", "bn254")
+	s.field("alpha", g1PointJSON(bn254VK.G1.Alpha))
+	s.field("beta", g2PointJSON(bn254VK.G2.Beta))
+	s.field("gamma", g2PointJSON(bn254VK.G2.Gamma))
+	s.field("delta", g2PointJSON(bn254VK.G2.Delta))
+
+	s.write("  \"k\": [")
+	for i := range bn254VK.G1.K {
+		if i > 0 {
+			s.write(",")
+		}
+		s.write("\n    ")
+		s.value(g1PointJSON(bn254VK.G1.K[i]), "    ")
+	}
+	if len(bn254VK.G1.K) > 0 {
+		s.write("\n  ")
+	}
+	s.write("]\n}\n")
+
+	if s.err != nil {
+		return fmt.Errorf("unable to write verifying key json: %w", s.err)
+	}
+	if err := s.w.Flush(); err != nil {
+		return fmt.Errorf("unable to write verifying key json: %w", err)
+	}
+	return nil
+}
+
+// jsonStream writes a JSON document piecewise, remembering the first error.
+type jsonStream struct {
+	w   *bufio.Writer
+	buf bytes.Buffer
+	err error
+}
+
+func (s *jsonStream) write(str string) {
+	if s.err == nil {
+		_, s.err = s.w.WriteString(str)
+	}
+}
+
+// field writes a member of the top level object, followed by a comma as more members follow.
+func (s *jsonStream) field(name string, v interface{}) {
+	s.write(fmt.Sprintf("  %q: ", name))
+	s.value(v, "  ")
+	s.write(",\n")
+}
+
+// value writes v indented as a value nested at prefix.
+func (s *jsonStream) value(v interface{}, prefix string) {
+	if s.err != nil {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.err = err
+		return
+	}
+
+	s.buf.Reset()
+	if s.err = json.Indent(&s.buf, data, prefix, "  "); s.err != nil {
+		return
+	}
+	_, s.err = s.buf.WriteTo(s.w)
+}
+
+// jsonVerifyingKey returns vk as a BN254 verifying key, if it has a JSON form.
+func jsonVerifyingKey(vk groth16.VerifyingKey) (*groth16_bn254.VerifyingKey, error) {
+	bn254VK, ok := vk.(*groth16_bn254.VerifyingKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: verifying key %T has no json form", ErrUnsupportedProofSystem, vk)
+	}
+	if len(bn254VK.CommitmentKeys) != 0 {
+		return nil, fmt.Errorf("%w: verifying key with commitments has no json form", ErrUnsupportedProofSystem)
+	}
+	return bn254VK, nil
+}
+
+func g1PointJSON(p bn254.G1Affine) G1PointJSON {
+	return G1PointJSON{X: p.X.String(), Y: p.Y.String()}
+}
+
+func g2PointJSON(p bn254.G2Affine) G2PointJSON {
+	return G2PointJSON{
+		X: [2]string{p.X.A0.String(), p.X.A1.String()},
+		Y: [2]string{p.Y.A0.String(), p.Y.A1.String()},
+	}
+}
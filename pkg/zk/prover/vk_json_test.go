@@ -0,0 +1,57 @@
+package circuit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+func TestWriteVerifyingKeyJSON(t *testing.T) {
+	keys, err := GetOrSetup(ecc.BN254)
+	if err != nil {
+		t.Fatalf("unable to set up: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := ExportVerifyingKeyJSON(keys.VerifyingKey, dir); err != nil {
+		t.Fatalf("unable to export verifying key: %v", err)
+	}
+	streamed, err := os.ReadFile(filepath.Join(dir, VerifyingKeyJSONFile))
+	if err != nil {
+		t.Fatalf("unable to read verifying key: %v", err)
+	}
+
+	want, err := NewVerifyingKeyJSON(keys.VerifyingKey)
+	if err != nil {
+		t.Fatalf("unable to convert verifying key: %v", err)
+	}
+	buffered, err := json.MarshalIndent(want, "", "  ")
+	if err != nil {
+		t.Fatalf("unable to marshal verifying key: %v", err)
+	}
+
+	var got VerifyingKeyJSON
+	if err := json.Unmarshal(streamed, &got); err != nil {
+		t.Fatalf("streamed json does not parse: %v\n%s", err, streamed)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Fatalf("streamed json parses to %+v, want %+v", got, want)
+	}
+	if len(got.K) != 4 { // constant wire, C.X, C.Y and Nonce
+		t.Fatalf("got %d K points, want 4", len(got.K))
+	}
+	if !bytes.Equal(bytes.TrimSuffix(streamed, []byte("\n")), buffered) {
+		t.Fatalf("streamed json\n%s\ndiffers from buffered json\n%s", streamed, buffered)
+	}
+
+	if err := WriteVerifyingKeyJSON(&bytes.Buffer{}, groth16.NewVerifyingKey(ecc.BLS12_381)); !errors.Is(err, ErrUnsupportedProofSystem) {
+		t.Fatalf("expected %v, got %v", ErrUnsupportedProofSystem, err)
+	}
+}
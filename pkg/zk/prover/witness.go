@@ -0,0 +1,201 @@
+package circuit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/schema"
+)
+
+const (
+	// PublicWitnessBinFile holds the public witness in gnark's binary encoding.
+	PublicWitnessBinFile = "public_witness.bin"
+	// PublicWitnessJSONFile holds the same public witness as JSON, see PublicWitnessJSON.
+	PublicWitnessJSONFile = "public_witness.json"
+	// AnnotatedWitnessFile maps the name of every circuit variable to its value, see ExportAnnotatedWitness.
+	AnnotatedWitnessFile = "witness_annotated.json"
+)
+
+// PublicWitnessJSON is the JSON form of a public witness. Values are decimal strings in the order of
+// gnark's witness vector, i.e. the order of the public fields in the circuit definition.
+type PublicWitnessJSON struct {
+	NbPublic int      `json:"nb_public"`
+	Values   []string `json:"values"`
+}
+
+// ExportPublicWitness writes the public part of w to outDir both in gnark's binary encoding and as
+// JSON. Both files decode to the same witness, see ReadPublicWitnessJSON.
+func ExportPublicWitness(w witness.Witness, outDir string) error {
+	return ExportPublicWitnessTo(w, DirWriter(outDir))
+}
+
+// ExportPublicWitnessTo is ExportPublicWitness writing the artifacts with write.
+func ExportPublicWitnessTo(w witness.Witness, write WriteFileFunc) error {
+	public, err := w.Public()
+	if err != nil {
+		return fmt.Errorf("unable to extract public witness: %w", err)
+	}
+
+	bin, err := public.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("unable to marshal public witness: %w", err)
+	}
+	if err := write(PublicWitnessBinFile, bin); err != nil {
+		return fmt.Errorf("unable to write public witness: %w", err)
+	}
+
+	values, err := witnessValues(public)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(PublicWitnessJSON{NbPublic: len(values), Values: values}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal public witness json: %w", err)
+	}
+	if err := write(PublicWitnessJSONFile, data); err != nil {
+		return fmt.Errorf("unable to write public witness json: %w", err)
+	}
+
+	return nil
+}
+
+// ReadPublicWitnessJSON reads a public witness written by ExportPublicWitness, for the scalar field
+// of the circuit's curve, e.g. ecc.BN254.ScalarField().
+func ReadPublicWitnessJSON(path string, field *big.Int) (witness.Witness, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read public witness json: %w", err)
+	}
+
+	var encoded PublicWitnessJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal public witness json: %w", err)
+	}
+	if encoded.NbPublic != len(encoded.Values) {
+		return nil, fmt.Errorf("%w: nb_public is %d but %d values are given", witness.ErrInvalidWitness, encoded.NbPublic, len(encoded.Values))
+	}
+
+	values := make(chan any, len(encoded.Values))
+	for i, s := range encoded.Values {
+		v, ok := new(big.Int).SetString(s, 10)
+		if !ok || v.Sign() < 0 || v.Cmp(field) >= 0 {
+			return nil, fmt.Errorf("%w: value %d is not a field element", witness.ErrInvalidWitness, i)
+		}
+		values <- v
+	}
+	close(values)
+
+	w, err := witness.New(field)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+	if err := w.Fill(encoded.NbPublic, 0, values); err != nil {
+		return nil, fmt.Errorf("unable to fill witness: %w", err)
+	}
+
+	return w, nil
+}
+
+// witnessValues returns the decimal values of the witness vector, whatever the curve.
+func witnessValues(w witness.Witness) ([]string, error) {
+	vector := reflect.ValueOf(w.Vector())
+	if vector.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%w: unexpected vector type %T", witness.ErrInvalidWitness, w.Vector())
+	}
+
+	values := make([]string, vector.Len())
+	for i := range values {
+		e, ok := vector.Index(i).Addr().Interface().(interface{ BigInt(*big.Int) *big.Int })
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected element type %s", witness.ErrInvalidWitness, vector.Index(i).Type())
+		}
+		values[i] = e.BigInt(new(big.Int)).String()
+	}
+
+	return values, nil
+}
+
+// ExportAnnotatedWitness writes to AnnotatedWitnessFile in outDir a JSON object mapping the name of
+// every variable of the BN254 assignment to its decimal value. Variables appear in witness vector
+// order, i.e. public ones first as indexed by the Solidity verifier, and nested fields are named
+// the way gnark names them, e.g. "C_X" for field X of C.
+func ExportAnnotatedWitness(assignment frontend.Circuit, outDir string) error {
+	return ExportAnnotatedWitnessTo(assignment, DirWriter(outDir))
+}
+
+// ExportAnnotatedWitnessTo is ExportAnnotatedWitness writing the artifact with write.
+func ExportAnnotatedWitnessTo(assignment frontend.Circuit, write WriteFileFunc) error {
+	names, values, err := annotatedWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i := range names {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		name, _ := json.Marshal(names[i])
+		fmt.Fprintf(&buf, "\n  %s: %q", name, values[i])
+	}
+	buf.WriteString("\n}\n")
+
+	if err := write(AnnotatedWitnessFile, buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write annotated witness: %w", err)
+	}
+
+	return nil
+}
+
+// annotatedWitness returns the names of the variables of assignment along with their values, in
+// witness vector order.
+func annotatedWitness(assignment frontend.Circuit, field *big.Int) (names, values []string, err error) {
+	w, err := frontend.NewWitness(assignment, field)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create witness: %w", err)
+	}
+	values, err = witnessValues(w)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the witness holds the public variables first, then the secret ones, each in declaration order
+	public, secret, err := variableNames(assignment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names = append(public, secret...)
+	if len(names) != len(values) {
+		return nil, nil, fmt.Errorf("%w: %d variables but %d values", witness.ErrInvalidWitness, len(names), len(values))
+	}
+
+	return names, values, nil
+}
+
+// variableNames returns the names of the public and of the secret variables of circuit, each in
+// declaration order.
+func variableNames(circuit frontend.Circuit) (public, secret []string, err error) {
+	tVariable := reflect.TypeOf((*frontend.Variable)(nil)).Elem()
+	if _, err := schema.Walk(circuit, tVariable, func(leaf schema.LeafInfo, _ reflect.Value) error {
+		if leaf.Visibility == schema.Public {
+			public = append(public, leaf.FullName())
+		} else {
+			secret = append(secret, leaf.FullName())
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("unable to walk circuit: %w", err)
+	}
+
+	return public, secret, nil
+}
@@ -0,0 +1,110 @@
+package circuit
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+// pairCircuit has two public inputs, to check their order is preserved.
+type pairCircuit struct {
+	X frontend.Variable
+	A frontend.Variable `gnark:",public"`
+	B frontend.Variable `gnark:",public"`
+}
+
+func (c *pairCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Add(c.A, c.B), c.X)
+	return nil
+}
+
+func TestExportPublicWitness(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	full, err := frontend.NewWitness(&pairCircuit{X: 10, A: 3, B: 7}, field)
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := ExportPublicWitness(full, dir); err != nil {
+		t.Fatalf("unable to export public witness: %v", err)
+	}
+
+	bin, err := os.ReadFile(filepath.Join(dir, PublicWitnessBinFile))
+	if err != nil {
+		t.Fatalf("unable to read binary witness: %v", err)
+	}
+	fromBin, err := witness.New(field)
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	if err := fromBin.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("unable to decode binary witness: %v", err)
+	}
+
+	fromJSON, err := ReadPublicWitnessJSON(filepath.Join(dir, PublicWitnessJSONFile), field)
+	if err != nil {
+		t.Fatalf("unable to decode json witness: %v", err)
+	}
+
+	binBytes, _ := fromBin.MarshalBinary()
+	jsonBytes, _ := fromJSON.MarshalBinary()
+	if !bytes.Equal(binBytes, jsonBytes) {
+		t.Fatal("json and binary public witnesses differ")
+	}
+
+	values, err := witnessValues(fromJSON)
+	if err != nil {
+		t.Fatalf("unable to read values: %v", err)
+	}
+	if len(values) != 2 || values[0] != "3" || values[1] != "7" {
+		t.Fatalf("public values %v, want [3 7]", values)
+	}
+}
+
+func TestExportAnnotatedWitness(t *testing.T) {
+	m, r := big.NewInt(1234), big.NewInt(5678)
+	assignment := NewPedersenAssignment(m, r)
+
+	dir := t.TempDir()
+	if err := ExportAnnotatedWitness(assignment, dir); err != nil {
+		t.Fatalf("unable to export annotated witness: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, AnnotatedWitnessFile))
+	if err != nil {
+		t.Fatalf("unable to read annotated witness: %v", err)
+	}
+	var annotated map[string]string
+	if err := json.Unmarshal(data, &annotated); err != nil {
+		t.Fatalf("unable to decode annotated witness: %v", err)
+	}
+
+	want := map[string]string{
+		"C_X":   assignment.C.X.(*big.Int).String(),
+		"C_Y":   assignment.C.Y.(*big.Int).String(),
+		"Nonce": "0",
+		"M":     m.String(),
+		"R":     r.String(),
+	}
+	if len(annotated) != len(want) {
+		t.Fatalf("annotated witness has %d variables, want %d", len(annotated), len(want))
+	}
+	for name, value := range want {
+		if annotated[name] != value {
+			t.Fatalf("%s is %q, want %q", name, annotated[name], value)
+		}
+	}
+
+	// public variables come first, in the order the verifier indexes them
+	if i, j := bytes.Index(data, []byte(`"C_Y"`)), bytes.Index(data, []byte(`"M"`)); i < 0 || j < i {
+		t.Fatal("public variables are not listed before the secret ones")
+	}
+}
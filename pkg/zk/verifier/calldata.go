@@ -0,0 +1,50 @@
+package verifier
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+var (
+	// ErrInvalidUint256 denotes a string that is not a 0x-prefixed hex encoding of at most 32 bytes.
+	ErrInvalidUint256 = errors.New("invalid uint256 hex")
+	// ErrNotInField denotes a uint256 that is not smaller than the scalar field modulus.
+	ErrNotInField = errors.New("value is not smaller than the field modulus")
+)
+
+// FrToUint256Hex encodes e as the 0x-prefixed, zero padded, big endian 32 bytes of a Solidity uint256.
+func FrToUint256Hex(e fr.Element) string {
+	b := e.Bytes() // regular (non Montgomery) big endian form
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// Uint256HexToFr decodes a 0x-prefixed uint256 hex string into a field element. Values that are not
+// smaller than the modulus are rejected rather than reduced, since the verifier contract rejects them too.
+func Uint256HexToFr(s string) (fr.Element, error) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok {
+		digits, ok = strings.CutPrefix(s, "0X")
+	}
+	if !ok || len(digits) == 0 || len(digits) > 2*fr.Bytes {
+		return fr.Element{}, fmt.Errorf("%w: %q", ErrInvalidUint256, s)
+	}
+
+	// hex.DecodeString accepts hex digits only, where big.Int.SetString would take a sign too
+	b, err := hex.DecodeString(strings.Repeat("0", 2*fr.Bytes-len(digits)) + digits)
+	if err != nil {
+		return fr.Element{}, fmt.Errorf("%w: %q", ErrInvalidUint256, s)
+	}
+	v := new(big.Int).SetBytes(b)
+	if v.Cmp(fr.Modulus()) >= 0 {
+		return fr.Element{}, fmt.Errorf("%w: %s", ErrNotInField, s)
+	}
+
+	var e fr.Element
+	e.SetBigInt(v)
+	return e, nil
+}
@@ -0,0 +1,57 @@
+package verifier
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestUint256Hex(t *testing.T) {
+	var maxMinusOne fr.Element
+	maxMinusOne.SetBigInt(new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+
+	cases := []struct {
+		name string
+		e    fr.Element
+		hex  string
+	}{
+		{"zero", fr.Element{}, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"one", fr.One(), "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		{"modulus minus one", maxMinusOne, "0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FrToUint256Hex(tc.e); got != tc.hex {
+				t.Fatalf("encoded %s, want %s", got, tc.hex)
+			}
+
+			e, err := Uint256HexToFr(tc.hex)
+			if err != nil {
+				t.Fatalf("unable to decode: %v", err)
+			}
+			if !e.Equal(&tc.e) {
+				t.Fatalf("decoded %s, want %s", e.String(), tc.e.String())
+			}
+		})
+	}
+
+	if e, err := Uint256HexToFr("0x2a"); err != nil || e.Uint64() != 42 {
+		t.Fatalf("short hex decoded to %s, %v", e.String(), err)
+	}
+	if e, err := Uint256HexToFr("0xabc"); err != nil || e.Uint64() != 0xabc {
+		t.Fatalf("odd length hex decoded to %s, %v", e.String(), err)
+	}
+
+	modulus := "0x" + fr.Modulus().Text(16)
+	if _, err := Uint256HexToFr(modulus); !errors.Is(err, ErrNotInField) {
+		t.Fatalf("expected %v, got %v", ErrNotInField, err)
+	}
+	for _, s := range []string{"", "0x", "2a", "0xzz", "0x-1", "0x+5", "0x" + modulus[2:] + "00"} {
+		if _, err := Uint256HexToFr(s); !errors.Is(err, ErrInvalidUint256) {
+			t.Fatalf("%q: expected %v, got %v", s, ErrInvalidUint256, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package verifier
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hblocks/keyless/pkg/zk/verifier/gastest"
+)
+
+// readVerifierBytecode returns the creation bytecode of the verifier contractName of the fixture
+// verifying key, compiled by solc from the source checked in next to it, see go generate.
+func readVerifierBytecode(t *testing.T, contractName string) []byte {
+	t.Helper()
+
+	bin, err := os.ReadFile(filepath.Join("testdata", contractName+".bin"))
+	if err != nil {
+		t.Fatalf("unable to read bytecode: %v", err)
+	}
+	return common.FromHex(string(bytes.TrimSpace(bin)))
+}
+
+// newSquareProof proves knowledge of the square root of 9 and returns the proof, its public
+// inputs and the verifying key.
+func newSquareProof(t *testing.T) (groth16.Proof, []fr.Element, groth16.VerifyingKey) {
+	t.Helper()
+
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	w, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("unable to create witness: %v", err)
+	}
+	proof, err := groth16.Prove(cs, pk, w)
+	if err != nil {
+		t.Fatalf("unable to prove: %v", err)
+	}
+
+	var y fr.Element
+	y.SetUint64(9)
+	return proof, []fr.Element{y}, vk
+}
+
+// readFixture reads the verifying key and the verifyProof arguments of the deterministic proof
+// checked in to testdata, see the fixtures command of keyless.
+func readFixture(t *testing.T) (groth16.VerifyingKey, *ProofCalldata) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "verifying_key.bin"))
+	if err != nil {
+		t.Fatalf("unable to read verifying key: %v", err)
+	}
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	if _, err := vk.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatalf("unable to unmarshal verifying key: %v", err)
+	}
+
+	c, err := ReadProofCalldata(filepath.Join("testdata", ProofCalldataFile))
+	if err != nil {
+		t.Fatalf("unable to read calldata: %v", err)
+	}
+
+	return vk, c
+}
+
+func TestEstimateVerifierGas(t *testing.T) {
+	_, c := readFixture(t)
+
+	calldata, err := c.Pack()
+	if err != nil {
+		t.Fatalf("unable to encode calldata: %v", err)
+	}
+
+	gas, err := gastest.EstimateVerifierGas(t.Context(), readVerifierBytecode(t, DefaultContractName), calldata)
+	if err != nil {
+		t.Fatalf("unable to estimate gas: %v", err)
+	}
+	// a Groth16 check costs four pairings (~180k gas) plus the public input multiplications
+	if gas < 150_000 || gas > 500_000 {
+		t.Fatalf("verifier used %d gas, outside the plausible range", gas)
+	}
+}
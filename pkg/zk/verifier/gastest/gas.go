@@ -0,0 +1,106 @@
+// Package gastest deploys verifier contracts on an in-memory simulated chain to measure the gas
+// their calls use. It pulls in a full go-ethereum node, which is why it is kept out of the verifier
+// package and meant for tests and tooling only.
+package gastest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+var (
+	// ErrDeploymentFailed denotes verifier bytecode whose deployment reverted.
+	ErrDeploymentFailed = errors.New("verifier deployment failed")
+	// ErrProofRejected denotes a verifier call that reverted, i.e. a proof the contract does not accept.
+	ErrProofRejected = errors.New("proof rejected by verifier contract")
+)
+
+// deployerBalance funds the account deploying and calling the verifier on the simulated chain.
+var deployerBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+// EstimateVerifierGas deploys the verifier creation bytecode on an in-memory simulated chain and
+// returns the gas a transaction calling it with calldata would use, including the intrinsic and
+// calldata cost. gnark verifiers revert on invalid proofs, which is reported as ErrProofRejected.
+func EstimateVerifierGas(ctx context.Context, bytecode []byte, calldata []byte) (uint64, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate deployer key: %w", err)
+	}
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{deployer: {Balance: deployerBalance}})
+	defer backend.Close()
+	client := backend.Client()
+
+	contract, err := deploy(ctx, backend, key, bytecode)
+	if err != nil {
+		return 0, err
+	}
+
+	call := ethereum.CallMsg{From: deployer, To: &contract, Data: calldata}
+	if _, err := client.CallContract(ctx, call, nil); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrProofRejected, err)
+	}
+
+	gas, err := client.EstimateGas(ctx, call)
+	if err != nil {
+		return 0, fmt.Errorf("unable to estimate verifier gas: %w", err)
+	}
+
+	return gas, nil
+}
+
+// deploy sends a contract creation transaction with bytecode, mines it and returns the address of the contract.
+func deploy(ctx context.Context, backend *simulated.Backend, key *ecdsa.PrivateKey, bytecode []byte) (common.Address, error) {
+	client := backend.Client()
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unable to get chain id: %w", err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unable to get head: %w", err)
+	}
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{From: deployer, Data: bytecode})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrDeploymentFailed, err)
+	}
+
+	gasTipCap := big.NewInt(1e9)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     0,
+		Gas:       gas,
+		GasTipCap: gasTipCap,
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), gasTipCap),
+		Data:      bytecode,
+	})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unable to sign deployment: %w", err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return common.Address{}, fmt.Errorf("unable to send deployment: %w", err)
+	}
+	backend.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unable to get deployment receipt: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, ErrDeploymentFailed
+	}
+
+	return receipt.ContractAddress, nil
+}
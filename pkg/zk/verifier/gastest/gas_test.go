@@ -0,0 +1,31 @@
+package gastest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEstimateVerifierGasStubContracts(t *testing.T) {
+	// creation code copying a one byte runtime placed right after it into memory and returning it
+	creation := common.FromHex("6001600c60003960016000f3")
+
+	gas, err := EstimateVerifierGas(t.Context(), append(creation, 0x00), []byte{1, 2, 3, 4}) // STOP
+	if err != nil {
+		t.Fatalf("unable to estimate gas: %v", err)
+	}
+	// at least the intrinsic cost plus 16 gas per non-zero calldata byte, and no execution cost to speak of
+	if gas < 21000+4*16 || gas > 22000 {
+		t.Fatalf("stub used %d gas, want about %d", gas, 21000+4*16)
+	}
+
+	reverting := append(common.FromHex("6005600c60003960056000f3"), common.FromHex("60006000fd")...) // REVERT(0, 0)
+	if _, err := EstimateVerifierGas(t.Context(), reverting, nil); !errors.Is(err, ErrProofRejected) {
+		t.Fatalf("expected %v, got %v", ErrProofRejected, err)
+	}
+
+	if _, err := EstimateVerifierGas(t.Context(), common.FromHex("60006000fd"), nil); !errors.Is(err, ErrDeploymentFailed) {
+		t.Fatalf("expected %v, got %v", ErrDeploymentFailed, err)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build ignore
+
+// gen_verifiers writes the Solidity verifiers of the fixture verifying key to testdata, for solc to
+// compile into the bytecode the tests deploy. Run with go generate.
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/hblocks/keyless/pkg/zk/verifier"
+)
+
+func main() {
+	data, err := os.ReadFile(filepath.Join("testdata", "verifying_key.bin"))
+	if err != nil {
+		log.Fatalf("unable to read verifying key: %v", err)
+	}
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	if _, err := vk.ReadFrom(bytes.NewReader(data)); err != nil {
+		log.Fatalf("unable to unmarshal verifying key: %v", err)
+	}
+
+	// the file name must be the contract name, which solc names the bytecode after
+	write(vk, verifier.DefaultContractName)
+	write(vk, "KeylessVerifier",
+		verifier.WithSolidityOptions(verifier.SolidityOptions{ContractName: "KeylessVerifier"}),
+		verifier.WithFunctionName("verifyKeylessProof"))
+}
+
+// write generates the verifier of vk named contractName and writes it to testdata.
+func write(vk groth16.VerifyingKey, contractName string, opts ...verifier.SolidityOption) {
+	var source bytes.Buffer
+	if err := verifier.GenerateSolidityVerifier(&source, vk, opts...); err != nil {
+		log.Fatalf("unable to generate %s: %v", contractName, err)
+	}
+	if err := os.WriteFile(filepath.Join("testdata", contractName+".sol"), source.Bytes(), 0o644); err != nil {
+		log.Fatalf("unable to write %s: %v", contractName, err)
+	}
+}
@@ -0,0 +1,142 @@
+package verifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ProofCalldataFile holds the arguments of a verifyProof call as JSON, see ProofCalldata.
+const ProofCalldataFile = "proof_calldata.json"
+
+// ErrUnsupportedProof denotes a proof that has no Solidity encoding.
+var ErrUnsupportedProof = errors.New("proof has no solidity encoding")
+
+// ProofCalldata holds the arguments of verifyProof(uint256[8],uint256[n]) of a gnark Groth16 BN254
+// verifier without commitments, as 0x-prefixed uint256 hex strings. The JSON form can be pasted into
+// a contract call as is.
+type ProofCalldata struct {
+	Proof [8]string `json:"proof"`
+	Input []string  `json:"input"`
+}
+
+// NewProofCalldata returns the verifyProof arguments of proof and its public inputs.
+func NewProofCalldata(proof groth16.Proof, publicInputs []fr.Element) (*ProofCalldata, error) {
+	p, ok := proof.(interface{ MarshalSolidity() []byte })
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedProof, proof)
+	}
+	words := p.MarshalSolidity()
+	if len(words) != 8*32 {
+		return nil, fmt.Errorf("%w: proof with commitments", ErrUnsupportedProof)
+	}
+
+	c := &ProofCalldata{Input: make([]string, len(publicInputs))}
+	for i := range c.Proof {
+		c.Proof[i] = hexutil.Encode(words[i*32 : (i+1)*32])
+	}
+	for i := range publicInputs {
+		c.Input[i] = FrToUint256Hex(publicInputs[i])
+	}
+
+	return c, nil
+}
+
+// Pack ABI encodes the verifyProof call. Both arguments are static arrays, so the encoding is the
+// selector followed by the proof words and the public inputs.
+func (c *ProofCalldata) Pack() ([]byte, error) {
+	return c.PackFunction(DefaultFunctionName)
+}
+
+// PackFunction is Pack for a verifier whose verification function was renamed, see WithFunctionName.
+func (c *ProofCalldata) PackFunction(name string) ([]byte, error) {
+	signature := fmt.Sprintf("%s(uint256[8],uint256[%d])", name, len(c.Input))
+	calldata := append([]byte{}, crypto.Keccak256([]byte(signature))[:4]...)
+
+	for i, word := range c.Proof {
+		b, err := uint256Bytes(word)
+		if err != nil {
+			return nil, fmt.Errorf("proof word %d: %w", i, err)
+		}
+		calldata = append(calldata, b...)
+	}
+	for i, input := range c.Input {
+		e, err := Uint256HexToFr(input)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		b := e.Bytes()
+		calldata = append(calldata, b[:]...)
+	}
+
+	return calldata, nil
+}
+
+// VerifyProofCalldata ABI encodes a call to verifyProof(uint256[8],uint256[n]) of a gnark Groth16
+// BN254 verifier without commitments.
+func VerifyProofCalldata(proof groth16.Proof, publicInputs []fr.Element) ([]byte, error) {
+	c, err := NewProofCalldata(proof, publicInputs)
+	if err != nil {
+		return nil, err
+	}
+	return c.Pack()
+}
+
+// uint256Bytes decodes a 0x-prefixed uint256 hex string into its 32 byte big endian form.
+func uint256Bytes(s string) ([]byte, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) > 32 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidUint256, s)
+	}
+	return common.LeftPadBytes(b, 32), nil
+}
+
+// ExportProofCalldata writes the verifyProof arguments of proof and its public inputs to
+// ProofCalldataFile in outDir.
+func ExportProofCalldata(proof groth16.Proof, publicInputs []fr.Element, outDir string) error {
+	return ExportProofCalldataTo(proof, publicInputs, func(name string, data []byte) error {
+		return os.WriteFile(filepath.Join(outDir, name), data, 0o644)
+	})
+}
+
+// ExportProofCalldataTo is ExportProofCalldata writing the artifact with write, e.g. a
+// prover.WriteFileFunc keeping it in memory or uploading it.
+func ExportProofCalldataTo(proof groth16.Proof, publicInputs []fr.Element, write func(name string, data []byte) error) error {
+	c, err := NewProofCalldata(proof, publicInputs)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal proof calldata: %w", err)
+	}
+	if err := write(ProofCalldataFile, data); err != nil {
+		return fmt.Errorf("unable to write proof calldata: %w", err)
+	}
+
+	return nil
+}
+
+// ReadProofCalldata reads the verifyProof arguments written by ExportProofCalldata.
+func ReadProofCalldata(path string) (*ProofCalldata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read proof calldata: %w", err)
+	}
+
+	var c ProofCalldata
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal proof calldata: %w", err)
+	}
+
+	return &c, nil
+}
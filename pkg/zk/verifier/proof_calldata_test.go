@@ -0,0 +1,120 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/hblocks/keyless/pkg/zk/verifier/gastest"
+)
+
+func TestProofCalldataRoundTrip(t *testing.T) {
+	proof, inputs, _ := newSquareProof(t)
+
+	dir := t.TempDir()
+	if err := ExportProofCalldata(proof, inputs, dir); err != nil {
+		t.Fatalf("unable to export calldata: %v", err)
+	}
+	c, err := ReadProofCalldata(filepath.Join(dir, ProofCalldataFile))
+	if err != nil {
+		t.Fatalf("unable to read calldata: %v", err)
+	}
+
+	fromFile, err := c.Pack()
+	if err != nil {
+		t.Fatalf("unable to pack calldata: %v", err)
+	}
+	direct, err := VerifyProofCalldata(proof, inputs)
+	if err != nil {
+		t.Fatalf("unable to encode calldata: %v", err)
+	}
+	if !bytes.Equal(fromFile, direct) {
+		t.Fatal("calldata read from file differs from the direct encoding")
+	}
+	if len(direct) != 4+9*32 {
+		t.Fatalf("calldata is %d bytes, want %d", len(direct), 4+9*32)
+	}
+
+	c.Proof[3] = "0x" + string(bytes.Repeat([]byte("ff"), 33))
+	if _, err := c.Pack(); !errors.Is(err, ErrInvalidUint256) {
+		t.Fatalf("expected %v, got %v", ErrInvalidUint256, err)
+	}
+}
+
+// verifierCalls returns calldata the verifier must accept, the valid fixture call, and calldata it
+// must reject, by name.
+func verifierCalls(t *testing.T, c *ProofCalldata) (valid []byte, invalid map[string][]byte) {
+	t.Helper()
+
+	valid, err := c.Pack()
+	if err != nil {
+		t.Fatalf("unable to pack calldata: %v", err)
+	}
+
+	// claim another commitment
+	tampered := *c
+	tampered.Input = append([]string(nil), c.Input...)
+	tampered.Input[0] = "0x" + strings.Repeat("0", 63) + "a"
+	tamperedCalldata, err := tampered.Pack()
+	if err != nil {
+		t.Fatalf("unable to pack tampered calldata: %v", err)
+	}
+
+	// the same last input plus the modulus, which ECMUL would silently reduce; Pack refuses to
+	// encode it, so the word is patched in
+	unreduced := append([]byte(nil), valid...)
+	last := new(big.Int).SetBytes(unreduced[len(unreduced)-32:])
+	last.Add(last, fr.Modulus()).FillBytes(unreduced[len(unreduced)-32:])
+
+	// B with its coordinates in the order of gnark rather than of the pairing precompile
+	swapped := append([]byte(nil), valid...)
+	b := swapped[4+2*32 : 4+6*32]
+	for _, i := range []int{0, 64} {
+		x := append([]byte(nil), b[i:i+32]...)
+		copy(b[i:i+32], b[i+32:i+64])
+		copy(b[i+32:i+64], x)
+	}
+
+	return valid, map[string][]byte{
+		"tampered input":   tamperedCalldata,
+		"unreduced input":  unreduced,
+		"swapped B":        swapped,
+		"short calldata":   valid[:len(valid)-1],
+		"unknown selector": append([]byte{0xde, 0xad, 0xbe, 0xef}, valid[4:]...),
+	}
+}
+
+// TestSolidityVerifierEndToEnd checks that the verifier generated for the fixture verifying key,
+// compiled by solc to testdata/Verifier.bin, accepts the fixture proof exported to
+// proof_calldata.json and rejects tampered ones, i.e. on-chain and off-chain agree.
+func TestSolidityVerifierEndToEnd(t *testing.T) {
+	vk, c := readFixture(t)
+
+	var source bytes.Buffer
+	if err := GenerateSolidityVerifier(&source, vk); err != nil {
+		t.Fatalf("unable to generate verifier: %v", err)
+	}
+	checkedIn, err := os.ReadFile(filepath.Join("testdata", DefaultContractName+".sol"))
+	if err != nil {
+		t.Fatalf("unable to read verifier source: %v", err)
+	}
+	if !bytes.Equal(checkedIn, source.Bytes()) {
+		t.Fatal("testdata/Verifier.sol is stale, run go generate")
+	}
+	bytecode := readVerifierBytecode(t, DefaultContractName)
+
+	valid, invalid := verifierCalls(t, c)
+	if _, err := gastest.EstimateVerifierGas(t.Context(), bytecode, valid); err != nil {
+		t.Fatalf("verifier rejected a valid proof: %v", err)
+	}
+	for name, calldata := range invalid {
+		if _, err := gastest.EstimateVerifierGas(t.Context(), bytecode, calldata); !errors.Is(err, gastest.ErrProofRejected) {
+			t.Fatalf("%s: expected %v, got %v", name, gastest.ErrProofRejected, err)
+		}
+	}
+}
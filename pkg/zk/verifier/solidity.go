@@ -0,0 +1,153 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/consensys/gnark"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/solidity"
+)
+
+var (
+	// ErrInvalidContractName denotes a contract name that is not a Solidity identifier.
+	ErrInvalidContractName = errors.New("invalid solidity contract name")
+	// ErrInvalidFunctionName denotes a function name that is not a Solidity identifier.
+	ErrInvalidFunctionName = errors.New("invalid solidity function name")
+)
+
+const (
+	// DefaultLicense is the SPDX license identifier of the gnark verifier template.
+	DefaultLicense = "MIT"
+	// DefaultContractName is the name of the contract in the gnark verifier template.
+	DefaultContractName = "Verifier"
+	// DefaultFunctionName is the name of the verification function in the gnark verifier template.
+	DefaultFunctionName = "verifyProof"
+)
+
+var (
+	solidityIdentifier = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+	// spdxLine and contractLine match the license and contract declaration of the gnark template.
+	spdxLine     = regexp.MustCompile(`(?m)^// SPDX-License-Identifier:.*\n`)
+	contractLine = regexp.MustCompile(`(?m)^contract ` + DefaultContractName + ` \{`)
+	// functionCall matches the declaration of, or a call to, the verification function.
+	functionCall = regexp.MustCompile(`\b` + DefaultFunctionName + `(\s*\()`)
+)
+
+// SolidityOptions sets the header and name of the generated verifier. Empty fields keep the
+// defaults: the pragma of gnark, DefaultLicense and DefaultContractName.
+type SolidityOptions struct {
+	Pragma       string // version constraint of the pragma solidity directive, e.g. "0.8.24"
+	License      string // SPDX license identifier, e.g. "Apache-2.0"
+	ContractName string
+}
+
+type solidityConfig struct {
+	exportOptions []solidity.ExportOption
+	license       string
+	contractName  string
+	functionName  string
+}
+
+// SolidityOption configures the generated Solidity verifier.
+type SolidityOption interface {
+	apply(*solidityConfig)
+}
+
+type solidityOptionFunc func(*solidityConfig)
+
+func (f solidityOptionFunc) apply(c *solidityConfig) { f(c) }
+
+// WithExportOptions passes options such as solidity.WithPragmaVersion through to gnark.
+func WithExportOptions(opts ...solidity.ExportOption) SolidityOption {
+	return solidityOptionFunc(func(c *solidityConfig) {
+		c.exportOptions = append(c.exportOptions, opts...)
+	})
+}
+
+// WithSolidityOptions sets the pragma, SPDX license and contract name of the verifier.
+func WithSolidityOptions(opts SolidityOptions) SolidityOption {
+	return solidityOptionFunc(func(c *solidityConfig) {
+		if opts.Pragma != "" {
+			c.exportOptions = append(c.exportOptions, solidity.WithPragmaVersion(opts.Pragma))
+		}
+		if opts.License != "" {
+			c.license = opts.License
+		}
+		if opts.ContractName != "" {
+			c.contractName = opts.ContractName
+		}
+	})
+}
+
+// WithFunctionName renames the verification function, verifyProof in the gnark template, e.g. to
+// avoid a clash with a contract inheriting the verifier. Calls must then be encoded with
+// ProofCalldata.PackFunction.
+func WithFunctionName(name string) SolidityOption {
+	return solidityOptionFunc(func(c *solidityConfig) {
+		c.functionName = name
+	})
+}
+
+// GenerateSolidityVerifier writes a Solidity contract verifying Groth16 proofs for vk. The SPDX
+// license identifier comes first, as solc expects, followed by a comment header recording the
+// gnark version it was generated with.
+func GenerateSolidityVerifier(w io.Writer, vk groth16.VerifyingKey, opts ...SolidityOption) error {
+	cfg := solidityConfig{
+		license:      DefaultLicense,
+		contractName: DefaultContractName,
+		functionName: DefaultFunctionName,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	if !solidityIdentifier.MatchString(cfg.contractName) {
+		return fmt.Errorf("%w: %q", ErrInvalidContractName, cfg.contractName)
+	}
+	if !solidityIdentifier.MatchString(cfg.functionName) {
+		return fmt.Errorf("%w: %q", ErrInvalidFunctionName, cfg.functionName)
+	}
+
+	var exported bytes.Buffer
+	if err := vk.ExportSolidity(&exported, cfg.exportOptions...); err != nil {
+		return fmt.Errorf("unable to export solidity verifier: %w", err)
+	}
+
+	// the template declares its own license and name; replace them with the configured ones
+	contract := bytes.TrimLeft(spdxLine.ReplaceAll(exported.Bytes(), nil), "\n")
+	contract = contractLine.ReplaceAllLiteral(contract, []byte("contract "+cfg.contractName+" {"))
+	if cfg.functionName != DefaultFunctionName {
+		contract = renameFunction(contract, cfg.functionName)
+	}
+
+	if _, err := fmt.Fprintf(w, "// SPDX-License-Identifier: %s\n// Groth16 verifier generated with gnark %s\n\n",
+		cfg.license, gnark.Version); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+	if _, err := w.Write(contract); err != nil {
+		return fmt.Errorf("unable to write solidity verifier: %w", err)
+	}
+
+	return nil
+}
+
+// renameFunction renames the declaration of and calls to the verification function in source. Only
+// code is rewritten: the rest of a line from // on is a comment and left untouched, so the NatSpec
+// of the template keeps referring to verifyProof. The template has no block comments or string
+// literals containing //.
+func renameFunction(source []byte, name string) []byte {
+	lines := bytes.SplitAfter(source, []byte("\n"))
+	for i, line := range lines {
+		code, comment := line, []byte(nil)
+		if j := bytes.Index(line, []byte("//")); j >= 0 {
+			code, comment = line[:j], line[j:]
+		}
+		code = functionCall.ReplaceAll(code, []byte(name+"$1"))
+		lines[i] = append(code[:len(code):len(code)], comment...)
+	}
+	return bytes.Join(lines, nil)
+}
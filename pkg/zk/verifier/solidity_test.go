@@ -0,0 +1,147 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/hblocks/keyless/pkg/zk/verifier/gastest"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func newTestVerifyingKey(t *testing.T) groth16.VerifyingKey {
+	t.Helper()
+
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("unable to compile circuit: %v", err)
+	}
+	_, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("unable to run setup: %v", err)
+	}
+	return vk
+}
+
+func TestGenerateSolidityVerifierOptions(t *testing.T) {
+	vk := newTestVerifyingKey(t)
+
+	var source bytes.Buffer
+	if err := GenerateSolidityVerifier(&source, vk, WithSolidityOptions(SolidityOptions{
+		Pragma:       "0.8.24",
+		License:      "Apache-2.0",
+		ContractName: "KeylessVerifier",
+	})); err != nil {
+		t.Fatalf("unable to generate verifier: %v", err)
+	}
+
+	out := source.String()
+	if !strings.HasPrefix(out, "// SPDX-License-Identifier: Apache-2.0\n") {
+		t.Fatalf("verifier does not start with the SPDX header:\n%s", out[:min(len(out), 200)])
+	}
+	if n := strings.Count(out, "SPDX-License-Identifier"); n != 1 {
+		t.Fatalf("verifier declares %d licenses, want 1", n)
+	}
+	for _, want := range []string{"pragma solidity 0.8.24;", "contract KeylessVerifier {"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("verifier does not contain %q", want)
+		}
+	}
+	if strings.Contains(out, "contract Verifier {") {
+		t.Fatal("verifier still declares the template contract name")
+	}
+
+	var standard bytes.Buffer
+	if err := GenerateSolidityVerifier(&standard, vk); err != nil {
+		t.Fatalf("unable to generate default verifier: %v", err)
+	}
+	if !strings.HasPrefix(standard.String(), "// SPDX-License-Identifier: "+DefaultLicense+"\n") ||
+		!strings.Contains(standard.String(), "contract "+DefaultContractName+" {") {
+		t.Fatal("default verifier does not keep the template license and name")
+	}
+
+	err := GenerateSolidityVerifier(&bytes.Buffer{}, vk, WithSolidityOptions(SolidityOptions{ContractName: "1Verifier"}))
+	if !errors.Is(err, ErrInvalidContractName) {
+		t.Fatalf("expected %v, got %v", ErrInvalidContractName, err)
+	}
+}
+
+func TestRenameFunction(t *testing.T) {
+	source := "    /// Same order as verifyProof(proof, input).\n" +
+		"    function verifyProof(uint256[8] calldata proof) public view {\n" +
+		"        this.verifyProof (proof); // calls verifyProof again\n" +
+		"        verifyProofs(proof);\n"
+	want := "    /// Same order as verifyProof(proof, input).\n" +
+		"    function verifyKeyless(uint256[8] calldata proof) public view {\n" +
+		"        this.verifyKeyless (proof); // calls verifyProof again\n" +
+		"        verifyProofs(proof);\n"
+
+	if got := string(renameFunction([]byte(source), "verifyKeyless")); got != want {
+		t.Fatalf("renamed source:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateSolidityVerifierFunctionName(t *testing.T) {
+	fixture, c := readFixture(t)
+
+	var source bytes.Buffer
+	if err := GenerateSolidityVerifier(&source, fixture,
+		WithSolidityOptions(SolidityOptions{ContractName: "KeylessVerifier"}),
+		WithFunctionName("verifyKeylessProof"),
+	); err != nil {
+		t.Fatalf("unable to generate verifier: %v", err)
+	}
+	out := source.String()
+	if !strings.Contains(out, "function verifyKeylessProof(") {
+		t.Fatal("verifier does not declare the renamed function")
+	}
+	if strings.Contains(out, "function "+DefaultFunctionName+"(") {
+		t.Fatal("verifier still declares the original function")
+	}
+
+	if err := GenerateSolidityVerifier(&bytes.Buffer{}, fixture, WithFunctionName("verify-proof")); !errors.Is(err, ErrInvalidFunctionName) {
+		t.Fatalf("expected %v, got %v", ErrInvalidFunctionName, err)
+	}
+
+	// the bytecode checked in to testdata must be compiled from this very source
+	checkedIn, err := os.ReadFile(filepath.Join("testdata", "KeylessVerifier.sol"))
+	if err != nil {
+		t.Fatalf("unable to read verifier source: %v", err)
+	}
+	if !bytes.Equal(checkedIn, source.Bytes()) {
+		t.Fatal("testdata/KeylessVerifier.sol is stale, run go generate")
+	}
+	bytecode := readVerifierBytecode(t, "KeylessVerifier")
+
+	calldata, err := c.PackFunction("verifyKeylessProof")
+	if err != nil {
+		t.Fatalf("unable to pack calldata: %v", err)
+	}
+	if _, err := gastest.EstimateVerifierGas(t.Context(), bytecode, calldata); err != nil {
+		t.Fatalf("renamed function rejected the proof: %v", err)
+	}
+
+	calldata, err = c.Pack()
+	if err != nil {
+		t.Fatalf("unable to pack calldata: %v", err)
+	}
+	if _, err := gastest.EstimateVerifierGas(t.Context(), bytecode, calldata); !errors.Is(err, gastest.ErrProofRejected) {
+		t.Fatalf("expected %v for the original selector, got %v", gastest.ErrProofRejected, err)
+	}
+}
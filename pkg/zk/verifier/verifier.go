@@ -1 +1,7 @@
 package verifier
+
+// The fixtures in testdata are a deterministic proof and verifying key, along with the Solidity
+// verifiers of the key and their bytecode compiled with solc, regenerated with:
+//go:generate go run ../../.. fixtures -out testdata
+//go:generate go run gen_verifiers.go
+//go:generate solc --optimize --bin --overwrite -o testdata testdata/Verifier.sol testdata/KeylessVerifier.sol